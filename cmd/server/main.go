@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,10 +12,18 @@ import (
 
 	"github.com/t1nyb0x/jamberry/internal/bot"
 	"github.com/t1nyb0x/jamberry/internal/config"
+	"github.com/t1nyb0x/jamberry/internal/domain"
 	"github.com/t1nyb0x/jamberry/internal/handler"
+	"github.com/t1nyb0x/jamberry/internal/health"
+	"github.com/t1nyb0x/jamberry/internal/httpserver"
 	"github.com/t1nyb0x/jamberry/internal/infrastructure/cache"
+	"github.com/t1nyb0x/jamberry/internal/infrastructure/servicelink"
+	"github.com/t1nyb0x/jamberry/internal/infrastructure/shortlink"
+	"github.com/t1nyb0x/jamberry/internal/infrastructure/sqlite"
 	"github.com/t1nyb0x/jamberry/internal/infrastructure/tracktaste"
+	"github.com/t1nyb0x/jamberry/internal/linkresolver"
 	"github.com/t1nyb0x/jamberry/internal/logger"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
 	"github.com/t1nyb0x/jamberry/internal/ratelimit"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
@@ -32,25 +42,84 @@ func main() {
 	slog.Info("starting jamberry", "log_level", cfg.LogLevel)
 
 	// Botの作成
-	b, err := bot.New(cfg.DiscordBotToken)
+	b, err := bot.New(cfg.DiscordBotToken, cfg.AutoLinkExpand)
 	if err != nil {
 		slog.Error("failed to create bot", "error", err)
 		os.Exit(1)
 	}
 
 	// インフラ層の作成
-	ttClient := tracktaste.NewClient(cfg.TrackTasteAPIURL)
-	cacheManager := cache.NewManager(cfg.RedisURL)
+	retryConfig := tracktaste.DefaultRetryConfig()
+	retryConfig.MaxRetries = cfg.TrackTasteRetries
+	ttClient := tracktaste.NewClientWithRetry(cfg.TrackTasteAPIURL, retryConfig)
+	cacheManager := cache.NewManager(cfg.RedisURL, cache.L1Config{
+		MaxEntries: cfg.L1CacheMaxEntries,
+		MaxBytes:   cfg.L1CacheMaxBytes,
+	})
 	defer cacheManager.Close()
 
-	limiter := ratelimit.NewLimiter()
+	db, err := sqlite.Open(cfg.DatabasePath)
+	if err != nil {
+		slog.Error("failed to open database", "path", cfg.DatabasePath, "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Warn("failed to close database", "error", err)
+		}
+	}()
+	favoritesStore := sqlite.NewFavoritesStore(db)
+	historyStore := sqlite.NewHistoryStore(db)
+	guildSettingsStore := sqlite.NewGuildSettingsStore(db)
+
+	// レートリミッター（Redisで複数インスタンス間のウィンドウを共有し、障害時はインメモリで判定する）
+	memLimiter := ratelimit.NewLimiterWithQuota(ratelimit.Quota{
+		UserMax:  ratelimit.MaxRequests,
+		GuildMax: cfg.RateLimitGuildMax,
+	})
+	var limiter ratelimit.RateLimiter = memLimiter
+	redisLimiter, err := ratelimit.NewRedisLimiter(cfg.RedisURL, memLimiter)
+	if err != nil {
+		slog.Warn("failed to create redis rate limiter, using in-memory limiter", "error", err)
+	} else {
+		limiter = redisLimiter
+		defer redisLimiter.Close()
+	}
+
+	// トラック・アーティスト・アルバムの取得結果はL1/L2キャッシュに保存し、tracktaste 障害時は古いデータで応答する
+	musicRepo := cache.NewMusicRepository(ttClient, cacheManager, cache.DefaultEntityTTL())
+
+	// Spotify の短縮URL（spotify.link など）の展開と、他サービス（Apple Music など）のリンクの Spotify のURLへの対応付け
+	shortLinkResolver := shortlink.NewResolver(shortlink.NewHTTPClient(shortlink.DefaultTimeout), shortlink.Config{})
+	linkResolver := linkresolver.NewResolver(
+		shortLinkResolver,
+		linkresolver.NewHTTPClient(linkresolver.DefaultTimeout),
+		ttClient,
+		linkresolver.Config{},
+	)
+
+	// トラック・アルバムを他のサービス（Apple Music など）で開くリンクの検索（結果はキャッシュに保存）
+	serviceLinkClient := servicelink.NewHTTPClient(servicelink.DefaultTimeout)
+	serviceLinkers := []domain.ServiceLinker{
+		servicelink.NewAppleMusic(serviceLinkClient, ""),
+		servicelink.NewYouTubeMusic(),
+		servicelink.NewDeezer(serviceLinkClient),
+	}
+	if cfg.KKBoxClientID != "" && cfg.KKBoxClientSecret != "" {
+		serviceLinkers = append(serviceLinkers, servicelink.NewKKBox(serviceLinkClient, cfg.KKBoxClientID, cfg.KKBoxClientSecret, ""))
+	}
+	serviceLinks := servicelink.NewResolver(cacheManager, servicelink.Config{}, serviceLinkers...)
 
 	// ユースケース層の作成
-	trackUC := usecase.NewTrackUseCase(ttClient)
-	artistUC := usecase.NewArtistUseCase(ttClient)
-	albumUC := usecase.NewAlbumUseCase(ttClient)
-	recommendUC := usecase.NewRecommendUseCase(ttClient)
-	searchUC := usecase.NewSearchUseCase(ttClient)
+	trackUC := usecase.NewTrackUseCase(musicRepo)
+	artistUC := usecase.NewArtistUseCase(musicRepo)
+	albumUC := usecase.NewAlbumUseCase(musicRepo)
+	recommendUC := usecase.NewRecommendUseCase(musicRepo, musicRepo, musicRepo)
+	searchUC := usecase.NewSearchUseCase(musicRepo, musicRepo, musicRepo)
+	playlistUC := usecase.NewPlaylistUseCase(musicRepo)
+	favoritesUC := usecase.NewFavoritesUseCase(favoritesStore, musicRepo, musicRepo, musicRepo)
+	historyUC := usecase.NewHistoryUseCase(historyStore, cfg.HistoryMaxEntries)
+	guildSettingsUC := usecase.NewGuildSettingsUseCase(guildSettingsStore)
 
 	// ハンドラーの作成
 	h := handler.NewHandler(
@@ -59,17 +128,54 @@ func main() {
 		albumUC,
 		recommendUC,
 		searchUC,
+		playlistUC,
+		favoritesUC,
+		historyUC,
+		guildSettingsUC,
 		cacheManager,
 		limiter,
+		ratelimit.DefaultCosts().Merge(cfg.RateLimitCosts),
 		ttClient,
+		linkResolver,
+		serviceLinks,
+		handler.AutoLinkConfig{
+			Enabled:  cfg.AutoLinkExpand,
+			MaxLinks: cfg.AutoLinkMaxLinks,
+		},
+		handler.LocaleConfig{
+			Default: cfg.DefaultLocale,
+			Guilds:  cfg.GuildLocales,
+		},
 	)
 
 	// インタラクションハンドラーの登録
 	b.AddHandler(h.HandleInteraction)
 
-	// メンションハンドラーの登録
+	// メッセージハンドラーの登録（メンション応答・リンク自動展開）
 	b.AddHandler(h.HandleMessageCreate)
 
+	// readinessチェック対象の登録
+	checker := health.NewChecker()
+	checker.Add("discord", func(ctx context.Context) error {
+		if !b.Connected() {
+			return errors.New("discord session is not connected")
+		}
+		return nil
+	})
+	checker.Add("redis", func(ctx context.Context) error {
+		if !cacheManager.RedisOK() {
+			return errors.New("redis is unavailable")
+		}
+		return nil
+	})
+	checker.Add("tracktaste", func(ctx context.Context) error {
+		_, err := ttClient.FetchHealth(ctx)
+		return err
+	})
+
+	// 運用向けHTTPサーバーの起動（HEALTH_ADDR / METRICS_ADDR 設定時のみ）
+	httpServers := startHTTPServers(cfg, checker)
+
 	// Botの起動
 	if err := b.Start(); err != nil {
 		slog.Error("failed to start bot", "error", err)
@@ -88,9 +194,15 @@ func main() {
 	// L1キャッシュのクリーンアップ
 	cacheManager.StartL1Cleanup(ctx, 5*time.Minute)
 
+	// Redisの自動再接続
+	cacheManager.StartRedisHealthCheck(ctx, 15*time.Second)
+
+	// tracktaste 障害時に古いデータを返したエンティティの再取得（サーキットブレーカーの復旧待ちと同じ間隔）
+	musicRepo.StartStaleRefresh(ctx, 30*time.Second)
+
 	// レートリミッターのクリーンアップ
 	done := make(chan struct{})
-	limiter.StartCleanup(done, 30*time.Second)
+	memLimiter.StartCleanup(done, 30*time.Second)
 
 	slog.Info("jamberry is now running. Press CTRL+C to exit.")
 
@@ -104,6 +216,44 @@ func main() {
 	// クリーンアップ
 	cancel()
 	close(done)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	for _, srv := range httpServers {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("failed to shutdown http server", "error", err)
+		}
+	}
+	shutdownCancel()
 
 	slog.Info("jamberry has been shut down")
 }
+
+// startHTTPServers は設定されたアドレスごとに運用向けHTTPサーバーを起動します
+// HEALTH_ADDR と METRICS_ADDR が同じ場合は1つのリスナーで両方を公開します
+func startHTTPServers(cfg *config.Config, checker *health.Checker) []*httpserver.Server {
+	muxes := make(map[string]*http.ServeMux)
+	muxFor := func(addr string) *http.ServeMux {
+		if mux, ok := muxes[addr]; ok {
+			return mux
+		}
+		mux := http.NewServeMux()
+		muxes[addr] = mux
+		return mux
+	}
+
+	if cfg.HealthAddr != "" {
+		mux := muxFor(cfg.HealthAddr)
+		mux.Handle("/healthz", checker.LivenessHandler())
+		mux.Handle("/readyz", checker.ReadinessHandler())
+	}
+	if cfg.MetricsAddr != "" {
+		muxFor(cfg.MetricsAddr).Handle("/metrics", metrics.Handler())
+	}
+
+	servers := make([]*httpserver.Server, 0, len(muxes))
+	for addr, mux := range muxes {
+		srv := httpserver.New(addr, mux)
+		srv.Start()
+		servers = append(servers, srv)
+	}
+	return servers
+}
@@ -2,6 +2,7 @@ package bot
 
 import (
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/version"
@@ -9,23 +10,46 @@ import (
 
 // Bot はDiscord Botを表します
 type Bot struct {
-	session  *discordgo.Session
-	commands []*discordgo.ApplicationCommand
+	session   *discordgo.Session
+	commands  []*discordgo.ApplicationCommand
+	connected atomic.Bool // Gatewayに接続済みか（Ready/Resumedでtrue、Disconnectでfalse）
 }
 
 // New は新しいBotを作成します
-func New(token string) (*Bot, error) {
+// messageContent が true の場合、メッセージ本文の取得に必要な MessageContent インテント（特権インテント）を要求します
+func New(token string, messageContent bool) (*Bot, error) {
 	session, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return nil, err
 	}
 
 	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages
+	if messageContent {
+		session.Identify.Intents |= discordgo.IntentsMessageContent
+	}
 
-	return &Bot{
+	b := &Bot{
 		session:  session,
 		commands: Commands(),
-	}, nil
+	}
+
+	// Gatewayの接続状態を追跡（readinessチェック用）
+	session.AddHandler(func(_ *discordgo.Session, _ *discordgo.Ready) {
+		b.connected.Store(true)
+	})
+	session.AddHandler(func(_ *discordgo.Session, _ *discordgo.Resumed) {
+		b.connected.Store(true)
+	})
+	session.AddHandler(func(_ *discordgo.Session, _ *discordgo.Disconnect) {
+		b.connected.Store(false)
+	})
+
+	return b, nil
+}
+
+// Connected はDiscord Gatewayに接続済みかどうかを返します
+func (b *Bot) Connected() bool {
+	return b.connected.Load()
 }
 
 // Session はDiscordセッションを返します
@@ -2,16 +2,32 @@ package bot
 
 import (
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 // Commands はスラッシュコマンドの定義を返します
+// 説明と選択肢名は日本語をデフォルトとし、i18n のカタログにある言語の訳を設定します
 func Commands() []*discordgo.ApplicationCommand {
+	return localizeCommands(commands())
+}
+
+// commands は日本語のスラッシュコマンドの定義を返します
+func commands() []*discordgo.ApplicationCommand {
 	urlOption := &discordgo.ApplicationCommandOption{
 		Type:        discordgo.ApplicationCommandOptionString,
 		Name:        "url",
 		Description: "Spotify の URL, URI, または ID を入力",
 		Required:    true,
 	}
+	// トラックを指定するサブコマンドはキーワードの入力中に検索結果を候補として表示する
+	trackURLOption := &discordgo.ApplicationCommandOption{
+		Type:         discordgo.ApplicationCommandOptionString,
+		Name:         "url",
+		Description:  "Spotify の URL, URI, ID を入力（キーワードを入力すると候補を表示）",
+		Required:     true,
+		Autocomplete: true,
+	}
 
 	return []*discordgo.ApplicationCommand{
 		{
@@ -23,7 +39,7 @@ func Commands() []*discordgo.ApplicationCommand {
 					Name:        "track",
 					Description: "トラックの詳細情報を取得します",
 					Options: []*discordgo.ApplicationCommandOption{
-						urlOption,
+						trackURLOption,
 					},
 				},
 				{
@@ -44,10 +60,18 @@ func Commands() []*discordgo.ApplicationCommand {
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
-					Name:        "recommend",
-					Description: "トラックに基づくおすすめ楽曲を取得します",
+					Name:        "playlist",
+					Description: "プレイリストの詳細情報と収録曲を取得します",
 					Options: []*discordgo.ApplicationCommandOption{
 						urlOption,
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "recommend",
+					Description: "トラック・アルバム・プレイリストに基づくおすすめ楽曲を取得します",
+					Options: []*discordgo.ApplicationCommandOption{
+						trackURLOption,
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
 							Name:        "mode",
@@ -73,7 +97,7 @@ func Commands() []*discordgo.ApplicationCommand {
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "search",
-					Description: "トラックを検索します",
+					Description: "トラック・アーティスト・アルバムを検索します",
 					Options: []*discordgo.ApplicationCommandOption{
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
@@ -81,8 +105,103 @@ func Commands() []*discordgo.ApplicationCommand {
 							Description: "検索キーワードを入力",
 							Required:    true,
 						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "type",
+							Description: "検索する種別（省略時はトラック）",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{
+									Name:  "トラック",
+									Value: "track",
+								},
+								{
+									Name:  "アーティスト",
+									Value: "artist",
+								},
+								{
+									Name:  "アルバム",
+									Value: "album",
+								},
+								{
+									Name:  "すべて",
+									Value: "all",
+								},
+							},
+						},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "fav",
+					Description: "お気に入りを管理します",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "add",
+							Description: "トラック・アーティスト・アルバムをお気に入りに登録します",
+							Options: []*discordgo.ApplicationCommandOption{
+								urlOption,
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "type",
+									Description: "ID のみを入力した場合の種別（省略時はトラック）",
+									Required:    false,
+									Choices: []*discordgo.ApplicationCommandOptionChoice{
+										{
+											Name:  "トラック",
+											Value: "track",
+										},
+										{
+											Name:  "アーティスト",
+											Value: "artist",
+										},
+										{
+											Name:  "アルバム",
+											Value: "album",
+										},
+									},
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "お気に入りの一覧を表示します",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "remove",
+							Description: "お気に入りから削除します",
+							Options: []*discordgo.ApplicationCommandOption{
+								urlOption,
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "history",
+					Description: "コマンドの実行履歴を管理します",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "実行履歴を表示し、ボタンから再実行します",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "clear",
+							Description: "実行履歴をすべて削除します",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "config",
+					Description: "サーバーごとの設定を管理します（サーバー管理権限が必要です）",
+					Options:     configOptions(),
+				},
 			},
 		},
 		{
@@ -93,5 +212,168 @@ func Commands() []*discordgo.ApplicationCommand {
 			Name:        "help",
 			Description: "jamberry のヘルプを表示します",
 		},
+		// メッセージのコンテキストメニュー（メッセージ中の最初の Spotify リンクを対象にする）
+		{
+			Type: discordgo.MessageApplicationCommand,
+			Name: "Spotify の情報を表示",
+		},
+		{
+			Type: discordgo.MessageApplicationCommand,
+			Name: "この曲でおすすめを表示",
+		},
+	}
+}
+
+// configOptions は /jam config のサブコマンドの定義を返します
+func configOptions() []*discordgo.ApplicationCommandOption {
+	minPageSize := float64(usecase.MinPageSize)
+
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "show",
+			Description: "現在のサーバー設定を表示します",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "mode",
+			Description: "レコメンドのデフォルトモードを設定します",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "モードを指定しない /jam recommend で使うモード",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "バランス", Value: "balanced"},
+						{Name: "雰囲気重視", Value: "similar"},
+						{Name: "関連性重視", Value: "related"},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "page-size",
+			Description: "一覧の1ページあたりの表示件数を設定します",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "size",
+					Description: "1ページあたりの表示件数",
+					Required:    true,
+					MinValue:    &minPageSize,
+					MaxValue:    usecase.MaxPageSize,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "autolink",
+			Description: "メッセージ中の Spotify リンクの自動展開を切り替えます",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "enabled",
+					Description: "自動展開を有効にするか",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "language",
+			Description: "Discord の言語設定が日本語・英語以外のメンバーへの応答言語を設定します",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "language",
+					Description: "応答言語",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "自動（Discord のサーバーの言語）", Value: "auto"},
+						{Name: "日本語", Value: "ja"},
+						{Name: "English", Value: "en"},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "detail",
+			Description: "検索・レコメンドの一覧から選択した曲の詳細を表示する範囲を設定します",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "visibility",
+					Description: "詳細を表示する範囲",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "自分のみ", Value: "ephemeral"},
+						{Name: "チャンネル全体", Value: "public"},
+					},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "channel",
+			Description: "コマンドを利用できるチャンネルを設定します（未設定の場合は全チャンネル）",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "action",
+					Description: "操作",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "追加", Value: "add"},
+						{Name: "削除", Value: "remove"},
+						{Name: "すべて解除", Value: "clear"},
+					},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "対象のチャンネル（追加・削除の場合）",
+					Required:     false,
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reset",
+			Description: "サーバー設定をすべてデフォルトに戻します",
+		},
+	}
+}
+
+// localizeCommands はコマンドの説明と選択肢名に Discord のロケールごとの訳を設定します
+// コマンド名・オプション名はヘルプや履歴で参照するため、どの言語でも同じ名前のままにします
+// 説明のないコンテキストメニューのコマンドは、メニューに表示される名前に訳を設定します
+func localizeCommands(cmds []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	for _, cmd := range cmds {
+		if cmd.Type == discordgo.MessageApplicationCommand {
+			if localizations := i18n.DiscordLocalizations(cmd.Name); localizations != nil {
+				cmd.NameLocalizations = &localizations
+			}
+			continue
+		}
+		if localizations := i18n.DiscordLocalizations(cmd.Description); localizations != nil {
+			cmd.DescriptionLocalizations = &localizations
+		}
+		localizeOptions(cmd.Options)
+	}
+	return cmds
+}
+
+// localizeOptions はオプション（サブコマンドを含む）の説明と選択肢名に訳を設定します
+func localizeOptions(options []*discordgo.ApplicationCommandOption) {
+	for _, opt := range options {
+		opt.DescriptionLocalizations = i18n.DiscordLocalizations(opt.Description)
+		for _, choice := range opt.Choices {
+			choice.NameLocalizations = i18n.DiscordLocalizations(choice.Name)
+		}
+		localizeOptions(opt.Options)
 	}
 }
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCommands_Localizations(t *testing.T) {
+	var checkOptions func(path string, options []*discordgo.ApplicationCommandOption)
+	checkOptions = func(path string, options []*discordgo.ApplicationCommandOption) {
+		for _, opt := range options {
+			optPath := path + " " + opt.Name
+			if opt.DescriptionLocalizations[discordgo.EnglishUS] == "" {
+				t.Errorf("%s: missing en-US description for %q", optPath, opt.Description)
+			}
+			for _, choice := range opt.Choices {
+				if choice.NameLocalizations[discordgo.EnglishUS] == "" {
+					t.Errorf("%s: missing en-US name for choice %q", optPath, choice.Name)
+				}
+			}
+			checkOptions(optPath, opt.Options)
+		}
+	}
+
+	for _, cmd := range Commands() {
+		if cmd.Type == discordgo.MessageApplicationCommand {
+			if cmd.NameLocalizations == nil || (*cmd.NameLocalizations)[discordgo.EnglishUS] == "" {
+				t.Errorf("context menu %q: missing en-US name", cmd.Name)
+			}
+			continue
+		}
+		if cmd.DescriptionLocalizations == nil || (*cmd.DescriptionLocalizations)[discordgo.EnglishUS] == "" {
+			t.Errorf("/%s: missing en-US description for %q", cmd.Name, cmd.Description)
+		}
+		if cmd.NameLocalizations != nil {
+			t.Errorf("/%s: command names should not be localized", cmd.Name)
+		}
+		checkOptions("/"+cmd.Name, cmd.Options)
+	}
+}
+
+func TestCommands_Autocomplete(t *testing.T) {
+	want := map[string]bool{"track": true, "recommend": true}
+
+	for _, cmd := range Commands() {
+		if cmd.Name != "jam" {
+			continue
+		}
+		for _, sub := range cmd.Options {
+			for _, opt := range sub.Options {
+				if opt.Name != "url" {
+					continue
+				}
+				if opt.Autocomplete != want[sub.Name] {
+					t.Errorf("/jam %s url: Autocomplete = %v, want %v", sub.Name, opt.Autocomplete, want[sub.Name])
+				}
+			}
+		}
+	}
+}
@@ -3,24 +3,64 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+)
+
+const (
+	// DefaultAutoLinkMaxLinks は1メッセージあたりに展開するリンク数のデフォルト値です
+	DefaultAutoLinkMaxLinks = 3
+	// DefaultDatabasePath はユーザーデータを保存するSQLiteファイルのデフォルトパスです
+	DefaultDatabasePath = "data/jamberry.db"
+	// DefaultHistoryMaxEntries はユーザーごとに保持する履歴件数のデフォルト値です
+	DefaultHistoryMaxEntries = 50
+	// DefaultTrackTasteMaxRetries はtracktaste APIリクエストのリトライ回数のデフォルト値です
+	DefaultTrackTasteMaxRetries = 2
 )
 
 // Config はアプリケーションの設定を保持します
 type Config struct {
-	DiscordBotToken  string
-	TrackTasteAPIURL string
-	RedisURL         string
-	LogLevel         string
+	DiscordBotToken   string
+	TrackTasteAPIURL  string
+	RedisURL          string
+	LogLevel          string
+	AutoLinkExpand    bool // 通常メッセージ中のSpotifyリンクを自動展開するか
+	AutoLinkMaxLinks  int  // 1メッセージあたりに展開する最大リンク数
+	DatabasePath      string
+	HistoryMaxEntries int                    // ユーザーごとに保持するコマンド実行履歴の件数
+	MetricsAddr       string                 // /metrics を公開するアドレス（空の場合は無効）
+	HealthAddr        string                 // /healthz, /readyz を公開するアドレス（空の場合は無効）
+	L1CacheMaxEntries int                    // L1キャッシュの最大エントリ数（0の場合はデフォルト値）
+	L1CacheMaxBytes   int64                  // L1キャッシュの最大データ量（バイト、0の場合はデフォルト値）
+	RateLimitGuildMax int                    // ギルドごとのレートリミット上限（0の場合はギルド単位の制限なし）
+	RateLimitCosts    map[string]int         // サブコマンドごとのコストの上書き
+	TrackTasteRetries int                    // tracktaste APIリクエストのリトライ回数（0の場合はリトライしない）
+	DefaultLocale     i18n.Locale            // ユーザー・ギルドの言語が判定できない場合の応答言語
+	GuildLocales      map[string]i18n.Locale // ギルドIDごとのデフォルトの応答言語
+	KKBoxClientID     string                 // KKBOX Open API のクライアントID（空の場合は KKBOX のリンクを表示しない）
+	KKBoxClientSecret string                 // KKBOX Open API のクライアントシークレット
 }
 
 // Load は環境変数から設定を読み込みます
 func Load() (*Config, error) {
 	cfg := &Config{
-		DiscordBotToken:  os.Getenv("DISCORD_BOT_TOKEN"),
-		TrackTasteAPIURL: os.Getenv("TRACKTASTE_API_URL"),
-		RedisURL:         os.Getenv("REDIS_URL"),
-		LogLevel:         os.Getenv("LOG_LEVEL"),
+		DiscordBotToken:   os.Getenv("DISCORD_BOT_TOKEN"),
+		TrackTasteAPIURL:  os.Getenv("TRACKTASTE_API_URL"),
+		RedisURL:          os.Getenv("REDIS_URL"),
+		LogLevel:          os.Getenv("LOG_LEVEL"),
+		DatabasePath:      os.Getenv("DATABASE_PATH"),
+		MetricsAddr:       os.Getenv("METRICS_ADDR"),
+		HealthAddr:        os.Getenv("HEALTH_ADDR"),
+		KKBoxClientID:     os.Getenv("KKBOX_ID"),
+		KKBoxClientSecret: os.Getenv("KKBOX_SECRET"),
+		AutoLinkMaxLinks:  DefaultAutoLinkMaxLinks,
+		HistoryMaxEntries: DefaultHistoryMaxEntries,
+		RateLimitGuildMax: ratelimit.GuildMaxRequests,
+		TrackTasteRetries: DefaultTrackTasteMaxRetries,
+		DefaultLocale:     i18n.DefaultLocale,
 	}
 
 	// 必須項目のバリデーション
@@ -39,13 +79,131 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))
 	}
 
+	// オプション項目の読み込み
+	if v := os.Getenv("AUTO_LINK_EXPAND"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AUTO_LINK_EXPAND: %w", err)
+		}
+		cfg.AutoLinkExpand = enabled
+	}
+	if v := os.Getenv("AUTO_LINK_MAX_LINKS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid AUTO_LINK_MAX_LINKS: %q", v)
+		}
+		cfg.AutoLinkMaxLinks = n
+	}
+	if v := os.Getenv("HISTORY_MAX_ENTRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid HISTORY_MAX_ENTRIES: %q", v)
+		}
+		cfg.HistoryMaxEntries = n
+	}
+	if v := os.Getenv("L1_CACHE_MAX_ENTRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid L1_CACHE_MAX_ENTRIES: %q", v)
+		}
+		cfg.L1CacheMaxEntries = n
+	}
+	if v := os.Getenv("L1_CACHE_MAX_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid L1_CACHE_MAX_BYTES: %q", v)
+		}
+		cfg.L1CacheMaxBytes = n
+	}
+	if v := os.Getenv("RATE_LIMIT_GUILD_MAX"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_GUILD_MAX: %q", v)
+		}
+		cfg.RateLimitGuildMax = n
+	}
+	if v := os.Getenv("TRACKTASTE_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid TRACKTASTE_MAX_RETRIES: %q", v)
+		}
+		cfg.TrackTasteRetries = n
+	}
+	if v := os.Getenv("RATE_LIMIT_COSTS"); v != "" {
+		costs, err := parseCosts(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_COSTS: %w", err)
+		}
+		cfg.RateLimitCosts = costs
+	}
+
+	if v := os.Getenv("DEFAULT_LOCALE"); v != "" {
+		loc, ok := i18n.Parse(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid DEFAULT_LOCALE: %q", v)
+		}
+		cfg.DefaultLocale = loc
+	}
+	if v := os.Getenv("GUILD_LOCALES"); v != "" {
+		locales, err := parseGuildLocales(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GUILD_LOCALES: %w", err)
+		}
+		cfg.GuildLocales = locales
+	}
+
 	// デフォルト値の設定
 	if cfg.LogLevel == "" {
 		cfg.LogLevel = "INFO"
 	}
+	if cfg.DatabasePath == "" {
+		cfg.DatabasePath = DefaultDatabasePath
+	}
 
 	// TrackTasteAPIURLの末尾スラッシュを除去
 	cfg.TrackTasteAPIURL = strings.TrimSuffix(cfg.TrackTasteAPIURL, "/")
 
 	return cfg, nil
 }
+
+// parseCosts は "recommend=3,search=2" 形式のコスト指定を解析します
+func parseCosts(v string) (map[string]int, error) {
+	costs := make(map[string]int)
+	for _, pair := range strings.Split(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected command=cost, got %q", pair)
+		}
+		cost, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || cost <= 0 {
+			return nil, fmt.Errorf("invalid cost for %q: %q", name, value)
+		}
+		costs[strings.TrimSpace(name)] = cost
+	}
+	return costs, nil
+}
+
+// parseGuildLocales は "123456789=en,987654321=ja" 形式のギルドごとの言語指定を解析します
+func parseGuildLocales(v string) (map[string]i18n.Locale, error) {
+	locales := make(map[string]i18n.Locale)
+	for _, pair := range strings.Split(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		guildID, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected guild_id=locale, got %q", pair)
+		}
+		loc, ok := i18n.Parse(value)
+		if !ok {
+			return nil, fmt.Errorf("unsupported locale for %q: %q", guildID, value)
+		}
+		locales[strings.TrimSpace(guildID)] = loc
+	}
+	return locales, nil
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Album はアルバムの基本情報を表すドメインエンティティです
 type Album struct {
 	ID          string
@@ -22,6 +24,7 @@ type AlbumDetail struct {
 	Popularity  *int
 	UPC         string
 	Genres      []string
+	CachedAt    time.Time // ゼロ値以外の場合は tracktaste 障害時に返した古いキャッシュデータ（取得日時）
 }
 
 // AlbumTrack はアルバム内のトラック情報を表します
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Artist はアーティストの基本情報を表すドメインエンティティです
 type Artist struct {
 	ID   string
@@ -16,4 +18,5 @@ type ArtistDetail struct {
 	Genres     []string
 	Popularity *int
 	Images     []Image
+	CachedAt   time.Time // ゼロ値以外の場合は tracktaste 障害時に返した古いキャッシュデータ（取得日時）
 }
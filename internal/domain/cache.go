@@ -7,13 +7,15 @@ import (
 
 // PaginationData はページネーション用のキャッシュデータを表します
 type PaginationData struct {
-	Command string          `json:"command"`
-	Query   string          `json:"query"`
-	Type    string          `json:"type"`
-	Items   json.RawMessage `json:"items"`
-	Total   int             `json:"total"`
-	OwnerID string          `json:"owner_id"`
-	Mode    string          `json:"mode,omitempty"` // レコメンドモード（recommend専用）
+	Command  string          `json:"command"`
+	Query    string          `json:"query"`
+	Type     string          `json:"type"`
+	Items    json.RawMessage `json:"items"`
+	Total    int             `json:"total"`
+	OwnerID  string          `json:"owner_id"`
+	Mode     string          `json:"mode,omitempty"`      // レコメンドモード（recommend専用）
+	Seeds    []string        `json:"seeds,omitempty"`     // シードトラック名（アルバム/プレイリスト起点のrecommend専用）
+	PageSize int             `json:"page_size,omitempty"` // コマンド実行時の1ページあたりの表示件数（0の場合はデフォルト値）
 }
 
 // CacheRepository はキャッシュを操作するリポジトリインターフェースです
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 外部API（tracktaste）のエラーの分類です
+// インフラ層は *UpstreamError でこれらをラップして返し、呼び出し元は errors.Is で判定します
+// ユーザー向けのメッセージへの変換はハンドラー層で行います
+var (
+	// ErrUpstreamUnavailable は外部APIが一時的に利用できないことを表します（5xx・接続エラー・サーキットブレーカーによる拒否）
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrUpstreamTimeout は外部APIへのリクエストがタイムアウトしたことを表します
+	ErrUpstreamTimeout = errors.New("upstream timeout")
+	// ErrRateLimited は外部APIの流量制限に達したことを表します
+	ErrRateLimited = errors.New("upstream rate limited")
+	// ErrInvalidInput は入力が空、またはパラメータの形式が不正なことを表します
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInvalidURL は入力が Spotify の URL ではないことを表します
+	ErrInvalidURL = errors.New("invalid spotify url")
+	// ErrInvalidResource は URL のエンティティ種別が期待と異なることを表します
+	ErrInvalidResource = errors.New("invalid resource type")
+	// ErrUpstream は上記以外の外部APIのエラーを表します
+	ErrUpstream = errors.New("upstream error")
+)
+
+// UpstreamError は外部APIのエラーを分類（Kind）とともに表します
+type UpstreamError struct {
+	Kind   error  // ErrUpstreamTimeout などの分類
+	Code   string // APIのエラーコード（レスポンスにない場合は HTTP_<status>、CONNECTION_ERROR、CIRCUIT_OPEN）
+	Status int    // HTTPステータス（レスポンスがない場合は0）
+	Err    error  // 元のエラー（APIのエラーレスポンスや接続エラー）
+}
+
+func (e *UpstreamError) Error() string {
+	msg := fmt.Sprintf("%v (code: %s, status: %d)", e.Kind, e.Code, e.Status)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap は分類と元のエラーの両方を返します（errors.Is / errors.As の対象にするため）
+func (e *UpstreamError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// IsUpstreamOutage は外部APIの一時的な障害（利用不可・タイムアウト）によるエラーかどうかを返します
+func IsUpstreamOutage(err error) bool {
+	return errors.Is(err, ErrUpstreamUnavailable) || errors.Is(err, ErrUpstreamTimeout)
+}
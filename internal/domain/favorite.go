@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrFavoriteExists はお気に入りが既に登録済みであることを表します
+	ErrFavoriteExists = errors.New("favorite already exists")
+	// ErrFavoriteNotFound はお気に入りが見つからないことを表します
+	ErrFavoriteNotFound = errors.New("favorite not found")
+	// ErrFavoriteLimitReached はお気に入りの登録件数が上限に達していることを表します
+	ErrFavoriteLimitReached = errors.New("favorite limit reached")
+)
+
+// Favorite はユーザーのお気に入り（トラック/アーティスト/アルバムへの参照）を表します
+type Favorite struct {
+	UserID     string    `json:"user_id"`
+	EntityType string    `json:"entity_type"` // track / artist / album
+	SpotifyID  string    `json:"spotify_id"`
+	URL        string    `json:"url"`
+	Name       string    `json:"name"`
+	Subtitle   string    `json:"subtitle,omitempty"` // アーティスト名など補足情報
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FavoritesRepository はユーザーのお気に入りを永続化するリポジトリインターフェースです
+type FavoritesRepository interface {
+	// AddFavorite はお気に入りを登録します（登録済みの場合は ErrFavoriteExists、
+	// ユーザーの登録件数が limit 件以上の場合は ErrFavoriteLimitReached を返します）
+	AddFavorite(ctx context.Context, fav *Favorite, limit int) error
+
+	// ListFavorites はユーザーのお気に入りを新しい順に取得します
+	ListFavorites(ctx context.Context, userID string) ([]Favorite, error)
+
+	// CountFavorites はユーザーのお気に入り件数を返します
+	CountFavorites(ctx context.Context, userID string) (int, error)
+
+	// RemoveFavorite はお気に入りを削除します（未登録の場合は ErrFavoriteNotFound を返します）
+	RemoveFavorite(ctx context.Context, userID, spotifyID string) error
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrGuildSettingsNotFound はギルドの設定が保存されていないことを表します
+var ErrGuildSettingsNotFound = errors.New("guild settings not found")
+
+// DetailVisibility は検索・レコメンドの一覧から選択した項目の詳細の表示範囲です
+type DetailVisibility string
+
+const (
+	// DetailVisibilityEphemeral は選択した本人のみに表示します（未設定の場合のデフォルト）
+	DetailVisibilityEphemeral DetailVisibility = "ephemeral"
+	// DetailVisibilityPublic はチャンネル全体に表示します
+	DetailVisibilityPublic DetailVisibility = "public"
+)
+
+// GuildSettings はギルドごとのBotの設定を表します
+// ゼロ値の項目は未設定で、全体の設定（環境変数）やデフォルト値に従います
+type GuildSettings struct {
+	GuildID          string
+	RecommendMode    RecommendMode    // レコメンドのデフォルトモード（空の場合は balanced）
+	PageSize         int              // 一覧の1ページあたりの表示件数（0の場合はデフォルト値）
+	AutoLink         *bool            // リンク自動展開の有効/無効（nil の場合は AUTO_LINK_EXPAND に従う）
+	Locale           string           // 応答言語 ja / en（空の場合は実行者のDiscordの言語に従う）
+	AllowedChannels  []string         // コマンドを利用できるチャンネルID（空の場合は全チャンネル）
+	DetailVisibility DetailVisibility // 一覧から選択した項目の詳細の表示範囲（空の場合は ephemeral）
+	UpdatedAt        time.Time
+}
+
+// ChannelAllowed はチャンネルでBotを利用できるかどうかを返します
+func (s *GuildSettings) ChannelAllowed(channelID string) bool {
+	return len(s.AllowedChannels) == 0 || slices.Contains(s.AllowedChannels, channelID)
+}
+
+// GuildSettingsRepository はギルドごとの設定を永続化するリポジトリインターフェースです
+type GuildSettingsRepository interface {
+	// GetGuildSettings はギルドの設定を取得します（未保存の場合は ErrGuildSettingsNotFound を返します）
+	GetGuildSettings(ctx context.Context, guildID string) (*GuildSettings, error)
+
+	// SaveGuildSettings はギルドの設定を保存します（保存済みの場合は上書きします）
+	SaveGuildSettings(ctx context.Context, settings *GuildSettings) error
+
+	// DeleteGuildSettings はギルドの設定を削除します（未保存の場合も成功します）
+	DeleteGuildSettings(ctx context.Context, guildID string) error
+}
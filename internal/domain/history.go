@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrHistoryNotFound は履歴が見つからないことを表します
+var ErrHistoryNotFound = errors.New("history entry not found")
+
+// HistoryEntry はユーザーのコマンド実行履歴の1件を表します
+type HistoryEntry struct {
+	ID         int64     `json:"id"`
+	UserID     string    `json:"user_id"`
+	Command    string    `json:"command"`               // track / artist / album / recommend / search
+	EntityType string    `json:"entity_type,omitempty"` // track / artist / album / playlist（search は検索対象の種別で、track / artist / album / all）
+	URL        string    `json:"url,omitempty"`         // 正規化済みのSpotify URL（search は空）
+	Query      string    `json:"query,omitempty"`       // 検索キーワード（search のみ）
+	Mode       string    `json:"mode,omitempty"`        // レコメンドモード（recommend のみ）
+	Label      string    `json:"label,omitempty"`       // 表示名（曲名・アーティスト名など）
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// HistoryRepository はユーザーのコマンド実行履歴を永続化するリポジトリインターフェースです
+type HistoryRepository interface {
+	// AddHistory は履歴を追加し、採番したIDを entry.ID に設定します
+	AddHistory(ctx context.Context, entry *HistoryEntry) error
+
+	// ListHistory はユーザーの履歴を新しい順に最大 limit 件取得します
+	ListHistory(ctx context.Context, userID string, limit int) ([]HistoryEntry, error)
+
+	// GetHistory はユーザーの履歴を1件取得します（存在しない場合は ErrHistoryNotFound を返します）
+	GetHistory(ctx context.Context, userID string, id int64) (*HistoryEntry, error)
+
+	// PruneHistory はユーザーの履歴を新しい順に keep 件だけ残して削除します
+	PruneHistory(ctx context.Context, userID string, keep int) error
+
+	// ClearHistory はユーザーの履歴をすべて削除し、削除件数を返します
+	ClearHistory(ctx context.Context, userID string) (int, error)
+}
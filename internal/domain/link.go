@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLinkNotMatched は他サービスのリンクに対応する Spotify のトラック・アルバムが見つからないことを表します
+var ErrLinkNotMatched = errors.New("no matching spotify entity for link")
+
+// LinkResolver は Spotify の短縮URL（spotify.link など）や他サービス（Apple Music など）のリンクを Spotify のURLに解決するインターフェースです
+type LinkResolver interface {
+	// ResolveLink はリンクに対応する Spotify のURLを返します
+	ResolveLink(ctx context.Context, rawURL string) (string, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+// Playlist はプレイリストの詳細情報を表すドメインエンティティです
+type Playlist struct {
+	ID          string
+	Name        string
+	URL         string
+	Description string
+	Owner       string // 作成者の表示名
+	Followers   *int
+	Images      []Image
+	Tracks      []Track
+	TotalTracks int // プレイリスト全体の曲数（Tracks は先頭の一部のみの場合がある）
+}
@@ -21,12 +21,24 @@ type TrackRepository interface {
 type ArtistRepository interface {
 	// FetchArtist はアーティスト情報を取得します
 	FetchArtist(ctx context.Context, spotifyURL string) (*ArtistDetail, error)
+
+	// SearchArtists はアーティストを検索します
+	SearchArtists(ctx context.Context, query string) ([]ArtistDetail, error)
 }
 
 // AlbumRepository はアルバム情報を取得するリポジトリインターフェースです
 type AlbumRepository interface {
 	// FetchAlbum はアルバム情報を取得します
 	FetchAlbum(ctx context.Context, spotifyURL string) (*AlbumDetail, error)
+
+	// SearchAlbums はアルバムを検索します
+	SearchAlbums(ctx context.Context, query string) ([]Album, error)
+}
+
+// PlaylistRepository はプレイリスト情報を取得するリポジトリインターフェースです
+type PlaylistRepository interface {
+	// FetchPlaylist はプレイリスト情報を取得します
+	FetchPlaylist(ctx context.Context, spotifyURL string) (*Playlist, error)
 }
 
 // MusicRepository は音楽情報を取得する統合リポジトリインターフェースです
@@ -34,4 +46,16 @@ type MusicRepository interface {
 	TrackRepository
 	ArtistRepository
 	AlbumRepository
+	PlaylistRepository
+}
+
+// ExternalIDRepository は ISRC / UPC から Spotify のトラック・アルバムを検索するリポジトリインターフェースです
+// 他サービスのリンクを Spotify のリンクに対応付けるために使います
+type ExternalIDRepository interface {
+	// FindTrackByISRC は ISRC が一致するトラックを返します（見つからない場合は nil）
+	FindTrackByISRC(ctx context.Context, isrc string) (*Track, error)
+
+	// FindAlbumByUPC は UPC が一致するアルバムを返します（見つからない場合は nil）
+	// query（アルバム名・アーティスト名）で候補を検索し、候補のアルバムの UPC と照合します
+	FindAlbumByUPC(ctx context.Context, upc, query string) (*AlbumDetail, error)
 }
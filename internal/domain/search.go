@@ -0,0 +1,20 @@
+package domain
+
+// SearchType は検索対象の種別を表します
+type SearchType string
+
+const (
+	SearchTypeTrack  SearchType = "track"  // トラック（デフォルト）
+	SearchTypeArtist SearchType = "artist" // アーティスト
+	SearchTypeAlbum  SearchType = "album"  // アルバム
+	SearchTypeAll    SearchType = "all"    // トラック・アーティスト・アルバムをまとめて検索
+)
+
+// SearchItem は種別をまたいだ検索結果の1件を表します
+// Type に応じて Track / Artist / Album のいずれかが設定されます
+type SearchItem struct {
+	Type   SearchType    `json:"type"`
+	Track  *Track        `json:"track,omitempty"`
+	Artist *ArtistDetail `json:"artist,omitempty"`
+	Album  *Album        `json:"album,omitempty"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "context"
+
+// ServiceLink は Spotify 以外の音楽配信サービスでトラック・アルバムを開くリンクです
+type ServiceLink struct {
+	Service string `json:"service"` // サービス名（Apple Music など）
+	URL     string `json:"url"`
+	Search  bool   `json:"search,omitempty"` // 一致を確認したリンクではなく、サービスの検索結果へのリンク
+}
+
+// ServiceLinker は Spotify のトラック・アルバムに対応する1つのサービスのリンクを検索するインターフェースです
+// サービスごとに実装し、ServiceLinkRepository の実装に登録します
+type ServiceLinker interface {
+	// Service はサービス名を返します
+	Service() string
+
+	// FindTrack はトラックに対応するURLを返します（見つからない場合は空文字）
+	FindTrack(ctx context.Context, track *Track) (string, error)
+
+	// FindAlbum はアルバムに対応するURLを返します（見つからない場合は空文字）
+	FindAlbum(ctx context.Context, album *AlbumDetail) (string, error)
+}
+
+// SearchLinker は一致するトラック・アルバムを確認できず、サービスの検索結果へのリンクを返す ServiceLinker です
+// このリンカーのリンクは ServiceLink.Search を true にし、一致を確認したリンクと分けて表示します
+type SearchLinker interface {
+	ServiceLinker
+
+	// SearchOnly は検索結果へのリンクのみを返す場合に true を返します
+	SearchOnly() bool
+}
+
+// ServiceLinkRepository は Spotify のトラック・アルバムに対応する他サービスのリンクをまとめて取得するリポジトリインターフェースです
+// リンクが見つからない・取得に失敗したサービスは結果に含めません
+type ServiceLinkRepository interface {
+	// TrackLinks はトラックに対応する他サービスのリンクを返します
+	TrackLinks(ctx context.Context, track *Track) []ServiceLink
+
+	// AlbumLinks はアルバムに対応する他サービスのリンクを返します
+	AlbumLinks(ctx context.Context, album *AlbumDetail) []ServiceLink
+}
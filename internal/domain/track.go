@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Track はトラック情報を表すドメインエンティティです
 type Track struct {
 	ID          string
@@ -13,6 +15,7 @@ type Track struct {
 	ISRC        *string
 	Album       Album
 	Artists     []Artist
+	CachedAt    time.Time // ゼロ値以外の場合は tracktaste 障害時に返した古いキャッシュデータ（取得日時）
 }
 
 // AudioFeatures はトラックの音響特徴量を表します（旧仕様: Spotify Audio Features）
@@ -5,15 +5,17 @@ import (
 	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/presenter"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 // handleArtist はアーティスト情報取得コマンドを処理します
 func (h *Handler) handleArtist(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
 	if len(options) == 0 {
 		slog.Info("validation failed: empty input", "command", "jam artist")
-		h.responder.RespondEphemeral(s, i, "❌ URL を入力してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
 		return
 	}
 
@@ -26,14 +28,24 @@ func (h *Handler) handleArtist(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 
 	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
 	output, err := h.artistUseCase.GetArtist(ctx, usecase.ArtistInput{Input: input})
 	if err != nil {
-		h.responder.EditResponse(s, i, err.Error())
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
 		return
 	}
 
-	// Embed構築・返信
-	emb := presenter.BuildArtistEmbed(output.Artist)
-	h.responder.EditResponseEmbed(s, i, emb)
+	// Embed構築・返信（お気に入り登録ボタン付き）
+	emb := presenter.BuildArtistEmbed(loc, output.Artist)
+	components := presenter.BuildFavoriteButton("artist", output.Artist.ID)
+	if _, err := h.responder.EditResponseWithComponents(s, i, emb, components); err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+	h.recordHistory(i, usecase.HistoryRecordInput{Command: "artist", Input: input, Label: output.Artist.Name})
 	slog.Info("command completed", "command", "jam artist", "artist_name", output.Artist.Name, "artist_id", output.Artist.ID)
 }
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/ttlcache"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// minSuggestQueryLength は候補の検索を始める入力の最小文字数です
+	minSuggestQueryLength = 2
+	// suggestTimeout は候補の検索のタイムアウトです（Discord はオートコンプリートに3秒以内の応答を要求します）
+	suggestTimeout = 2 * time.Second
+	// suggestCacheTTL は検索結果をキャッシュする期間です
+	// 入力中は1文字ごとにリクエストが届くため、同じ入力の再検索（削除して打ち直した場合など）をまとめます
+	suggestCacheTTL = 30 * time.Second
+	// suggestCacheMaxEntries はキャッシュに保持する入力の最大数です
+	suggestCacheMaxEntries = 1000
+)
+
+// handleAutocomplete はスラッシュコマンドのオートコンプリートを処理します
+// /jam track と /jam recommend の url オプションに入力中のキーワードでトラックを検索し、候補として返します
+func (h *Handler) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cmdData := i.ApplicationCommandData()
+	if cmdData.Name != "jam" || len(cmdData.Options) == 0 {
+		return
+	}
+
+	subCmd := cmdData.Options[0]
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	if opt := focusedOption(subCmd.Options); opt != nil && opt.Name == "url" && (subCmd.Name == "track" || subCmd.Name == "recommend") {
+		// 許可チャンネル以外では候補を出さない（コマンドの実行時に利用できるチャンネルを通知する）
+		if h.guildSettings(i.GuildID).ChannelAllowed(i.ChannelID) {
+			choices = h.suggestTracks(opt.StringValue())
+		}
+	}
+
+	if err := h.responder.RespondAutocomplete(s, i, choices); err != nil {
+		slog.Debug("failed to respond autocomplete", "subcommand", subCmd.Name, "error", err)
+	}
+}
+
+// suggestTracks は入力中のキーワードでトラックを検索し、オートコンプリートの候補を返します
+// URL・URI・ID の入力中や検索に失敗した場合は候補なしとします
+func (h *Handler) suggestTracks(input string) []*discordgo.ApplicationCommandOptionChoice {
+	query := normalizeSuggestQuery(input)
+	if len([]rune(query)) < minSuggestQueryLength || isSpotifyReference(query) {
+		return nil
+	}
+
+	tracks, err := h.suggestions.get(query, func(ctx context.Context) ([]domain.Track, error) {
+		output, err := h.searchUseCase.SearchTracks(ctx, usecase.SearchInput{Query: query})
+		if usecase.IsNotFoundError(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return output.Tracks, nil
+	})
+	if err != nil {
+		slog.Debug("autocomplete search failed", "query", query, "error", err)
+		return nil
+	}
+	return presenter.BuildTrackChoices(tracks)
+}
+
+// focusedOption はユーザーが入力中のオプションを返します
+func focusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range options {
+		if opt.Focused {
+			return opt
+		}
+		if focused := focusedOption(opt.Options); focused != nil {
+			return focused
+		}
+	}
+	return nil
+}
+
+// normalizeSuggestQuery はキャッシュのキーが揃うよう、入力の空白と大文字小文字を正規化します
+func normalizeSuggestQuery(input string) string {
+	return strings.ToLower(strings.Join(strings.Fields(input), " "))
+}
+
+// isSpotifyReference は入力が Spotify の URL・URI・ID（またはその入力途中）かどうかを返します
+func isSpotifyReference(input string) bool {
+	return strings.HasPrefix(input, "http://") ||
+		strings.HasPrefix(input, "https://") ||
+		strings.HasPrefix(input, "spotify:") ||
+		spotify.SpotifyIDRegex.MatchString(input)
+}
+
+// suggestCache はオートコンプリートの検索結果を短時間保持するキャッシュです
+// 同じ入力の同時の検索は1回にまとめ、tracktaste へのリクエストを抑えます
+type suggestCache struct {
+	entries *ttlcache.Cache[[]domain.Track]
+	now     func() time.Time
+	group   singleflight.Group
+}
+
+// newSuggestCache は新しいsuggestCacheを作成します
+func newSuggestCache(ttl time.Duration, maxEntries int) *suggestCache {
+	return &suggestCache{
+		entries: ttlcache.New[[]domain.Track](ttl, maxEntries),
+		now:     time.Now,
+	}
+}
+
+// get はキャッシュから検索結果を返します
+// キャッシュにない場合は fetch で検索して保存します（エラーはキャッシュしません）
+func (c *suggestCache) get(query string, fetch func(ctx context.Context) ([]domain.Track, error)) ([]domain.Track, error) {
+	if tracks, ok := c.entries.Get(query, c.now()); ok {
+		return tracks, nil
+	}
+
+	v, err, _ := c.group.Do(query, func() (any, error) {
+		// 入力を続けて応答が不要になっても、結果は次の入力のためにキャッシュする
+		ctx, cancel := context.WithTimeout(context.Background(), suggestTimeout)
+		defer cancel()
+
+		tracks, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.entries.Set(query, tracks, c.now())
+		return tracks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]domain.Track), nil
+}
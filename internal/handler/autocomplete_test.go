@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// searchTrackRepository はテスト用の検索のみを実装したトラックリポジトリです
+type searchTrackRepository struct {
+	domain.TrackRepository
+	tracks  []domain.Track
+	queries atomic.Int32
+}
+
+func (r *searchTrackRepository) SearchTracks(ctx context.Context, query string) ([]domain.Track, error) {
+	r.queries.Add(1)
+	return r.tracks, nil
+}
+
+func TestSuggestTracks(t *testing.T) {
+	repo := &searchTrackRepository{tracks: []domain.Track{
+		{Name: "Song", URL: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", Artists: []domain.Artist{{Name: "Artist"}}},
+	}}
+	h := &Handler{
+		searchUseCase: usecase.NewSearchUseCase(repo, nil, nil),
+		suggestions:   newSuggestCache(suggestCacheTTL, suggestCacheMaxEntries),
+	}
+
+	choices := h.suggestTracks("  Song ")
+	if len(choices) != 1 || choices[0].Name != "Song — Artist" {
+		t.Fatalf("suggestTracks() = %v, want [Song — Artist]", choices)
+	}
+
+	// 空白や大文字小文字の違う同じ入力はキャッシュから返す
+	h.suggestTracks("song")
+	if got := repo.queries.Load(); got != 1 {
+		t.Errorf("search requests = %d, want 1 (cached)", got)
+	}
+
+	// URL・URI・ID の入力中や短すぎる入力は検索しない
+	for _, input := range []string{"", "s", "https://open.spot", "spotify:track:", "4iV5W9uYEdYUVa79Axb7Rh"} {
+		if got := h.suggestTracks(input); got != nil {
+			t.Errorf("suggestTracks(%q) = %v, want nil", input, got)
+		}
+	}
+	if got := repo.queries.Load(); got != 1 {
+		t.Errorf("search requests = %d, want 1", got)
+	}
+}
+
+func TestSuggestCache(t *testing.T) {
+	now := time.Now()
+	c := newSuggestCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	var calls int
+	fetch := func(ctx context.Context) ([]domain.Track, error) {
+		calls++
+		return []domain.Track{{Name: "Song"}}, nil
+	}
+
+	for range 2 {
+		if _, err := c.get("song", fetch); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch calls = %d, want 1", calls)
+	}
+
+	// TTL を過ぎると再検索する
+	now = now.Add(time.Minute)
+	if _, err := c.get("song", fetch); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("fetch calls after expiry = %d, want 2", calls)
+	}
+
+	// エラーはキャッシュしない
+	failErr := errors.New("unavailable")
+	if _, err := c.get("fail", func(ctx context.Context) ([]domain.Track, error) { return nil, failErr }); !errors.Is(err, failErr) {
+		t.Errorf("get() error = %v, want %v", err, failErr)
+	}
+	if _, ok := c.entries.Get("fail", now); ok {
+		t.Error("failed search should not be cached")
+	}
+
+	// 上限を超えるエントリは保持しない
+	for _, q := range []string{"a1", "a2", "a3"} {
+		if _, err := c.get(q, fetch); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if got := c.entries.Len(); got > 2 {
+		t.Errorf("entries = %d, want <= 2", got)
+	}
+}
+
+func TestSuggestCache_Concurrent(t *testing.T) {
+	c := newSuggestCache(time.Minute, 10)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]domain.Track, error) {
+		calls.Add(1)
+		<-release
+		return []domain.Track{{Name: "Song"}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.get("song", fetch)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fetch calls = %d, want 1 (deduplicated)", got)
+	}
+}
+
+func TestFocusedOption(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "track", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "mode", Value: "balanced"},
+			{Name: "url", Value: "song", Focused: true},
+		}},
+	}
+
+	if got := focusedOption(options); got == nil || got.Name != "url" {
+		t.Errorf("focusedOption() = %v, want url", got)
+	}
+	if got := focusedOption(options[0].Options[:1]); got != nil {
+		t.Errorf("focusedOption() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+const (
+	// maxEmbedsPerMessage はDiscordが1メッセージに許可するEmbedの最大数です
+	maxEmbedsPerMessage = 10
+	// autoLinkTimeout はリンク自動展開1回あたりのタイムアウトです
+	autoLinkTimeout = 30 * time.Second
+)
+
+// AutoLinkConfig は通常メッセージ中のSpotifyリンク自動展開の設定です
+type AutoLinkConfig struct {
+	Enabled  bool
+	MaxLinks int // 1メッセージあたりに展開する最大リンク数
+}
+
+// maxLinks は実際に展開するリンク数の上限を返します
+func (c AutoLinkConfig) maxLinks() int {
+	if c.MaxLinks <= 0 || c.MaxLinks > maxEmbedsPerMessage {
+		return maxEmbedsPerMessage
+	}
+	return c.MaxLinks
+}
+
+// handleAutoLink は通常メッセージ中のSpotifyリンクを検出し、Embedで返信します
+func (h *Handler) handleAutoLink(s *discordgo.Session, m *discordgo.MessageCreate) {
+	ctx, cancel := context.WithTimeout(context.Background(), autoLinkTimeout)
+	defer cancel()
+
+	// 短縮URLの展開は外部へのリクエストになるため、レートリミットを確認してから行う
+	maxLinks := h.autoLink.maxLinks()
+	links := spotify.ExtractLinks(m.Content, maxLinks)
+	shortLinks := 0
+	if h.linkResolver != nil {
+		shortLinks = min(spotify.CountShortLinks(m.Content, maxLinks), maxLinks-len(links))
+	}
+	if len(links)+shortLinks == 0 {
+		return
+	}
+
+	slog.Debug("spotify links detected",
+		"guild_id", m.GuildID,
+		"channel_id", m.ChannelID,
+		"user_id", m.Author.ID,
+		"link_count", len(links),
+		"short_link_count", shortLinks,
+	)
+
+	// レートリミットチェック（リンクごとのコストの合計を消費する。超過時は通常メッセージのため黙って無視する）
+	cost := shortLinks * h.costs.Of(costShortLink)
+	for _, link := range links {
+		cost += h.costs.Of(string(link.EntityType))
+	}
+	decision := h.limiter.Take(ratelimit.Request{UserID: m.Author.ID, GuildID: m.GuildID, Cost: cost})
+	if !decision.Allowed {
+		slog.Warn("rate limit exceeded",
+			"user_id", m.Author.ID,
+			"guild_id", m.GuildID,
+			"source", "auto_link",
+			"scope", decision.Scope,
+			"cost", decision.Cost,
+			"retry_after", decision.RetryAfter,
+		)
+		return
+	}
+
+	// 短縮URLは展開してから通常のリンクと同じように扱う
+	if shortLinks > 0 {
+		links = spotify.ExtractLinks(h.expandShortLinks(ctx, m.Content, shortLinks), maxLinks)
+	}
+
+	loc := h.guildLocale(m.GuildID)
+
+	var embeds []*discordgo.MessageEmbed
+	for _, link := range links {
+		emb, _, err := h.buildLinkEmbed(ctx, loc, link)
+		if err != nil {
+			slog.Warn("failed to expand spotify link", "url", link.URL, "error", err)
+			continue
+		}
+		embeds = append(embeds, emb)
+	}
+
+	if len(embeds) == 0 {
+		return
+	}
+
+	if _, err := s.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Embeds:    embeds,
+		Reference: m.Reference(),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{},
+		},
+	}); err != nil {
+		slog.Error("failed to send auto link response", "error", err)
+		return
+	}
+
+	// 元メッセージのDiscord標準Embedを抑制（メッセージの管理権限が必要）
+	if _, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:      m.ID,
+		Channel: m.ChannelID,
+		Flags:   discordgo.MessageFlagsSuppressEmbeds,
+	}); err != nil {
+		slog.Warn("failed to suppress original embeds", "message_id", m.ID, "error", err)
+	}
+
+	slog.Info("auto link completed",
+		"guild_id", m.GuildID,
+		"channel_id", m.ChannelID,
+		"user_id", m.Author.ID,
+		"embed_count", len(embeds),
+	)
+}
+
+// buildLinkEmbed はリンク種別に応じたユースケースを呼び出してEmbedを構築します
+// Embedとあわせて、履歴の表示に使うエンティティ名を返します
+func (h *Handler) buildLinkEmbed(ctx context.Context, loc i18n.Locale, link spotify.ValidationResult) (*discordgo.MessageEmbed, string, error) {
+	switch link.EntityType {
+	case spotify.EntityTrack:
+		output, err := h.trackUseCase.GetTrack(ctx, usecase.TrackInput{Input: link.URL})
+		if err != nil {
+			return nil, "", err
+		}
+		return presenter.BuildTrackEmbed(loc, output.Track, h.trackServiceLinks(ctx, output.Track)), output.Track.Name, nil
+	case spotify.EntityArtist:
+		output, err := h.artistUseCase.GetArtist(ctx, usecase.ArtistInput{Input: link.URL})
+		if err != nil {
+			return nil, "", err
+		}
+		return presenter.BuildArtistEmbed(loc, output.Artist), output.Artist.Name, nil
+	case spotify.EntityAlbum:
+		output, err := h.albumUseCase.GetAlbum(ctx, usecase.AlbumInput{Input: link.URL})
+		if err != nil {
+			return nil, "", err
+		}
+		return presenter.BuildAlbumEmbed(loc, output.Album, h.albumServiceLinks(ctx, output.Album)), output.Album.Name, nil
+	case spotify.EntityPlaylist:
+		output, err := h.playlistUseCase.GetPlaylist(ctx, usecase.PlaylistInput{Input: link.URL})
+		if err != nil {
+			return nil, "", err
+		}
+		// ページングのボタンは付けないため、先頭のページのみを表示する
+		return presenter.BuildPlaylistEmbed(loc, output.Playlist, 0, PageSize), output.Playlist.Name, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported entity type: %s", link.EntityType)
+	}
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+func TestAutoLinkConfig_MaxLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		config AutoLinkConfig
+		want   int
+	}{
+		{"configured value", AutoLinkConfig{Enabled: true, MaxLinks: 3}, 3},
+		{"zero falls back to discord limit", AutoLinkConfig{Enabled: true, MaxLinks: 0}, maxEmbedsPerMessage},
+		{"capped at discord limit", AutoLinkConfig{Enabled: true, MaxLinks: 20}, maxEmbedsPerMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.maxLinks(); got != tt.want {
+				t.Errorf("maxLinks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleMessageCreate_AutoLink(t *testing.T) {
+	const (
+		found   = "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"
+		missing = "https://open.spotify.com/track/0VjIjW4GlUZAMYd2vXMi3b"
+	)
+	disabled := false
+
+	tests := []struct {
+		name        string
+		configOff   bool // AUTO_LINK_EXPAND=false
+		guildID     string
+		channelID   string
+		author      *discordgo.User
+		content     string
+		deny        bool
+		wantCost    int // レートリミットで判定したコスト（0の場合は判定しない）
+		wantFetches int32
+		wantEmbeds  int // 返信した Embed の数（0の場合は返信しない）
+	}{
+		{
+			name:        "expands links and skips failed ones",
+			content:     "これ聴いて " + found + " と " + missing,
+			wantCost:    2,
+			wantFetches: 2,
+			wantEmbeds:  1,
+		},
+		{
+			name:    "ignores messages without links",
+			content: "今日はいい天気ですね",
+		},
+		{
+			name:    "ignores bot authors",
+			author:  &discordgo.User{ID: "other-bot", Bot: true},
+			content: found,
+		},
+		{
+			name:    "ignores own messages",
+			author:  &discordgo.User{ID: "bot", Bot: true},
+			content: found,
+		},
+		{
+			name:      "disabled by config",
+			configOff: true,
+			content:   found,
+		},
+		{
+			name:    "disabled in guild",
+			guildID: "off",
+			content: found,
+		},
+		{
+			name:      "channel not allowed",
+			guildID:   "limited",
+			channelID: "c1",
+			content:   found,
+		},
+		{
+			name:        "allowed channel",
+			guildID:     "limited",
+			channelID:   "c-allowed",
+			content:     found,
+			wantCost:    1,
+			wantFetches: 1,
+			wantEmbeds:  1,
+		},
+		{
+			name:     "rate limited by total cost",
+			content:  found + " " + missing,
+			deny:     true,
+			wantCost: 2,
+		},
+		{
+			name:        "all links fail",
+			content:     missing,
+			wantCost:    1,
+			wantFetches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fetchTrackRepository{tracks: map[string]*domain.Track{
+				found: {ID: "4iV5W9uYEdYUVa79Axb7Rh", Name: "Lemon", URL: found},
+			}}
+			limiter := &fakeLimiter{deny: tt.deny}
+			h := newGuildSettingsHandler(
+				domain.GuildSettings{GuildID: "off", AutoLink: &disabled},
+				domain.GuildSettings{GuildID: "limited", AllowedChannels: []string{"c-allowed"}},
+			)
+			h.trackUseCase = usecase.NewTrackUseCase(repo)
+			h.limiter = limiter
+			h.costs = ratelimit.DefaultCosts()
+			h.autoLink = AutoLinkConfig{Enabled: !tt.configOff}
+			s, discord := newTestSession()
+
+			m := newTestMessage(tt.content)
+			if tt.guildID != "" {
+				m.GuildID = tt.guildID
+			}
+			if tt.channelID != "" {
+				m.ChannelID = tt.channelID
+			}
+			if tt.author != nil {
+				m.Author = tt.author
+			}
+			h.HandleMessageCreate(s, m)
+
+			if tt.wantCost == 0 {
+				if len(limiter.requests) != 0 {
+					t.Errorf("limiter requests = %+v, want none", limiter.requests)
+				}
+			} else if len(limiter.requests) != 1 || limiter.requests[0].Cost != tt.wantCost {
+				t.Errorf("limiter requests = %+v, want one with cost %d", limiter.requests, tt.wantCost)
+			}
+			if got := repo.fetches.Load(); got != tt.wantFetches {
+				t.Errorf("track fetches = %d, want %d", got, tt.wantFetches)
+			}
+
+			calls := discord.calls()
+			if tt.wantEmbeds == 0 {
+				if len(calls) != 0 {
+					t.Errorf("discord requests = %v, want none", calls)
+				}
+				return
+			}
+			// 返信した後に元メッセージの Embed を抑制する
+			if len(calls) != 2 || !strings.HasPrefix(calls[0], "POST ") || !strings.HasPrefix(calls[1], "PATCH ") {
+				t.Fatalf("discord requests = %v, want reply and suppress", calls)
+			}
+			var sent discordgo.MessageSend
+			if err := json.Unmarshal([]byte(discord.body(0)), &sent); err != nil {
+				t.Fatalf("failed to decode reply: %v", err)
+			}
+			if len(sent.Embeds) != tt.wantEmbeds {
+				t.Errorf("reply embeds = %d, want %d", len(sent.Embeds), tt.wantEmbeds)
+			}
+		})
+	}
+}
+
+func TestHandleAutoLink_ShortLinksRateLimited(t *testing.T) {
+	content := "これ聴いて https://spotify.link/abc と https://spotify.link/def"
+
+	tests := []struct {
+		name      string
+		deny      bool
+		maxLinks  int
+		wantCost  int
+		wantCalls int
+	}{
+		{name: "not expanded when rate limited", deny: true, wantCost: 2},
+		{name: "expanded after take", wantCost: 2, wantCalls: 2},
+		{name: "charged up to max links", maxLinks: 1, wantCost: 1, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeLinkResolver{}
+			limiter := &fakeLimiter{deny: tt.deny}
+			h := &Handler{
+				limiter:      limiter,
+				costs:        ratelimit.DefaultCosts(),
+				linkResolver: resolver,
+				autoLink:     AutoLinkConfig{Enabled: true, MaxLinks: tt.maxLinks},
+			}
+			s, discord := newTestSession()
+
+			h.handleAutoLink(s, newTestMessage(content))
+
+			if len(limiter.requests) != 1 || limiter.requests[0].Cost != tt.wantCost {
+				t.Errorf("limiter requests = %+v, want one with cost %d", limiter.requests, tt.wantCost)
+			}
+			if resolver.calls != tt.wantCalls {
+				t.Errorf("resolver calls = %d, want %d", resolver.calls, tt.wantCalls)
+			}
+			// 展開できなかった短縮URLには返信しない
+			if got := discord.calls(); len(got) != 0 {
+				t.Errorf("discord requests = %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestBuildLinkEmbed_Playlist(t *testing.T) {
+	tracks := make([]domain.Track, 7)
+	for i := range tracks {
+		tracks[i] = domain.Track{ID: string(rune('a' + i)), Name: "Track"}
+	}
+	repo := playlistRepositoryFunc(func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+		return &domain.Playlist{ID: "37i9dQZF1DXcBWIGoYBM5M", Name: "Tokyo Super Hits!", URL: spotifyURL, Tracks: tracks}, nil
+	})
+	h := &Handler{playlistUseCase: usecase.NewPlaylistUseCase(repo)}
+
+	links := spotify.ExtractLinks("https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=x", 0)
+	if len(links) != 1 {
+		t.Fatalf("ExtractLinks() = %d links, want 1", len(links))
+	}
+
+	emb, name, err := h.buildLinkEmbed(context.Background(), i18n.Japanese, links[0])
+	if err != nil {
+		t.Fatalf("buildLinkEmbed() error = %v", err)
+	}
+	if name != "Tokyo Super Hits!" || emb.Title != "📃 Tokyo Super Hits!" {
+		t.Errorf("buildLinkEmbed() = (%q, %q), want playlist embed", emb.Title, name)
+	}
+	// ページングのボタンがないため先頭のページのみを表示する
+	if !strings.Contains(emb.Description, "(1-5 / 7 曲)") {
+		t.Errorf("Description = %q, want first page only", emb.Description)
+	}
+}
+
+// fetchTrackRepository はテスト用の取得のみを実装したトラックリポジトリです
+type fetchTrackRepository struct {
+	domain.TrackRepository
+	tracks  map[string]*domain.Track // URL ごとのトラック（ない場合はエラー）
+	fetches atomic.Int32
+}
+
+func (r *fetchTrackRepository) FetchTrack(ctx context.Context, spotifyURL string) (*domain.Track, error) {
+	r.fetches.Add(1)
+	if track, ok := r.tracks[spotifyURL]; ok {
+		return track, nil
+	}
+	return nil, &domain.UpstreamError{Kind: domain.ErrUpstream, Code: "NOT_FOUND", Status: http.StatusNotFound}
+}
+
+// playlistRepositoryFunc は関数を domain.PlaylistRepository として使うためのアダプターです
+type playlistRepositoryFunc func(ctx context.Context, spotifyURL string) (*domain.Playlist, error)
+
+func (f playlistRepositoryFunc) FetchPlaylist(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+	return f(ctx, spotifyURL)
+}
+
+// fakeLimiter はテスト用の ratelimit.RateLimiter です（deny が true の場合はすべて拒否します）
+type fakeLimiter struct {
+	deny     bool
+	requests []ratelimit.Request
+}
+
+func (f *fakeLimiter) Allow(userID string) bool {
+	return !f.deny
+}
+
+func (f *fakeLimiter) Take(req ratelimit.Request) ratelimit.Decision {
+	f.requests = append(f.requests, req)
+	if f.deny {
+		return ratelimit.Decision{Scope: ratelimit.ScopeUser, Cost: req.Cost, GuildRemaining: -1, RetryAfter: time.Second}
+	}
+	return ratelimit.Decision{Allowed: true, Cost: req.Cost, GuildRemaining: -1}
+}
+
+// fakeDiscord はテスト用に Discord API へのリクエストを記録し、空の成功レスポンスを返します
+type fakeDiscord struct {
+	mu       sync.Mutex
+	requests []string // "METHOD パス" 形式
+	bodies   []string
+}
+
+func (f *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	f.bodies = append(f.bodies, string(body))
+	f.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+// calls は記録したリクエストを返します
+func (f *fakeDiscord) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// body は i 番目のリクエストの本文を返します
+func (f *fakeDiscord) body(i int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.bodies[i]
+}
+
+// newTestSession は Discord API へのリクエストを fakeDiscord に送るセッションを作成します
+func newTestSession() (*discordgo.Session, *fakeDiscord) {
+	discord := &fakeDiscord{}
+	s, _ := discordgo.New("Bot test")
+	s.Client = &http.Client{Transport: discord}
+	s.State.User = &discordgo.User{ID: "bot"}
+	return s, discord
+}
+
+// newTestMessage はギルドのチャンネルに投稿されたユーザーのメッセージを作成します
+func newTestMessage(content string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		ID:        "m1",
+		ChannelID: "c1",
+		GuildID:   "g1",
+		Content:   content,
+		Author:    &discordgo.User{ID: "u1"},
+	}}
+}
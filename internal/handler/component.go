@@ -3,31 +3,38 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
 	"strconv"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/presenter"
 )
 
+// ページング操作時のメッセージ
+const (
+	msgCacheExpired = "データの有効期限が切れました。再度コマンドを実行してください。"
+	msgNotOwner     = "この操作はコマンド実行者のみが使用できます。『👁 自分も見る』ボタンを押すと、あなた専用の表示ができます。"
+)
+
 // handlePaging はページングボタンを処理します
 func (h *Handler) handlePaging(s *discordgo.Session, i *discordgo.InteractionCreate, messageID, action string, parts []string, userID string) {
+	loc := h.locale(i)
 	ctx := context.Background()
 
 	// キャッシュからデータを取得
 	cacheData, err := h.cache.Get(ctx, messageID)
 	if err != nil {
 		slog.Info("cache expired for button interaction", "message_id", messageID, "user_id", userID)
-		h.responder.RespondEphemeral(s, i, "データの有効期限が切れました。再度コマンドを実行してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgCacheExpired))
 		return
 	}
 
 	// 操作権限チェック
 	if cacheData.OwnerID != userID {
 		slog.Info("paging permission denied", "action", action, "owner_id", cacheData.OwnerID, "user_id", userID)
-		h.responder.RespondEphemeral(s, i, "この操作はコマンド実行者のみが使用できます。『👁 自分も見る』ボタンを押すと、あなた専用の表示ができます。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgNotOwner))
 		return
 	}
 
@@ -47,7 +54,8 @@ func (h *Handler) handlePaging(s *discordgo.Session, i *discordgo.InteractionCre
 		newPage = currentPage + 1
 	}
 
-	totalPages := (cacheData.Total + PageSize - 1) / PageSize
+	pageSize := cachedPageSize(cacheData)
+	totalPages := (cacheData.Total + pageSize - 1) / pageSize
 	if newPage >= totalPages {
 		newPage = totalPages - 1
 	}
@@ -56,8 +64,9 @@ func (h *Handler) handlePaging(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 
 	// Embedを構築
-	emb := buildEmbedFromCache(cacheData, newPage)
-	components := presenter.BuildPaginationButtons(messageID, newPage, totalPages)
+	emb := buildEmbedFromCache(loc, cacheData, newPage)
+	components := presenter.BuildPaginationButtons(loc, messageID, newPage, totalPages)
+	components = append(components, buildExtraComponentsFromCache(loc, cacheData, newPage)...)
 
 	// メッセージを更新
 	h.responder.UpdateMessage(s, i, emb, components)
@@ -66,6 +75,7 @@ func (h *Handler) handlePaging(s *discordgo.Session, i *discordgo.InteractionCre
 
 // handleViewOwn は「自分も見る」ボタンを処理します
 func (h *Handler) handleViewOwn(s *discordgo.Session, i *discordgo.InteractionCreate, messageID string) {
+	loc := h.locale(i)
 	ctx := context.Background()
 	userID := getUserID(i)
 
@@ -75,37 +85,23 @@ func (h *Handler) handleViewOwn(s *discordgo.Session, i *discordgo.InteractionCr
 	cacheData, err := h.cache.Get(ctx, messageID)
 	if err != nil {
 		slog.Info("cache expired for view_own", "message_id", messageID, "user_id", userID)
-		h.responder.RespondEphemeral(s, i, "データの有効期限が切れました。再度コマンドを実行してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgCacheExpired))
 		return
 	}
 
-	totalPages := (cacheData.Total + PageSize - 1) / PageSize
-	emb := buildEmbedFromCache(cacheData, 0)
-
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "◀ 前へ",
-					Style:    discordgo.SecondaryButton,
-					CustomID: fmt.Sprintf("ephemeral_prev:%s:0", messageID),
-					Disabled: true,
-				},
-				discordgo.Button{
-					Label:    "次へ ▶",
-					Style:    discordgo.SecondaryButton,
-					CustomID: fmt.Sprintf("ephemeral_next:%s:0", messageID),
-					Disabled: totalPages <= 1,
-				},
-			},
-		},
-	}
+	pageSize := cachedPageSize(cacheData)
+	totalPages := (cacheData.Total + pageSize - 1) / pageSize
+	emb := buildEmbedFromCache(loc, cacheData, 0)
+
+	components := presenter.BuildEphemeralPaginationButtons(loc, messageID, 0, totalPages)
+	components = append(components, buildExtraComponentsFromCache(loc, cacheData, 0)...)
 
 	h.responder.RespondEphemeralWithEmbed(s, i, emb, components)
 }
 
 // handleEphemeralPaging はエフェメラルメッセージのページングボタンを処理します
 func (h *Handler) handleEphemeralPaging(s *discordgo.Session, i *discordgo.InteractionCreate, messageID, action string, parts []string) {
+	loc := h.locale(i)
 	ctx := context.Background()
 	userID := getUserID(i)
 
@@ -113,7 +109,7 @@ func (h *Handler) handleEphemeralPaging(s *discordgo.Session, i *discordgo.Inter
 	cacheData, err := h.cache.Get(ctx, messageID)
 	if err != nil {
 		slog.Info("cache expired for ephemeral paging", "message_id", messageID, "user_id", userID)
-		h.responder.RespondEphemeral(s, i, "データの有効期限が切れました。再度コマンドを実行してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgCacheExpired))
 		return
 	}
 
@@ -133,7 +129,8 @@ func (h *Handler) handleEphemeralPaging(s *discordgo.Session, i *discordgo.Inter
 		newPage = currentPage + 1
 	}
 
-	totalPages := (cacheData.Total + PageSize - 1) / PageSize
+	pageSize := cachedPageSize(cacheData)
+	totalPages := (cacheData.Total + pageSize - 1) / pageSize
 	if newPage >= totalPages {
 		newPage = totalPages - 1
 	}
@@ -142,35 +139,39 @@ func (h *Handler) handleEphemeralPaging(s *discordgo.Session, i *discordgo.Inter
 	}
 
 	// Embedを構築
-	emb := buildEmbedFromCache(cacheData, newPage)
+	emb := buildEmbedFromCache(loc, cacheData, newPage)
 
 	// エフェメラル用のボタンを構築
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "◀ 前へ",
-					Style:    discordgo.SecondaryButton,
-					CustomID: fmt.Sprintf("ephemeral_prev:%s:%d", messageID, newPage),
-					Disabled: newPage == 0,
-				},
-				discordgo.Button{
-					Label:    "次へ ▶",
-					Style:    discordgo.SecondaryButton,
-					CustomID: fmt.Sprintf("ephemeral_next:%s:%d", messageID, newPage),
-					Disabled: newPage >= totalPages-1,
-				},
-			},
-		},
-	}
+	components := presenter.BuildEphemeralPaginationButtons(loc, messageID, newPage, totalPages)
+	components = append(components, buildExtraComponentsFromCache(loc, cacheData, newPage)...)
 
 	// エフェメラルメッセージを更新
 	h.responder.UpdateEphemeralMessage(s, i, emb, components)
 	slog.Debug("ephemeral page updated", "action", action, "message_id", messageID, "page", newPage, "total_pages", totalPages, "user_id", userID)
 }
 
-// buildEmbedFromCache はキャッシュデータからEmbedを構築します
-func buildEmbedFromCache(cacheData *domain.PaginationData, page int) *discordgo.MessageEmbed {
+// buildEmbedFromCache はキャッシュデータから指定した言語のEmbedを構築します
+func buildEmbedFromCache(loc i18n.Locale, cacheData *domain.PaginationData, page int) *discordgo.MessageEmbed {
+	pageSize := cachedPageSize(cacheData)
+
+	if cacheData.Command == "playlist" {
+		var playlist domain.Playlist
+		_ = json.Unmarshal(cacheData.Items, &playlist)
+		return presenter.BuildPlaylistEmbed(loc, &playlist, page, pageSize)
+	}
+
+	if cacheData.Command == "favorites" {
+		var items []domain.Favorite
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildFavoritesEmbed(loc, items, page, pageSize, cacheData.Total)
+	}
+
+	if cacheData.Command == "history" {
+		var items []domain.HistoryEntry
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildHistoryEmbed(loc, items, page, pageSize, cacheData.Total)
+	}
+
 	if cacheData.Command == "recommend" {
 		var items []domain.SimilarTrack
 		_ = json.Unmarshal(cacheData.Items, &items)
@@ -178,10 +179,65 @@ func buildEmbedFromCache(cacheData *domain.PaginationData, page int) *discordgo.
 		if mode == "" {
 			mode = domain.RecommendModeBalanced
 		}
-		return presenter.BuildRecommendEmbed(cacheData.Query, items, page, PageSize, cacheData.Total, mode)
+		return presenter.BuildRecommendEmbedWithSeeds(loc, cacheData.Query, cacheData.Seeds, items, page, pageSize, cacheData.Total, mode)
+	}
+
+	// 検索結果は保存した種別に応じて表示を切り替える（種別を保存していない古いデータはトラック）
+	switch domain.SearchType(cacheData.Type) {
+	case domain.SearchTypeArtist:
+		var items []domain.ArtistDetail
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildArtistSearchEmbed(loc, cacheData.Query, items, page, pageSize, cacheData.Total)
+	case domain.SearchTypeAlbum:
+		var items []domain.Album
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildAlbumSearchEmbed(loc, cacheData.Query, items, page, pageSize, cacheData.Total)
+	case domain.SearchTypeAll:
+		var items []domain.SearchItem
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildAllSearchEmbed(loc, cacheData.Query, items, page, pageSize, cacheData.Total)
 	}
 
 	var items []domain.Track
 	_ = json.Unmarshal(cacheData.Items, &items)
-	return presenter.BuildSearchEmbed(cacheData.Query, items, page, PageSize, cacheData.Total)
+	return presenter.BuildSearchEmbed(loc, cacheData.Query, items, page, pageSize, cacheData.Total)
+}
+
+// buildExtraComponentsFromCache はページングボタン以外にページごとに必要なコンポーネントを構築します
+func buildExtraComponentsFromCache(loc i18n.Locale, cacheData *domain.PaginationData, page int) []discordgo.MessageComponent {
+	pageSize := cachedPageSize(cacheData)
+
+	switch cacheData.Command {
+	case "history":
+		var items []domain.HistoryEntry
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildHistoryRerunButtons(items, page, pageSize)
+	case "recommend":
+		var items []domain.SimilarTrack
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildRecommendSelectMenu(loc, items, page, pageSize)
+	case "search":
+		// アーティスト・アルバムの検索結果は曲の詳細を選べないため、セレクトメニューを付けない
+		switch domain.SearchType(cacheData.Type) {
+		case domain.SearchTypeArtist, domain.SearchTypeAlbum:
+			return nil
+		case domain.SearchTypeAll:
+			var items []domain.SearchItem
+			_ = json.Unmarshal(cacheData.Items, &items)
+			return presenter.BuildSearchItemSelectMenu(loc, items, page, pageSize)
+		}
+		var items []domain.Track
+		_ = json.Unmarshal(cacheData.Items, &items)
+		return presenter.BuildTrackSelectMenu(loc, items, page, pageSize)
+	}
+	return nil
+}
+
+// cachedPageSize はキャッシュデータの1ページあたりの表示件数を返します
+// 表示件数を保存していない古いキャッシュデータはデフォルト値とします
+func cachedPageSize(cacheData *domain.PaginationData) int {
+	if cacheData.PageSize > 0 {
+		return cacheData.PageSize
+	}
+	return PageSize
 }
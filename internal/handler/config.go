@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+)
+
+// サーバー設定の操作時のメッセージ
+const (
+	msgConfigGuildOnly     = "❌ このコマンドはサーバー内でのみ使用できます。"
+	msgConfigNoPermission  = "❌ この操作には「サーバー管理」権限が必要です。"
+	msgConfigUpdated       = "✅ サーバー設定を更新しました。"
+	msgConfigReset         = "✅ サーバー設定をデフォルトに戻しました。"
+	msgConfigNeedChannel   = "❌ チャンネルを指定してください。"
+	msgChannelNotAllowed   = "❌ このチャンネルでは jamberry のコマンドを利用できません。"
+	msgChannelAllowedList  = "利用できるチャンネル: %s"
+	msgAutoLinkUnavailable = "⚠️ Bot 全体でリンク自動展開が無効（AUTO_LINK_EXPAND=false）のため、この設定は反映されません。"
+)
+
+// configManagePermissions は /jam config の実行に必要な権限です（管理者は常に許可）
+const configManagePermissions = discordgo.PermissionManageGuild | discordgo.PermissionAdministrator
+
+// handleConfig は /jam config サブコマンドグループを処理します
+// Discord のコマンド権限はトップレベルの /jam 単位でしか設定できないため、実行者の権限をここで確認します
+func (h *Handler) handleConfig(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
+	if len(options) == 0 {
+		return
+	}
+	if i.GuildID == "" || i.Member == nil {
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgConfigGuildOnly))
+		return
+	}
+	if !canManageConfig(i.Member) {
+		slog.Info("config permission denied", "guild_id", i.GuildID, "user_id", getUserID(i))
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgConfigNoPermission))
+		return
+	}
+
+	ctx := context.Background()
+	subCmd := options[0]
+	var (
+		settings *domain.GuildSettings
+		err      error
+		notice   = msgConfigUpdated
+	)
+	switch subCmd.Name {
+	case "show":
+		settings, err = h.guildSettingsUseCase.Get(ctx, i.GuildID)
+		notice = ""
+	case "mode":
+		settings, err = h.guildSettingsUseCase.SetRecommendMode(ctx, i.GuildID, domain.RecommendMode(optionString(subCmd.Options, "mode")))
+	case "page-size":
+		settings, err = h.guildSettingsUseCase.SetPageSize(ctx, i.GuildID, int(optionInt(subCmd.Options, "size")))
+	case "autolink":
+		settings, err = h.guildSettingsUseCase.SetAutoLink(ctx, i.GuildID, optionBool(subCmd.Options, "enabled"))
+		if err == nil && !h.autoLink.Enabled {
+			notice = msgAutoLinkUnavailable
+		}
+	case "language":
+		// auto は未設定（メンバーの Discord の言語設定に従う）に戻す
+		locale := optionString(subCmd.Options, "language")
+		if locale == "auto" {
+			locale = ""
+		}
+		settings, err = h.guildSettingsUseCase.SetLocale(ctx, i.GuildID, locale)
+	case "detail":
+		settings, err = h.guildSettingsUseCase.SetDetailVisibility(ctx, i.GuildID, domain.DetailVisibility(optionString(subCmd.Options, "visibility")))
+	case "channel":
+		settings, err = h.updateAllowedChannels(ctx, i.GuildID, subCmd.Options)
+		if err == nil && settings == nil {
+			h.responder.RespondEphemeral(s, i, i18n.T(loc, msgConfigNeedChannel))
+			return
+		}
+	case "reset":
+		if err = h.guildSettingsUseCase.Reset(ctx, i.GuildID); err == nil {
+			settings = &domain.GuildSettings{GuildID: i.GuildID}
+			notice = msgConfigReset
+		}
+	default:
+		return
+	}
+	if err != nil {
+		h.responder.RespondEphemeral(s, i, errorMessage(loc, err))
+		return
+	}
+
+	// 言語の変更は応答自体にも反映する
+	loc = h.locale(i)
+	emb := presenter.BuildGuildSettingsEmbed(loc, settings, presenter.GuildSettingsDefaults{
+		PageSize: PageSize,
+		AutoLink: h.autoLink.Enabled,
+	})
+	if notice != "" {
+		emb.Description = i18n.T(loc, notice)
+	}
+	h.responder.RespondEphemeralWithEmbed(s, i, emb, nil)
+
+	slog.Info("command completed", "command", "jam config "+subCmd.Name, "guild_id", i.GuildID, "user_id", getUserID(i))
+}
+
+// canManageConfig はメンバーがサーバー設定を変更できるかどうかを返します
+func canManageConfig(member *discordgo.Member) bool {
+	return member != nil && member.Permissions&configManagePermissions != 0
+}
+
+// updateAllowedChannels は /jam config channel の操作を適用します
+// チャンネルの指定が必要な操作でチャンネルが省略された場合は nil を返します
+func (h *Handler) updateAllowedChannels(ctx context.Context, guildID string, options []*discordgo.ApplicationCommandInteractionDataOption) (*domain.GuildSettings, error) {
+	action := optionString(options, "action")
+	if action == "clear" {
+		return h.guildSettingsUseCase.ClearAllowedChannels(ctx, guildID)
+	}
+
+	var channelID string
+	for _, opt := range options {
+		if opt.Name == "channel" {
+			channelID = opt.ChannelValue(nil).ID
+		}
+	}
+	if channelID == "" {
+		return nil, nil
+	}
+
+	if action == "remove" {
+		return h.guildSettingsUseCase.DisallowChannel(ctx, guildID, channelID)
+	}
+	return h.guildSettingsUseCase.AllowChannel(ctx, guildID, channelID)
+}
+
+// guildSettings はギルドの設定を取得します
+// 取得に失敗した場合やDMの場合は、すべて未設定（全体の設定に従う）として扱います
+func (h *Handler) guildSettings(guildID string) *domain.GuildSettings {
+	if h.guildSettingsUseCase == nil || guildID == "" {
+		return &domain.GuildSettings{GuildID: guildID}
+	}
+	settings, err := h.guildSettingsUseCase.Get(context.Background(), guildID)
+	if err != nil {
+		slog.Warn("failed to load guild settings, using defaults", "guild_id", guildID, "error", err)
+		return &domain.GuildSettings{GuildID: guildID}
+	}
+	return settings
+}
+
+// checkChannelAllowed はコマンドを実行したチャンネルがギルドの許可チャンネルに含まれるか確認します
+// 含まれない場合は利用できるチャンネルを Ephemeral で通知し、false を返します
+func (h *Handler) checkChannelAllowed(s *discordgo.Session, i *discordgo.InteractionCreate, settings *domain.GuildSettings) bool {
+	if settings.ChannelAllowed(i.ChannelID) {
+		return true
+	}
+
+	slog.Info("command rejected: channel not allowed", "guild_id", i.GuildID, "channel_id", i.ChannelID, "user_id", getUserID(i))
+	h.responder.RespondEphemeral(s, i, channelNotAllowedMessage(h.locale(i), settings.AllowedChannels))
+	return false
+}
+
+// channelNotAllowedMessage は許可チャンネル以外でコマンドを実行した場合のメッセージを指定した言語で構築します
+func channelNotAllowedMessage(loc i18n.Locale, allowed []string) string {
+	mentions := make([]string, len(allowed))
+	for idx, id := range allowed {
+		mentions[idx] = fmt.Sprintf("<#%s>", id)
+	}
+	return i18n.T(loc, msgChannelNotAllowed) + "\n" + i18n.Sprintf(loc, msgChannelAllowedList, strings.Join(mentions, " "))
+}
+
+// pageSizeOf はギルドの設定に応じた一覧の1ページあたりの表示件数を返します
+func pageSizeOf(settings *domain.GuildSettings) int {
+	if settings.PageSize > 0 {
+		return settings.PageSize
+	}
+	return PageSize
+}
+
+// autoLinkEnabled はギルドでリンク自動展開が有効かどうかを返します
+// AUTO_LINK_EXPAND=false の場合は MessageContent インテントを要求しないため、ギルドの設定に関わらず無効です
+func (h *Handler) autoLinkEnabled(settings *domain.GuildSettings) bool {
+	if !h.autoLink.Enabled {
+		return false
+	}
+	if settings.AutoLink != nil {
+		return *settings.AutoLink
+	}
+	return true
+}
+
+// optionString は名前の一致する文字列オプションの値を返します（未指定の場合は空文字）
+func optionString(options []*discordgo.ApplicationCommandInteractionDataOption, name string) string {
+	for _, opt := range options {
+		if opt.Name == name {
+			return opt.StringValue()
+		}
+	}
+	return ""
+}
+
+// optionInt は名前の一致する整数オプションの値を返します（未指定の場合は0）
+func optionInt(options []*discordgo.ApplicationCommandInteractionDataOption, name string) int64 {
+	for _, opt := range options {
+		if opt.Name == name {
+			return opt.IntValue()
+		}
+	}
+	return 0
+}
+
+// optionBool は名前の一致する真偽値オプションの値を返します（未指定の場合は false）
+func optionBool(options []*discordgo.ApplicationCommandInteractionDataOption, name string) bool {
+	for _, opt := range options {
+		if opt.Name == name {
+			return opt.BoolValue()
+		}
+	}
+	return false
+}
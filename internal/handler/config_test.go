@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// memoryGuildSettingsRepository はテスト用のインメモリのギルド設定リポジトリです
+type memoryGuildSettingsRepository struct {
+	settings map[string]domain.GuildSettings
+}
+
+func (m *memoryGuildSettingsRepository) GetGuildSettings(ctx context.Context, guildID string) (*domain.GuildSettings, error) {
+	s, ok := m.settings[guildID]
+	if !ok {
+		return nil, domain.ErrGuildSettingsNotFound
+	}
+	return &s, nil
+}
+
+func (m *memoryGuildSettingsRepository) SaveGuildSettings(ctx context.Context, settings *domain.GuildSettings) error {
+	m.settings[settings.GuildID] = *settings
+	return nil
+}
+
+func (m *memoryGuildSettingsRepository) DeleteGuildSettings(ctx context.Context, guildID string) error {
+	delete(m.settings, guildID)
+	return nil
+}
+
+// newGuildSettingsHandler はギルドの設定を持つテスト用のハンドラーを作成します
+func newGuildSettingsHandler(settings ...domain.GuildSettings) *Handler {
+	repo := &memoryGuildSettingsRepository{settings: make(map[string]domain.GuildSettings)}
+	for _, s := range settings {
+		repo.settings[s.GuildID] = s
+	}
+	return &Handler{guildSettingsUseCase: usecase.NewGuildSettingsUseCase(repo)}
+}
+
+func TestPageSizeOf(t *testing.T) {
+	if got := pageSizeOf(&domain.GuildSettings{}); got != PageSize {
+		t.Errorf("pageSizeOf(unset) = %d, want %d", got, PageSize)
+	}
+	if got := pageSizeOf(&domain.GuildSettings{PageSize: 8}); got != 8 {
+		t.Errorf("pageSizeOf(8) = %d, want 8", got)
+	}
+}
+
+func TestCachedPageSize(t *testing.T) {
+	if got := cachedPageSize(&domain.PaginationData{}); got != PageSize {
+		t.Errorf("cachedPageSize(legacy) = %d, want %d", got, PageSize)
+	}
+	if got := cachedPageSize(&domain.PaginationData{PageSize: 3}); got != 3 {
+		t.Errorf("cachedPageSize(3) = %d, want 3", got)
+	}
+}
+
+func TestAutoLinkEnabled(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name     string
+		global   bool
+		autoLink *bool
+		want     bool
+	}{
+		{name: "follows global when unset", global: true, want: true},
+		{name: "guild disables", global: true, autoLink: &disabled, want: false},
+		{name: "guild enables", global: true, autoLink: &enabled, want: true},
+		{name: "global disabled overrides guild", global: false, autoLink: &enabled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{autoLink: AutoLinkConfig{Enabled: tt.global}}
+			if got := h.autoLinkEnabled(&domain.GuildSettings{AutoLink: tt.autoLink}); got != tt.want {
+				t.Errorf("autoLinkEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuildSettings_Fallback(t *testing.T) {
+	h := newGuildSettingsHandler(domain.GuildSettings{GuildID: "g1", PageSize: 7})
+
+	if got := h.guildSettings("g1"); got.PageSize != 7 {
+		t.Errorf("guildSettings(g1).PageSize = %d, want 7", got.PageSize)
+	}
+	if got := h.guildSettings(""); got.PageSize != 0 || !got.ChannelAllowed("any") {
+		t.Errorf("guildSettings(DM) = %+v, want empty settings", got)
+	}
+
+	// ユースケース未設定の場合もデフォルトで動作する
+	if got := (&Handler{}).guildSettings("g1"); got.GuildID != "g1" || got.PageSize != 0 {
+		t.Errorf("guildSettings() without usecase = %+v, want empty settings", got)
+	}
+}
+
+func TestHandlerLocale_GuildSettings(t *testing.T) {
+	h := newGuildSettingsHandler(domain.GuildSettings{GuildID: "g1", Locale: "en"})
+	h.locales = LocaleConfig{Default: i18n.Japanese, Guilds: map[string]i18n.Locale{"g1": i18n.Japanese}}
+
+	// ギルドの設定は GUILD_LOCALES より優先し、実行者の言語よりは優先しない
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Locale: discordgo.French, GuildID: "g1"}}
+	if got := h.locale(i); got != i18n.English {
+		t.Errorf("locale() = %q, want en", got)
+	}
+	i.Locale = discordgo.Japanese
+	if got := h.locale(i); got != i18n.Japanese {
+		t.Errorf("locale() with japanese user = %q, want ja", got)
+	}
+	if got := h.guildLocale("g1"); got != i18n.English {
+		t.Errorf("guildLocale() = %q, want en", got)
+	}
+}
+
+func TestChannelNotAllowedMessage(t *testing.T) {
+	ja := channelNotAllowedMessage(i18n.Japanese, []string{"111", "222"})
+	if !strings.Contains(ja, "このチャンネルでは") || !strings.Contains(ja, "<#111> <#222>") {
+		t.Errorf("channelNotAllowedMessage(ja) = %q", ja)
+	}
+
+	en := channelNotAllowedMessage(i18n.English, []string{"111"})
+	if en != "❌ jamberry commands cannot be used in this channel.\nAllowed channels: <#111>" {
+		t.Errorf("channelNotAllowedMessage(en) = %q", en)
+	}
+}
+
+func TestCanManageConfig(t *testing.T) {
+	if canManageConfig(nil) {
+		t.Error("canManageConfig(nil) = true, want false")
+	}
+
+	tests := []struct {
+		name        string
+		permissions int64
+		want        bool
+	}{
+		{name: "manage server", permissions: discordgo.PermissionManageGuild, want: true},
+		{name: "administrator", permissions: discordgo.PermissionAdministrator, want: true},
+		{name: "regular member", permissions: discordgo.PermissionSendMessages | discordgo.PermissionManageMessages, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canManageConfig(&discordgo.Member{Permissions: tt.permissions}); got != tt.want {
+				t.Errorf("canManageConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
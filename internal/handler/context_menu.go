@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// メッセージのコンテキストメニューのコマンド名（bot.Commands の定義と一致させる）
+const (
+	contextMenuInfo      = "Spotify の情報を表示"
+	contextMenuRecommend = "この曲でおすすめを表示"
+)
+
+// コンテキストメニューの操作時のメッセージ
+const (
+	msgNoSpotifyLink      = "❌ このメッセージに Spotify のリンクが見つかりませんでした。"
+	msgNoRecommendSeedURL = "❌ このメッセージにトラックまたはアルバムの Spotify リンクが見つかりませんでした。"
+)
+
+// handleMessageCommand はメッセージのコンテキストメニューのコマンドを処理します
+// 対象メッセージの最初の Spotify リンクを使い、結果は実行者のみに表示します
+func (h *Handler) handleMessageCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	cmdData := i.ApplicationCommandData()
+
+	if cmdData.Name != contextMenuInfo && cmdData.Name != contextMenuRecommend {
+		return
+	}
+	if !h.checkChannelAllowed(s, i, h.guildSettings(i.GuildID)) {
+		return
+	}
+
+	var target *discordgo.Message
+	if cmdData.Resolved != nil {
+		target = cmdData.Resolved.Messages[cmdData.TargetID]
+	}
+	link, ok := contextMenuLink(cmdData.Name, messageLinks(target))
+	// 通常のリンクがない場合のみ短縮URLを展開する
+	expand := !ok && h.linkResolver != nil && target != nil && spotify.CountShortLinks(target.Content, 1) > 0
+	if !ok && !expand {
+		h.respondNoContextMenuLink(s, i, cmdData.Name)
+		return
+	}
+
+	// 短縮URLの展開は外部へのリクエストになるため、レートリミットを確認してから行う
+	// 情報の表示では展開するまでリンクの種別が分からないため、短縮URLのコストを消費する
+	command := "recommend"
+	if cmdData.Name == contextMenuInfo {
+		command = string(link.EntityType)
+		if expand {
+			command = costShortLink
+		}
+	}
+	if !h.checkRateLimit(s, i, command, "context_menu") {
+		return
+	}
+
+	if expand {
+		// DeferReply 前のため展開は短いタイムアウトで打ち切られる
+		link, ok = contextMenuLink(cmdData.Name, h.expandedMessageLinks(context.Background(), target))
+		if !ok {
+			h.respondNoContextMenuLink(s, i, cmdData.Name)
+			return
+		}
+	}
+
+	if cmdData.Name == contextMenuRecommend {
+		h.respondRecommend(s, i, link.URL, "", true)
+		return
+	}
+	h.respondLinkInfo(s, i, link)
+}
+
+// respondNoContextMenuLink は対象メッセージにコマンドで使える Spotify のリンクがないことを実行者のみに表示します
+func (h *Handler) respondNoContextMenuLink(s *discordgo.Session, i *discordgo.InteractionCreate, name string) {
+	msg := msgNoSpotifyLink
+	if name == contextMenuRecommend {
+		msg = msgNoRecommendSeedURL
+	}
+	slog.Info("validation failed: no spotify link in message", "command", name, "user_id", getUserID(i))
+	h.responder.RespondEphemeral(s, i, i18n.T(h.locale(i), msg))
+}
+
+// contextMenuLink はコマンドの対象にするリンクを選びます
+// レコメンドはアーティストを起点にできないため、最初のトラックまたはアルバムのリンクを選びます
+func contextMenuLink(name string, links []spotify.ValidationResult) (spotify.ValidationResult, bool) {
+	for _, link := range links {
+		if name == contextMenuInfo || link.EntityType == spotify.EntityTrack || link.EntityType == spotify.EntityAlbum {
+			return link, true
+		}
+	}
+	return spotify.ValidationResult{}, false
+}
+
+// respondLinkInfo はリンクのトラック・アーティスト・アルバム・プレイリストの情報を実行者のみに表示します
+// プレイリストは /jam playlist と同じく、お気に入り・実行履歴の対象外です
+func (h *Handler) respondLinkInfo(s *discordgo.Session, i *discordgo.InteractionCreate, link spotify.ValidationResult) {
+	loc := h.locale(i)
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", contextMenuInfo, "error", err)
+		return
+	}
+
+	emb, name, err := h.buildLinkEmbed(context.Background(), loc, link)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	var components []discordgo.MessageComponent
+	if link.EntityType != spotify.EntityPlaylist {
+		components = presenter.BuildFavoriteButton(string(link.EntityType), link.ID)
+	}
+	if _, err := h.responder.EditResponseWithComponents(s, i, emb, components); err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+	if link.EntityType != spotify.EntityPlaylist {
+		h.recordHistory(i, usecase.HistoryRecordInput{Command: string(link.EntityType), Input: link.URL, Label: name})
+	}
+	slog.Info("command completed", "command", contextMenuInfo, "type", link.EntityType, "spotify_id", link.ID)
+}
+
+// expandedMessageLinks はメッセージの本文中の短縮URLを展開してから Spotify のリンクを抽出します
+func (h *Handler) expandedMessageLinks(ctx context.Context, msg *discordgo.Message) []spotify.ValidationResult {
+	if msg == nil || h.linkResolver == nil {
+		return nil
+	}
+	expanded := *msg
+	expanded.Content = h.expandShortLinks(ctx, msg.Content, 1)
+	return messageLinks(&expanded)
+}
+
+// messageLinks はメッセージの本文と Embed の URL から Spotify のリンクを抽出します
+// Bot の Embed（jamberry 自身の応答を含む）に対しても使えるよう、Embed の URL も対象にします
+func messageLinks(msg *discordgo.Message) []spotify.ValidationResult {
+	if msg == nil {
+		return nil
+	}
+
+	texts := []string{msg.Content}
+	for _, emb := range msg.Embeds {
+		if emb != nil && emb.URL != "" {
+			texts = append(texts, emb.URL)
+		}
+	}
+	return spotify.ExtractLinks(strings.Join(texts, "\n"), 0)
+}
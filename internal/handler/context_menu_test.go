@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+func TestMessageLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *discordgo.Message
+		want []string
+	}{
+		{
+			name: "content",
+			msg:  &discordgo.Message{Content: "これ聴いて https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc"},
+			want: []string{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"},
+		},
+		{
+			name: "content and embed url",
+			msg: &discordgo.Message{
+				Content: "spotify:artist:0TnOYISbd1XYRBk9myaseg",
+				Embeds: []*discordgo.MessageEmbed{
+					{URL: "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3"},
+					{URL: "https://open.spotify.com/artist/0TnOYISbd1XYRBk9myaseg"},
+				},
+			},
+			want: []string{
+				"https://open.spotify.com/artist/0TnOYISbd1XYRBk9myaseg",
+				"https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3",
+			},
+		},
+		{
+			name: "playlist",
+			msg:  &discordgo.Message{Content: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M"},
+			want: []string{"https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M"},
+		},
+		{
+			name: "no links",
+			msg:  &discordgo.Message{Content: "hello"},
+		},
+		{
+			name: "unresolved message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := messageLinks(tt.msg)
+			if len(links) != len(tt.want) {
+				t.Fatalf("len(links) = %d, want %d", len(links), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if links[i].URL != want {
+					t.Errorf("links[%d].URL = %q, want %q", i, links[i].URL, want)
+				}
+			}
+		})
+	}
+}
+
+func TestContextMenuLink(t *testing.T) {
+	artist := spotify.ValidationResult{Valid: true, EntityType: spotify.EntityArtist, URL: "https://open.spotify.com/artist/0TnOYISbd1XYRBk9myaseg"}
+	album := spotify.ValidationResult{Valid: true, EntityType: spotify.EntityAlbum, URL: "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3"}
+
+	tests := []struct {
+		name    string
+		command string
+		links   []spotify.ValidationResult
+		want    string
+		wantOK  bool
+	}{
+		{name: "info uses first link", command: contextMenuInfo, links: []spotify.ValidationResult{artist, album}, want: artist.URL, wantOK: true},
+		{name: "recommend skips artist", command: contextMenuRecommend, links: []spotify.ValidationResult{artist, album}, want: album.URL, wantOK: true},
+		{name: "recommend without seed", command: contextMenuRecommend, links: []spotify.ValidationResult{artist}},
+		{name: "no links", command: contextMenuInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := contextMenuLink(tt.command, tt.links)
+			if ok != tt.wantOK || got.URL != tt.want {
+				t.Errorf("contextMenuLink() = (%q, %v), want (%q, %v)", got.URL, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestHandleMessageCommand_ShortLinkRateLimited(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   string
+		deny      bool
+		wantCost  int
+		wantCalls int
+	}{
+		{name: "not expanded when rate limited", command: contextMenuInfo, deny: true, wantCost: 1},
+		{name: "info charges short link cost", command: contextMenuInfo, wantCost: 1, wantCalls: 1},
+		{name: "recommend charges recommend cost", command: contextMenuRecommend, wantCost: 3, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeLinkResolver{}
+			limiter := &fakeLimiter{deny: tt.deny}
+			h := &Handler{
+				limiter:      limiter,
+				costs:        ratelimit.DefaultCosts(),
+				responder:    NewResponder(),
+				linkResolver: resolver,
+			}
+			s, discord := newTestSession()
+
+			target := &discordgo.Message{ID: "m1", Content: "これ聴いて https://spotify.link/abc"}
+			h.handleMessageCommand(s, newTestMessageCommand(tt.command, target))
+
+			if len(limiter.requests) != 1 || limiter.requests[0].Cost != tt.wantCost {
+				t.Errorf("limiter requests = %+v, want one with cost %d", limiter.requests, tt.wantCost)
+			}
+			if resolver.calls != tt.wantCalls {
+				t.Errorf("resolver calls = %d, want %d", resolver.calls, tt.wantCalls)
+			}
+			// レートリミット超過・リンクなしのどちらも Ephemeral で1回だけ応答する
+			if got := discord.calls(); len(got) != 1 || !strings.HasSuffix(got[0], "/callback") {
+				t.Errorf("discord requests = %v, want one interaction response", got)
+			}
+		})
+	}
+}
+
+// newTestMessageCommand は target を対象にしたメッセージのコンテキストメニューのインタラクションを作成します
+func newTestMessageCommand(name string, target *discordgo.Message) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "i1",
+		Type:      discordgo.InteractionApplicationCommand,
+		ChannelID: "c1",
+		GuildID:   "g1",
+		Token:     "token",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "u1"}},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name:     name,
+			TargetID: target.ID,
+			Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+				Messages: map[string]*discordgo.Message{target.ID: target},
+			},
+		},
+	}}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// handleTrackSelect は検索・レコメンドの一覧のセレクトメニューで選択した曲の詳細を表示します
+// 選択した値はトラックの Spotify ID です。一覧の操作と異なり、コマンド実行者以外も選択できます
+func (h *Handler) handleTrackSelect(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		return
+	}
+	if !h.checkRateLimit(s, i, "track", "track_select") {
+		return
+	}
+
+	h.respondTrackDetail(s, i, values[0], h.detailEphemeral(i))
+}
+
+// handleRecommendFrom は選択した曲の詳細の「この曲でおすすめ」ボタンを処理します
+// custom_id は recommend_from:<trackID> 形式です
+func (h *Handler) handleRecommendFrom(s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) {
+	if !h.checkRateLimit(s, i, "recommend", "recommend_from") {
+		return
+	}
+
+	h.respondRecommend(s, i, parts[1], "", h.detailEphemeral(i))
+}
+
+// respondTrackDetail は一覧から選択した曲の詳細を、お気に入り登録・レコメンドのボタン付きで表示します
+func (h *Handler) respondTrackDetail(s *discordgo.Session, i *discordgo.InteractionCreate, trackID string, ephemeral bool) {
+	loc := h.locale(i)
+
+	deferReply := h.responder.DeferReply
+	if ephemeral {
+		deferReply = h.responder.DeferReplyEphemeral
+	}
+	if err := deferReply(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "track_select", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	output, err := h.trackUseCase.GetTrack(ctx, usecase.TrackInput{Input: trackID})
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	emb := presenter.BuildTrackEmbed(loc, output.Track, h.trackServiceLinks(ctx, output.Track))
+	components := presenter.BuildTrackDetailButtons(loc, output.Track.ID)
+	if _, err := h.responder.EditResponseWithComponents(s, i, emb, components); err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+	h.recordHistory(i, usecase.HistoryRecordInput{Command: "track", Input: trackID, Label: output.Track.Name})
+	slog.Info("command completed", "command", "track_select", "track_name", output.Track.Name, "track_id", output.Track.ID, "ephemeral", ephemeral)
+}
+
+// detailEphemeral は一覧から選択した項目の詳細を実行者のみに表示するかどうかを返します
+// 一覧自体が実行者のみに表示されている場合は、ギルドの設定に関わらず実行者のみに表示します
+func (h *Handler) detailEphemeral(i *discordgo.InteractionCreate) bool {
+	if i.Message != nil && i.Message.Flags&discordgo.MessageFlagsEphemeral != 0 {
+		return true
+	}
+	return h.guildSettings(i.GuildID).DetailVisibility != domain.DetailVisibilityPublic
+}
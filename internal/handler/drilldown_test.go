@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+func TestDetailEphemeral(t *testing.T) {
+	h := newGuildSettingsHandler(domain.GuildSettings{GuildID: "public", DetailVisibility: domain.DetailVisibilityPublic})
+
+	tests := []struct {
+		name    string
+		guildID string
+		flags   discordgo.MessageFlags
+		want    bool
+	}{
+		{name: "unset defaults to ephemeral", guildID: "unset", want: true},
+		{name: "public setting", guildID: "public", want: false},
+		{name: "ephemeral list stays ephemeral", guildID: "public", flags: discordgo.MessageFlagsEphemeral, want: true},
+		{name: "direct message", guildID: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+				GuildID: tt.guildID,
+				Message: &discordgo.Message{Flags: tt.flags},
+			}}
+			if got := h.detailEphemeral(i); got != tt.want {
+				t.Errorf("detailEphemeral() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildExtraComponentsFromCache_TrackSelect(t *testing.T) {
+	tracks, _ := json.Marshal([]domain.Track{{ID: "t1", Name: "Lemon"}, {ID: "t2", Name: "Flamingo"}})
+	similar, _ := json.Marshal([]domain.SimilarTrack{{ID: "s1", Name: "Lemon"}})
+	artists, _ := json.Marshal([]domain.ArtistDetail{{ID: "a1", Name: "米津玄師"}})
+
+	tests := []struct {
+		name      string
+		cacheData *domain.PaginationData
+		want      []string // セレクトメニューの選択肢の値（nil の場合はメニューなし）
+	}{
+		{
+			name:      "track search",
+			cacheData: &domain.PaginationData{Command: "search", Type: "track", Items: tracks, Total: 2, PageSize: 5},
+			want:      []string{"t1", "t2"},
+		},
+		{
+			name:      "legacy search without type",
+			cacheData: &domain.PaginationData{Command: "search", Items: tracks, Total: 2, PageSize: 1},
+			want:      []string{"t1"},
+		},
+		{
+			name:      "recommend",
+			cacheData: &domain.PaginationData{Command: "recommend", Type: "track", Items: similar, Total: 1, PageSize: 5},
+			want:      []string{"s1"},
+		},
+		{
+			name:      "artist search",
+			cacheData: &domain.PaginationData{Command: "search", Type: "artist", Items: artists, Total: 1, PageSize: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := buildExtraComponentsFromCache(i18n.Japanese, tt.cacheData, 0)
+			if tt.want == nil {
+				if len(components) != 0 {
+					t.Errorf("components = %d, want none", len(components))
+				}
+				return
+			}
+			if len(components) != 1 {
+				t.Fatalf("components = %d, want 1 row", len(components))
+			}
+			menu := components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+			if len(menu.Options) != len(tt.want) {
+				t.Fatalf("options = %d, want %d", len(menu.Options), len(tt.want))
+			}
+			for idx, want := range tt.want {
+				if menu.Options[idx].Value != want {
+					t.Errorf("Options[%d].Value = %q, want %q", idx, menu.Options[idx].Value, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// ユーザー向けのエラーメッセージ（i18n のカタログのキーを兼ねます）
+const (
+	msgEmptyURL          = "❌ URL を入力してください。"
+	msgEmptyQuery        = "❌ 検索キーワードを入力してください。"
+	msgInvalidInput      = "❌ 入力形式が不正です。"
+	msgInvalidURL        = "❌ Spotify の URL を入力してください。"
+	msgInvalidResource   = "❌ 正しい種類の URL を入力してください。"
+	msgLinkNotMatched    = "❌ このリンクに対応する Spotify の曲・アルバムが見つかりませんでした。"
+	msgRateLimited       = "⏳ リクエスト制限中です。しばらくしてから再試行してください。"
+	msgUpstreamTimeout   = "❌ リクエストがタイムアウトしました。しばらくしてから再試行してください。"
+	msgConnectionError   = "❌ 接続エラーが発生しました。"
+	msgCircuitOpen       = "⚠️ TrackTaste API が一時的に利用できません。しばらくしてから再試行してください。"
+	msgServerError       = "❌ サーバーエラーが発生しました。しばらくしてから再試行してください。"
+	msgUnexpectedFailure = "❌ エラーが発生しました。しばらくしてから再試行してください。"
+)
+
+// errorMessage はユースケース・インフラ層のエラーを指定した言語のユーザー向けメッセージに変換します
+// エラーからメッセージへの対応付けと翻訳はここに集約します
+func errorMessage(loc i18n.Locale, err error) string {
+	var (
+		validationErr *usecase.ValidationError
+		notFoundErr   *usecase.NotFoundError
+		storageErr    *usecase.StorageError
+		upstreamErr   *domain.UpstreamError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		if len(validationErr.Args) > 0 {
+			return i18n.Sprintf(loc, validationErr.Message, validationErr.Args...)
+		}
+		return i18n.T(loc, validationErr.Message)
+	case errors.As(err, &notFoundErr):
+		return i18n.T(loc, notFoundErr.Message)
+	case errors.As(err, &storageErr):
+		return i18n.T(loc, storageErr.Message)
+	case errors.As(err, &upstreamErr):
+		return i18n.T(loc, upstreamMessage(upstreamErr))
+	case errors.Is(err, domain.ErrLinkNotMatched):
+		return i18n.T(loc, msgLinkNotMatched)
+	default:
+		return i18n.T(loc, msgUnexpectedFailure)
+	}
+}
+
+// upstreamMessage は外部API（tracktaste）のエラーを分類ごとのメッセージに変換します
+func upstreamMessage(err *domain.UpstreamError) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		switch err.Code {
+		case "EMPTY_PARAM", "EMPTY_URL":
+			return msgEmptyURL
+		case "EMPTY_QUERY":
+			return msgEmptyQuery
+		}
+		return msgInvalidInput
+	case errors.Is(err, domain.ErrInvalidURL):
+		return msgInvalidURL
+	case errors.Is(err, domain.ErrInvalidResource):
+		return msgInvalidResource
+	case errors.Is(err, domain.ErrRateLimited):
+		return msgRateLimited
+	case errors.Is(err, domain.ErrUpstreamTimeout):
+		return msgUpstreamTimeout
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		switch err.Code {
+		case "CIRCUIT_OPEN":
+			return msgCircuitOpen
+		case "CONNECTION_ERROR":
+			return msgConnectionError
+		}
+		return msgServerError
+	default:
+		return msgServerError
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+func TestErrorMessage(t *testing.T) {
+	upstream := func(kind error, code string) error {
+		return &domain.UpstreamError{Kind: kind, Code: code}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "validation error",
+			err:  &usecase.ValidationError{Message: "❌ Spotify の URL / ID として認識できませんでした。"},
+			want: "❌ Spotify の URL / ID として認識できませんでした。",
+		},
+		{
+			name: "not found error",
+			err:  &usecase.NotFoundError{Message: "🔍 該当する結果は見つかりませんでした。"},
+			want: "🔍 該当する結果は見つかりませんでした。",
+		},
+		{
+			name: "storage error",
+			err:  &usecase.StorageError{Message: "❌ お気に入りの保存に失敗しました。", Err: errors.New("database is locked")},
+			want: "❌ お気に入りの保存に失敗しました。",
+		},
+		{name: "empty url", err: upstream(domain.ErrInvalidInput, "EMPTY_URL"), want: msgEmptyURL},
+		{name: "empty query", err: upstream(domain.ErrInvalidInput, "EMPTY_QUERY"), want: msgEmptyQuery},
+		{name: "invalid param", err: upstream(domain.ErrInvalidInput, "INVALID_PARAM"), want: msgInvalidInput},
+		{name: "invalid url", err: upstream(domain.ErrInvalidURL, "NOT_SPOTIFY_URL"), want: msgInvalidURL},
+		{name: "invalid resource", err: upstream(domain.ErrInvalidResource, "INVALID_RESOURCE_TYPE"), want: msgInvalidResource},
+		{name: "rate limited", err: upstream(domain.ErrRateLimited, "HTTP_429"), want: msgRateLimited},
+		{name: "timeout", err: upstream(domain.ErrUpstreamTimeout, "REQUEST_TIMEOUT"), want: msgUpstreamTimeout},
+		{name: "circuit open", err: upstream(domain.ErrUpstreamUnavailable, "CIRCUIT_OPEN"), want: msgCircuitOpen},
+		{name: "connection error", err: upstream(domain.ErrUpstreamUnavailable, "CONNECTION_ERROR"), want: msgConnectionError},
+		{name: "server error", err: upstream(domain.ErrUpstreamUnavailable, "HTTP_503"), want: msgServerError},
+		{name: "unknown upstream error", err: upstream(domain.ErrUpstream, "SOMETHING_NEW"), want: msgServerError},
+		{
+			name: "wrapped upstream error",
+			err:  fmt.Errorf("fetch failed: %w", upstream(domain.ErrUpstreamTimeout, "HTTP_504")),
+			want: msgUpstreamTimeout,
+		},
+		{
+			name: "link not matched",
+			err:  fmt.Errorf("%w: track \"Lemon\"", domain.ErrLinkNotMatched),
+			want: msgLinkNotMatched,
+		},
+		{name: "unexpected error", err: errors.New("unsupported entity type: playlist"), want: msgUnexpectedFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorMessage(i18n.Japanese, tt.err); got != tt.want {
+				t.Errorf("errorMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorMessage_English(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "validation error from spotify validator",
+			err:  &usecase.ValidationError{Message: "❌ Spotify の TrackURL を入力してください"},
+			want: "❌ Please enter a Spotify track URL",
+		},
+		{
+			name: "validation error with args",
+			err:  &usecase.ValidationError{Message: "❌ お気に入りは最大 %d 件までです。不要なものを削除してください。", Args: []any{100}},
+			want: "❌ You can save up to 100 favorites. Please remove some first.",
+		},
+		{
+			name: "not found error",
+			err:  &usecase.NotFoundError{Message: "🔍 該当する結果は見つかりませんでした。"},
+			want: "🔍 No results found.",
+		},
+		{
+			name: "storage error",
+			err:  &usecase.StorageError{Message: "❌ 履歴の取得に失敗しました。", Err: errors.New("database is locked")},
+			want: "❌ Failed to load your history.",
+		},
+		{
+			name: "upstream error",
+			err:  &domain.UpstreamError{Kind: domain.ErrUpstreamUnavailable, Code: "CIRCUIT_OPEN"},
+			want: "⚠️ The TrackTaste API is temporarily unavailable. Please try again later.",
+		},
+		{
+			name: "unexpected error",
+			err:  errors.New("boom"),
+			want: "❌ An error occurred. Please try again later.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorMessage(i18n.English, tt.err); got != tt.want {
+				t.Errorf("errorMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// handleFavorites は /jam fav サブコマンドグループを処理します
+func (h *Handler) handleFavorites(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		return
+	}
+
+	subCmd := options[0]
+	switch subCmd.Name {
+	case "add":
+		h.handleFavoriteAdd(s, i, subCmd.Options)
+	case "list":
+		h.handleFavoriteList(s, i)
+	case "remove":
+		h.handleFavoriteRemove(s, i, subCmd.Options)
+	}
+}
+
+// handleFavoriteAdd はお気に入り登録コマンドを処理します
+func (h *Handler) handleFavoriteAdd(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
+	var input, entityType string
+	for _, opt := range options {
+		switch opt.Name {
+		case "url":
+			input = opt.StringValue()
+		case "type":
+			entityType = opt.StringValue()
+		}
+	}
+
+	if input == "" {
+		slog.Info("validation failed: empty input", "command", "jam fav add")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
+		return
+	}
+
+	// お気に入り操作は実行者のみに表示する
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "jam fav add", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+	output, err := h.favoritesUseCase.AddFavorite(ctx, usecase.FavoriteAddInput{
+		UserID:     getUserID(i),
+		Input:      input,
+		EntityType: spotify.EntityType(entityType),
+	})
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	h.responder.EditResponse(s, i, i18n.Sprintf(loc, "⭐ 「%s」をお気に入りに登録しました。", output.Favorite.Name))
+	slog.Info("command completed", "command", "jam fav add", "type", output.Favorite.EntityType, "spotify_id", output.Favorite.SpotifyID)
+}
+
+// handleFavoriteRemove はお気に入り削除コマンドを処理します
+func (h *Handler) handleFavoriteRemove(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
+	if len(options) == 0 {
+		slog.Info("validation failed: empty input", "command", "jam fav remove")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
+		return
+	}
+
+	input := options[0].StringValue()
+
+	// 他サービスのリンクの解決は3秒を超える場合があるため、先に応答を保留する
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "jam fav remove", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+	if err := h.favoritesUseCase.RemoveFavorite(ctx, usecase.FavoriteRemoveInput{
+		UserID: getUserID(i),
+		Input:  input,
+	}); err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	h.responder.EditResponse(s, i, i18n.T(loc, "🗑 お気に入りから削除しました。"))
+	slog.Info("command completed", "command", "jam fav remove")
+}
+
+// handleFavoriteList はお気に入り一覧コマンドを処理します（お気に入りは本人のみに表示する）
+func (h *Handler) handleFavoriteList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := h.locale(i)
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "jam fav list", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	userID := getUserID(i)
+	output, err := h.favoritesUseCase.ListFavorites(ctx, userID)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	favorites := output.Favorites
+	pageSize := pageSizeOf(h.guildSettings(i.GuildID))
+	totalPages := (len(favorites) + pageSize - 1) / pageSize
+	emb := presenter.BuildFavoritesEmbed(loc, favorites, 0, pageSize, len(favorites))
+
+	// 初期ボタン（placeholderで仮設定）
+	components := presenter.BuildEphemeralPaginationButtons(loc, "placeholder", 0, totalPages)
+
+	msg, err := h.responder.EditResponseWithComponents(s, i, emb, components)
+	if err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+
+	// キャッシュに保存
+	itemsJSON, _ := json.Marshal(favorites)
+	cacheData := &domain.PaginationData{
+		Command:  "favorites",
+		Type:     "favorite",
+		Items:    itemsJSON,
+		Total:    len(favorites),
+		OwnerID:  userID,
+		PageSize: pageSize,
+	}
+	if err := h.cache.Set(ctx, msg.ID, cacheData); err != nil {
+		slog.Warn("failed to cache data", "error", err)
+	}
+
+	// ボタンのCustomIDを更新
+	updatedComponents := presenter.BuildEphemeralPaginationButtons(loc, msg.ID, 0, totalPages)
+	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &updatedComponents,
+	})
+
+	slog.Info("command completed", "command", "jam fav list", "favorite_count", len(favorites), "message_id", msg.ID)
+}
+
+// handleFavoriteButton は「⭐ Save」ボタンを処理します
+// custom_id は fav_add:<type>:<spotifyID> 形式です
+func (h *Handler) handleFavoriteButton(s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) {
+	loc := h.locale(i)
+	if len(parts) < 3 {
+		slog.Warn("invalid favorite custom_id", "custom_id", i.MessageComponentData().CustomID)
+		return
+	}
+
+	if !h.checkRateLimit(s, i, "fav", "fav_add") {
+		return
+	}
+	userID := getUserID(i)
+
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "action", "fav_add", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	output, err := h.favoritesUseCase.AddFavorite(ctx, usecase.FavoriteAddInput{
+		UserID:     userID,
+		Input:      parts[2],
+		EntityType: spotify.EntityType(parts[1]),
+	})
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	h.responder.EditResponse(s, i, i18n.Sprintf(loc, "⭐ 「%s」をお気に入りに登録しました。", output.Favorite.Name))
+	slog.Info("favorite saved from button", "user_id", userID, "type", output.Favorite.EntityType, "spotify_id", output.Favorite.SpotifyID)
+}
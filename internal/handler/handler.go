@@ -7,21 +7,32 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
 	"github.com/t1nyb0x/jamberry/internal/infrastructure/tracktaste"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
 	"github.com/t1nyb0x/jamberry/internal/ratelimit"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 // Handler はDiscordコマンドハンドラーです
 type Handler struct {
-	trackUseCase     *usecase.TrackUseCase
-	artistUseCase    *usecase.ArtistUseCase
-	albumUseCase     *usecase.AlbumUseCase
-	recommendUseCase *usecase.RecommendUseCase
-	searchUseCase    *usecase.SearchUseCase
-	cache            domain.CacheRepository
-	limiter          *ratelimit.Limiter
-	responder        *Responder
-	ttClient         *tracktaste.Client
+	trackUseCase         *usecase.TrackUseCase
+	artistUseCase        *usecase.ArtistUseCase
+	albumUseCase         *usecase.AlbumUseCase
+	recommendUseCase     *usecase.RecommendUseCase
+	searchUseCase        *usecase.SearchUseCase
+	playlistUseCase      *usecase.PlaylistUseCase
+	favoritesUseCase     *usecase.FavoritesUseCase
+	historyUseCase       *usecase.HistoryUseCase
+	guildSettingsUseCase *usecase.GuildSettingsUseCase
+	cache                domain.CacheRepository
+	limiter              ratelimit.RateLimiter
+	costs                ratelimit.Costs
+	responder            *Responder
+	suggestions          *suggestCache
+	ttClient             *tracktaste.Client
+	linkResolver         domain.LinkResolver
+	serviceLinks         domain.ServiceLinkRepository
+	autoLink             AutoLinkConfig
+	locales              LocaleConfig
 }
 
 // NewHandler は新しいハンドラーを作成します
@@ -31,20 +42,39 @@ func NewHandler(
 	albumUC *usecase.AlbumUseCase,
 	recommendUC *usecase.RecommendUseCase,
 	searchUC *usecase.SearchUseCase,
+	playlistUC *usecase.PlaylistUseCase,
+	favoritesUC *usecase.FavoritesUseCase,
+	historyUC *usecase.HistoryUseCase,
+	guildSettingsUC *usecase.GuildSettingsUseCase,
 	cache domain.CacheRepository,
-	limiter *ratelimit.Limiter,
+	limiter ratelimit.RateLimiter,
+	costs ratelimit.Costs,
 	ttClient *tracktaste.Client,
+	linkResolver domain.LinkResolver,
+	serviceLinks domain.ServiceLinkRepository,
+	autoLink AutoLinkConfig,
+	locales LocaleConfig,
 ) *Handler {
 	return &Handler{
-		trackUseCase:     trackUC,
-		artistUseCase:    artistUC,
-		albumUseCase:     albumUC,
-		recommendUseCase: recommendUC,
-		searchUseCase:    searchUC,
-		cache:            cache,
-		limiter:          limiter,
-		responder:        NewResponder(),
-		ttClient:         ttClient,
+		trackUseCase:         trackUC,
+		artistUseCase:        artistUC,
+		albumUseCase:         albumUC,
+		recommendUseCase:     recommendUC,
+		searchUseCase:        searchUC,
+		playlistUseCase:      playlistUC,
+		favoritesUseCase:     favoritesUC,
+		historyUseCase:       historyUC,
+		guildSettingsUseCase: guildSettingsUC,
+		cache:                cache,
+		limiter:              limiter,
+		costs:                costs,
+		responder:            NewResponder(),
+		suggestions:          newSuggestCache(suggestCacheTTL, suggestCacheMaxEntries),
+		ttClient:             ttClient,
+		linkResolver:         linkResolver,
+		serviceLinks:         serviceLinks,
+		autoLink:             autoLink,
+		locales:              locales,
 	}
 }
 
@@ -55,10 +85,12 @@ func (h *Handler) HandleInteraction(s *discordgo.Session, i *discordgo.Interacti
 		h.handleCommand(s, i)
 	case discordgo.InteractionMessageComponent:
 		h.handleComponent(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		h.handleAutocomplete(s, i)
 	}
 }
 
-// handleCommand はスラッシュコマンドを処理します
+// handleCommand はスラッシュコマンドとコンテキストメニューのコマンドを処理します
 func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	userID := getUserID(i)
 
@@ -66,6 +98,19 @@ func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCr
 	cmdData := i.ApplicationCommandData()
 	cmdName := cmdData.Name
 
+	// メッセージのコンテキストメニューの処理
+	if cmdData.CommandType == discordgo.MessageApplicationCommand {
+		slog.Info("command received",
+			"guild_id", i.GuildID,
+			"channel_id", i.ChannelID,
+			"command", cmdName,
+			"user_id", userID,
+		)
+		metrics.IncCommand(cmdName, "")
+		h.handleMessageCommand(s, i)
+		return
+	}
+
 	// tracktasteコマンドの処理
 	if cmdName == "tracktaste" {
 		slog.Info("command received",
@@ -74,6 +119,7 @@ func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCr
 			"command", cmdName,
 			"user_id", userID,
 		)
+		metrics.IncCommand(cmdName, "")
 		h.handleTrackTaste(s, i)
 		return
 	}
@@ -86,6 +132,7 @@ func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCr
 			"command", cmdName,
 			"user_id", userID,
 		)
+		metrics.IncCommand(cmdName, "")
 		h.handleHelp(s, i)
 		return
 	}
@@ -107,11 +154,23 @@ func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCr
 		"subcommand", subCmdName,
 		"user_id", userID,
 	)
+	metrics.IncCommand(cmdName, subCmdName)
+
+	// サーバー設定は許可チャンネルの制限を受けない（制限の解除ができなくなるため）
+	if subCmdName == "config" {
+		if h.checkRateLimit(s, i, subCmdName, "command") {
+			h.handleConfig(s, i, options)
+		}
+		return
+	}
+
+	// 許可チャンネルのチェック（ギルドで許可チャンネルが設定されている場合のみ）
+	if !h.checkChannelAllowed(s, i, h.guildSettings(i.GuildID)) {
+		return
+	}
 
-	// レートリミットチェック
-	if !h.limiter.Allow(userID) {
-		slog.Warn("rate limit exceeded", "user_id", userID)
-		h.responder.RespondEphemeral(s, i, "⏳ 少し待ってから再試行してください。")
+	// レートリミットチェック（サブコマンドごとのコストを消費する）
+	if !h.checkRateLimit(s, i, subCmdName, "command") {
 		return
 	}
 
@@ -127,10 +186,16 @@ func (h *Handler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCr
 		h.handleRecommend(s, i, options)
 	case "search":
 		h.handleSearch(s, i, options)
+	case "playlist":
+		h.handlePlaylist(s, i, options)
+	case "fav":
+		h.handleFavorites(s, i, options)
+	case "history":
+		h.handleHistory(s, i, options)
 	}
 }
 
-// handleComponent はボタンコンポーネントを処理します
+// handleComponent はボタン・セレクトメニューのコンポーネントを処理します
 func (h *Handler) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	customID := i.MessageComponentData().CustomID
 	parts := strings.Split(customID, ":")
@@ -153,6 +218,14 @@ func (h *Handler) handleComponent(s *discordgo.Session, i *discordgo.Interaction
 		h.handleViewOwn(s, i, messageID)
 	case "ephemeral_prev", "ephemeral_next":
 		h.handleEphemeralPaging(s, i, messageID, action, parts)
+	case "fav_add":
+		h.handleFavoriteButton(s, i, parts)
+	case "history_run":
+		h.handleHistoryRerun(s, i, parts)
+	case "track_select":
+		h.handleTrackSelect(s, i)
+	case "recommend_from":
+		h.handleRecommendFrom(s, i, parts)
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/version"
 )
 
@@ -13,6 +14,21 @@ import (
 func (h *Handler) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	slog.Debug("handling help command")
 
+	embed := buildHelpEmbed(h.locale(i))
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("failed to respond with help", "error", err)
+	}
+}
+
+// buildHelpEmbed は指定した言語のヘルプEmbedを構築します
+func buildHelpEmbed(loc i18n.Locale) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title:       "🍇 jamberry ヘルプ",
 		Description: "Spotify の楽曲・アーティスト・アルバム情報を Discord で検索・共有できる Bot です。",
@@ -42,9 +58,18 @@ func (h *Handler) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreat
 					"• 収録トラック一覧",
 				Inline: false,
 			},
+			{
+				Name: "📃 `/jam playlist <url>`",
+				Value: "指定した Spotify プレイリストの詳細情報を表示します。\n" +
+					"• プレイリスト名、作成者、フォロワー数\n" +
+					"• 収録曲一覧（ページネーション対応）",
+				Inline: false,
+			},
 			{
 				Name: "✨ `/jam recommend <url> [mode]`",
 				Value: "指定したトラックに基づくおすすめ楽曲を5件表示します。\n" +
+					"アルバム/プレイリストの URL を指定すると、収録曲から最大5曲をシードにして結果を統合します（複数のシードで推薦された曲ほど上位に表示し、スコアはシードごとの平均です）。\n" +
+					"一覧のメニューで曲を選ぶと、その曲の詳細を表示します。\n" +
 					"• **バランス**: 雰囲気と関連性の両方を考慮（デフォルト）\n" +
 					"• **雰囲気重視**: BPM や音圧など音楽的特徴が似た曲\n" +
 					"• **関連性重視**: 同じアーティストやジャンルの関連曲\n\n" +
@@ -61,11 +86,47 @@ func (h *Handler) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreat
 				Inline: false,
 			},
 			{
-				Name: "🔍 `/jam search <query>`",
-				Value: "キーワードでトラックを検索します。\n" +
+				Name: "🔍 `/jam search <query> [type]`",
+				Value: "キーワードでトラック・アーティスト・アルバムを検索します。\n" +
+					"• `type` で種別を指定（track / artist / album / all、省略時はトラック）\n" +
 					"• 最大10件の検索結果を表示\n" +
 					"• ページネーション対応\n" +
-					"• 結果から詳細情報を確認可能",
+					"• 一覧のメニューで曲を選ぶと詳細を表示（詳細からおすすめも表示可能）",
+				Inline: false,
+			},
+			{
+				Name: "⭐ `/jam fav add|list|remove`",
+				Value: "トラック・アーティスト・アルバムをお気に入りとして保存します（本人のみに表示）。\n" +
+					"• `add <url> [type]`: お気に入りに登録（最大100件）\n" +
+					"• `list`: お気に入り一覧を表示（ページネーション対応）\n" +
+					"• `remove <url>`: お気に入りから削除\n" +
+					"• 詳細表示の「⭐ Save」ボタンからも登録できます",
+				Inline: false,
+			},
+			{
+				Name: "🕘 `/jam history list|clear`",
+				Value: "track / artist / album / recommend / search の実行履歴を扱います（本人のみに表示）。\n" +
+					"• `list`: 履歴を新しい順に表示し、番号ボタンで再実行\n" +
+					"• `clear`: 履歴をすべて削除\n" +
+					"• 古い履歴は保持件数（デフォルト50件）を超えると自動で削除されます",
+				Inline: false,
+			},
+			{
+				Name: "⚙️ `/jam config`",
+				Value: "サーバーごとの設定を変更します（「サーバー管理」権限が必要）。\n" +
+					"• `show`: 現在の設定を表示\n" +
+					"• `mode` / `page-size`: レコメンドのデフォルトモード、一覧の表示件数\n" +
+					"• `autolink` / `language`: リンク自動展開、応答言語\n" +
+					"• `detail`: 一覧から選択した曲の詳細を表示する範囲（自分のみ / チャンネル全体）\n" +
+					"• `channel`: コマンドを利用できるチャンネルを制限\n" +
+					"• `reset`: すべての設定をデフォルトに戻す",
+				Inline: false,
+			},
+			{
+				Name: "🖱️ メッセージのメニュー（アプリ）",
+				Value: "メッセージを右クリック（長押し）して「アプリ」から実行します（本人のみに表示）。\n" +
+					"• `Spotify の情報を表示`: メッセージ中の最初のリンクの詳細情報を表示\n" +
+					"• `この曲でおすすめを表示`: メッセージ中の最初のトラック・アルバムのリンクからおすすめ楽曲を表示",
 				Inline: false,
 			},
 			{
@@ -84,7 +145,9 @@ func (h *Handler) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreat
 				Name: "📝 対応する入力形式",
 				Value: "• **Spotify URL**: `https://open.spotify.com/track/xxxxx`\n" +
 					"• **Spotify URI**: `spotify:track:xxxxx`\n" +
-					"• **Spotify ID**: `xxxxx`（22文字の英数字）",
+					"• **Spotify ID**: `xxxxx`（22文字の英数字）\n" +
+					"• **短縮 URL**: `https://spotify.link/xxxxx`\n" +
+					"• **Apple Music / YouTube Music / Deezer**: トラック・アルバムの URL（対応する Spotify の曲を検索）",
 				Inline: false,
 			},
 		},
@@ -93,14 +156,16 @@ func (h *Handler) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreat
 		},
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
+	localizeStaticEmbed(loc, embed)
+	return embed
+}
 
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
-	}); err != nil {
-		slog.Error("failed to respond with help", "error", err)
+// localizeStaticEmbed は固定文言だけで構成された Embed のタイトル・説明・フィールドを指定した言語に翻訳します
+func localizeStaticEmbed(loc i18n.Locale, embed *discordgo.MessageEmbed) {
+	embed.Title = i18n.T(loc, embed.Title)
+	embed.Description = i18n.T(loc, embed.Description)
+	for _, field := range embed.Fields {
+		field.Name = i18n.T(loc, field.Name)
+		field.Value = i18n.T(loc, field.Value)
 	}
 }
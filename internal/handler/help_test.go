@@ -2,6 +2,9 @@ package handler
 
 import (
 	"testing"
+	"unicode"
+
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
 func TestHelpEmbedFields(t *testing.T) {
@@ -10,16 +13,21 @@ func TestHelpEmbedFields(t *testing.T) {
 		"🎵 `/jam track <url>`",
 		"👤 `/jam artist <url>`",
 		"💿 `/jam album <url>`",
+		"📃 `/jam playlist <url>`",
 		"✨ `/jam recommend <url> [mode]`",
-		"🔍 `/jam search <query>`",
+		"🔍 `/jam search <query> [type]`",
+		"⭐ `/jam fav add|list|remove`",
+		"🕘 `/jam history list|clear`",
+		"⚙️ `/jam config`",
+		"🖱️ メッセージのメニュー（アプリ）",
 		"🩺 `/tracktaste`",
 		"❓ `/help`",
 		"📝 対応する入力形式",
 	}
 
 	// フィールド数の確認
-	if len(expectedFields) != 8 {
-		t.Errorf("Expected 8 help fields, got %d", len(expectedFields))
+	if len(expectedFields) != 13 {
+		t.Errorf("Expected 13 help fields, got %d", len(expectedFields))
 	}
 }
 
@@ -56,3 +64,30 @@ func TestInputFormatHelpContent(t *testing.T) {
 		t.Errorf("Expected 3 input formats, got %d", len(expectedFormats))
 	}
 }
+
+func TestBuildHelpEmbed_Locale(t *testing.T) {
+	ja := buildHelpEmbed(i18n.Japanese)
+	if ja.Title != "🍇 jamberry ヘルプ" {
+		t.Errorf("Title = %q, want 🍇 jamberry ヘルプ", ja.Title)
+	}
+	if len(ja.Fields) != 13 {
+		t.Fatalf("Expected 13 help fields, got %d", len(ja.Fields))
+	}
+
+	en := buildHelpEmbed(i18n.English)
+	if en.Title != "🍇 jamberry Help" {
+		t.Errorf("Title = %q, want 🍇 jamberry Help", en.Title)
+	}
+	texts := []string{en.Title, en.Description}
+	for _, field := range en.Fields {
+		texts = append(texts, field.Name, field.Value)
+	}
+	for _, text := range texts {
+		for _, r := range text {
+			if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+				t.Errorf("help text should be translated, got %q", text)
+				break
+			}
+		}
+	}
+}
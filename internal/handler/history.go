@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// handleHistory は /jam history サブコマンドグループを処理します
+func (h *Handler) handleHistory(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(options) == 0 {
+		return
+	}
+
+	switch options[0].Name {
+	case "list":
+		h.handleHistoryList(s, i)
+	case "clear":
+		h.handleHistoryClear(s, i)
+	}
+}
+
+// handleHistoryList は履歴一覧コマンドを処理します（履歴は本人のみに表示する）
+func (h *Handler) handleHistoryList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := h.locale(i)
+	if err := h.responder.DeferReplyEphemeral(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "jam history list", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	userID := getUserID(i)
+	output, err := h.historyUseCase.ListHistory(ctx, userID)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	entries := output.Entries
+	pageSize := pageSizeOf(h.guildSettings(i.GuildID))
+	totalPages := (len(entries) + pageSize - 1) / pageSize
+	emb := presenter.BuildHistoryEmbed(loc, entries, 0, pageSize, len(entries))
+
+	// 初期ボタン（placeholderで仮設定）
+	components := presenter.BuildEphemeralPaginationButtons(loc, "placeholder", 0, totalPages)
+	components = append(components, presenter.BuildHistoryRerunButtons(entries, 0, pageSize)...)
+
+	msg, err := h.responder.EditResponseWithComponents(s, i, emb, components)
+	if err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+
+	// キャッシュに保存
+	itemsJSON, _ := json.Marshal(entries)
+	cacheData := &domain.PaginationData{
+		Command:  "history",
+		Type:     "history",
+		Items:    itemsJSON,
+		Total:    len(entries),
+		OwnerID:  userID,
+		PageSize: pageSize,
+	}
+	if err := h.cache.Set(ctx, msg.ID, cacheData); err != nil {
+		slog.Warn("failed to cache data", "error", err)
+	}
+
+	// ボタンのCustomIDを更新
+	updatedComponents := presenter.BuildEphemeralPaginationButtons(loc, msg.ID, 0, totalPages)
+	updatedComponents = append(updatedComponents, presenter.BuildHistoryRerunButtons(entries, 0, pageSize)...)
+	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &updatedComponents,
+	})
+
+	slog.Info("command completed", "command", "jam history list", "entry_count", len(entries), "message_id", msg.ID)
+}
+
+// handleHistoryClear は履歴削除コマンドを処理します
+func (h *Handler) handleHistoryClear(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := h.locale(i)
+	ctx := context.Background()
+	n, err := h.historyUseCase.ClearHistory(ctx, getUserID(i))
+	if err != nil {
+		h.responder.RespondEphemeral(s, i, errorMessage(loc, err))
+		return
+	}
+
+	h.responder.RespondEphemeral(s, i, i18n.Sprintf(loc, "🗑 履歴を %d 件削除しました。", n))
+	slog.Info("command completed", "command", "jam history clear", "deleted", n)
+}
+
+// handleHistoryRerun は履歴の再実行ボタンを処理します
+// custom_id は history_run:<historyID> 形式です
+func (h *Handler) handleHistoryRerun(s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) {
+	loc := h.locale(i)
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		slog.Warn("invalid history custom_id", "custom_id", i.MessageComponentData().CustomID)
+		return
+	}
+
+	userID := getUserID(i)
+	entry, err := h.historyUseCase.GetEntry(context.Background(), userID, id)
+	if err != nil {
+		h.responder.RespondEphemeral(s, i, errorMessage(loc, err))
+		return
+	}
+
+	// 再実行するコマンドのコストで判定する
+	if !h.checkRateLimit(s, i, entry.Command, "history_run") {
+		return
+	}
+
+	slog.Info("history rerun", "user_id", userID, "history_id", id, "command", entry.Command)
+
+	// 保存済みの入力からスラッシュコマンドと同じオプションを組み立てて再実行する
+	switch entry.Command {
+	case "track":
+		h.handleTrack(s, i, historyOptions("url", entry.URL))
+	case "artist":
+		h.handleArtist(s, i, historyOptions("url", entry.URL))
+	case "album":
+		h.handleAlbum(s, i, historyOptions("url", entry.URL))
+	case "recommend":
+		options := historyOptions("url", entry.URL)
+		if entry.Mode != "" {
+			options = append(options, historyOptions("mode", entry.Mode)...)
+		}
+		h.handleRecommend(s, i, options)
+	case "search":
+		options := historyOptions("query", entry.Query)
+		if entry.EntityType != "" {
+			options = append(options, historyOptions("type", entry.EntityType)...)
+		}
+		h.handleSearch(s, i, options)
+	default:
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, "❌ この履歴は再実行できません。"))
+	}
+}
+
+// historyOptions は再実行用の文字列オプションを構築します
+func historyOptions(name, value string) []*discordgo.ApplicationCommandInteractionDataOption {
+	return []*discordgo.ApplicationCommandInteractionDataOption{
+		{
+			Name:  name,
+			Type:  discordgo.ApplicationCommandOptionString,
+			Value: value,
+		},
+	}
+}
+
+// recordHistory はコマンド実行履歴を記録します（失敗してもコマンド自体は成功扱いとする）
+func (h *Handler) recordHistory(i *discordgo.InteractionCreate, input usecase.HistoryRecordInput) {
+	if h.historyUseCase == nil {
+		return
+	}
+
+	input.UserID = getUserID(i)
+	if err := h.historyUseCase.Record(context.Background(), input); err != nil {
+		slog.Warn("failed to record history", "user_id", input.UserID, "command", input.Command, "error", err)
+	}
+}
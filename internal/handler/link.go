@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/t1nyb0x/jamberry/internal/linkresolver"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+// costShortLink は短縮URLの展開で消費するレートリミットのコストの名前です
+// 展開するまでリンクの種別が分からないため、展開先の情報の取得を含めて1件あたりこのコストを消費します
+const costShortLink = "shortlink"
+
+// resolveLink は入力が Spotify の短縮URL（spotify.link など）や他サービス（Apple Music など）のリンクの場合、対応する Spotify のURLを返します
+// 短縮URLの展開に失敗した場合は入力をそのまま返し、ユースケースのバリデーションで入力エラーとして扱います
+// 他サービスのリンクに対応する Spotify のトラック・アルバムが見つからない場合はエラーを返します
+func (h *Handler) resolveLink(ctx context.Context, input string) (string, error) {
+	if h.linkResolver == nil || !linkresolver.IsResolvable(input) {
+		return input, nil
+	}
+
+	resolved, err := h.linkResolver.ResolveLink(ctx, input)
+	if err != nil {
+		if spotify.IsShortLink(input) {
+			slog.Info("short link not resolved", "url", strings.TrimSpace(input), "error", err)
+			return input, nil
+		}
+		slog.Info("link not resolved", "url", strings.TrimSpace(input), "error", err)
+		return "", err
+	}
+	return resolved, nil
+}
+
+// expandShortLinks はメッセージ本文中の Spotify の短縮URLを展開したURLに置き換えます
+// 展開する短縮URLは先頭から最大 maxLinks 件です（0以下の場合は無制限）
+func (h *Handler) expandShortLinks(ctx context.Context, content string, maxLinks int) string {
+	if h.linkResolver == nil {
+		return content
+	}
+	return spotify.ReplaceShortLinks(content, maxLinks, func(link string) string {
+		resolved, _ := h.resolveLink(ctx, link)
+		return resolved
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeLinkResolver はテスト用の domain.LinkResolver です
+type fakeLinkResolver struct {
+	links map[string]string
+	calls int
+}
+
+func (f *fakeLinkResolver) ResolveLink(ctx context.Context, rawURL string) (string, error) {
+	f.calls++
+	if resolved, ok := f.links[rawURL]; ok {
+		return resolved, nil
+	}
+	return "", errors.New("not found")
+}
+
+func TestHandler_ResolveLink(t *testing.T) {
+	resolver := &fakeLinkResolver{links: map[string]string{
+		"https://spotify.link/abc":             "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+		"https://www.deezer.com/track/3135556": "https://open.spotify.com/track/5W3cjX2J3tjhG8zb6u0qHn",
+	}}
+	h := &Handler{linkResolver: resolver}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "short link", input: "https://spotify.link/abc", want: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"},
+		{name: "unresolved short link", input: "https://spotify.link/missing", want: "https://spotify.link/missing"},
+		{name: "other service link", input: "https://www.deezer.com/track/3135556", want: "https://open.spotify.com/track/5W3cjX2J3tjhG8zb6u0qHn"},
+		{name: "unmatched other service link", input: "https://www.deezer.com/track/1", wantErr: true},
+		{name: "regular url", input: "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3", want: "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3"},
+		{name: "keyword", input: "hello", want: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.resolveLink(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// 短縮URL・他サービスのリンク以外は解決を試みない
+	if resolver.calls != 4 {
+		t.Errorf("resolver calls = %d, want 4", resolver.calls)
+	}
+}
+
+func TestHandler_ExpandShortLinks(t *testing.T) {
+	content := "これ聴いて https://spotify.link/abc と https://spotify.link/def"
+	resolver := &fakeLinkResolver{links: map[string]string{
+		"https://spotify.link/abc": "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+		"https://spotify.link/def": "https://open.spotify.com/album/1DFixLWuPkv3KT3TnV35m3",
+	}}
+
+	t.Run("expands up to max links", func(t *testing.T) {
+		h := &Handler{linkResolver: resolver}
+		want := "これ聴いて https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh と https://spotify.link/def"
+		if got := h.expandShortLinks(context.Background(), content, 1); got != want {
+			t.Errorf("expandShortLinks() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("without resolver", func(t *testing.T) {
+		h := &Handler{}
+		if got := h.expandShortLinks(context.Background(), content, 0); got != content {
+			t.Errorf("expandShortLinks() = %q, want unchanged", got)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+// LocaleConfig は応答メッセージの言語の設定です
+type LocaleConfig struct {
+	Default i18n.Locale            // ユーザー・ギルドの言語が判定できない場合の言語
+	Guilds  map[string]i18n.Locale // ギルドIDごとのデフォルト言語
+}
+
+// locale はインタラクションの応答に使う言語を返します
+// 実行者の Discord クライアントの言語 → ギルドのデフォルト言語 → 全体のデフォルト言語の順に判定します
+// ギルドのデフォルト言語は /jam config language の設定、GUILD_LOCALES、Discord のサーバーの言語の順に参照します
+func (h *Handler) locale(i *discordgo.InteractionCreate) i18n.Locale {
+	if loc, ok := i18n.FromDiscord(i.Locale); ok {
+		return loc
+	}
+	if loc, ok := h.configuredGuildLocale(i.GuildID); ok {
+		return loc
+	}
+	if i.GuildLocale != nil {
+		if loc, ok := i18n.FromDiscord(*i.GuildLocale); ok {
+			return loc
+		}
+	}
+	return h.defaultLocale()
+}
+
+// guildLocale は実行者の言語が分からない通常メッセージへの応答に使う言語を返します
+func (h *Handler) guildLocale(guildID string) i18n.Locale {
+	if loc, ok := h.configuredGuildLocale(guildID); ok {
+		return loc
+	}
+	return h.defaultLocale()
+}
+
+// configuredGuildLocale はギルドの設定（/jam config language）または GUILD_LOCALES で指定された言語を返します
+func (h *Handler) configuredGuildLocale(guildID string) (i18n.Locale, bool) {
+	if guildID == "" {
+		return "", false
+	}
+	if loc, ok := i18n.Parse(h.guildSettings(guildID).Locale); ok {
+		return loc, true
+	}
+	loc, ok := h.locales.Guilds[guildID]
+	return loc, ok
+}
+
+// defaultLocale は全体のデフォルト言語を返します
+func (h *Handler) defaultLocale() i18n.Locale {
+	if h.locales.Default == "" {
+		return i18n.DefaultLocale
+	}
+	return h.locales.Default
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+func TestHandlerLocale(t *testing.T) {
+	korean := discordgo.Korean
+	englishGuild := discordgo.EnglishUS
+
+	tests := []struct {
+		name        string
+		config      LocaleConfig
+		locale      discordgo.Locale
+		guildID     string
+		guildLocale *discordgo.Locale
+		want        i18n.Locale
+	}{
+		{
+			name:    "user locale takes precedence",
+			config:  LocaleConfig{Default: i18n.Japanese, Guilds: map[string]i18n.Locale{"g1": i18n.Japanese}},
+			locale:  discordgo.EnglishGB,
+			guildID: "g1",
+			want:    i18n.English,
+		},
+		{
+			name:    "configured guild default for unsupported user locale",
+			config:  LocaleConfig{Default: i18n.Japanese, Guilds: map[string]i18n.Locale{"g1": i18n.English}},
+			locale:  discordgo.French,
+			guildID: "g1",
+			want:    i18n.English,
+		},
+		{
+			name:        "discord guild locale when not configured",
+			config:      LocaleConfig{Default: i18n.Japanese},
+			locale:      discordgo.French,
+			guildID:     "g2",
+			guildLocale: &englishGuild,
+			want:        i18n.English,
+		},
+		{
+			name:        "global default",
+			config:      LocaleConfig{Default: i18n.English},
+			locale:      discordgo.French,
+			guildLocale: &korean,
+			want:        i18n.English,
+		},
+		{
+			name: "zero config falls back to japanese",
+			want: i18n.Japanese,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{locales: tt.config}
+			i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+				Locale:      tt.locale,
+				GuildID:     tt.guildID,
+				GuildLocale: tt.guildLocale,
+			}}
+			if got := h.locale(i); got != tt.want {
+				t.Errorf("locale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerGuildLocale(t *testing.T) {
+	h := &Handler{locales: LocaleConfig{
+		Default: i18n.Japanese,
+		Guilds:  map[string]i18n.Locale{"g1": i18n.English},
+	}}
+
+	if got := h.guildLocale("g1"); got != i18n.English {
+		t.Errorf("guildLocale(g1) = %q, want en", got)
+	}
+	if got := h.guildLocale("g2"); got != i18n.Japanese {
+		t.Errorf("guildLocale(g2) = %q, want ja", got)
+	}
+}
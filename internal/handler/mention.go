@@ -1,14 +1,16 @@
 package handler
 
 import (
-	"fmt"
 	"log/slog"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
 // HandleMessageCreate はメッセージ作成イベントを処理します
+// メンション時はヘルプを案内し、それ以外はリンク自動展開が有効な場合にSpotifyリンクを展開します
+// ギルドで許可チャンネルが設定されている場合、それ以外のチャンネルのメッセージには反応しません
 func (h *Handler) HandleMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
 	// Bot自身のメッセージは無視
 	if m.Author.ID == s.State.User.ID {
@@ -24,7 +26,16 @@ func (h *Handler) HandleMessageCreate(s *discordgo.Session, m *discordgo.Message
 		}
 	}
 
+	settings := h.guildSettings(m.GuildID)
+	if !settings.ChannelAllowed(m.ChannelID) {
+		return
+	}
+
 	if !isMentioned {
+		// 他のBotのメッセージは展開しない
+		if h.autoLinkEnabled(settings) && !m.Author.Bot {
+			h.handleAutoLink(s, m)
+		}
 		return
 	}
 
@@ -36,7 +47,7 @@ func (h *Handler) HandleMessageCreate(s *discordgo.Session, m *discordgo.Message
 	)
 
 	// ヘルプを提案するメッセージを送信
-	response := fmt.Sprintf(
+	response := i18n.Sprintf(h.guildLocale(m.GuildID),
 		"こんにちは！ 🍇\n"+
 			"jamberry の使い方は </help:%s> で確認できます。",
 		getHelpCommandID(s),
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/presenter"
+	"github.com/t1nyb0x/jamberry/internal/usecase"
+)
+
+// handlePlaylist はプレイリスト情報取得コマンドを処理します
+func (h *Handler) handlePlaylist(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
+	if len(options) == 0 {
+		slog.Info("validation failed: empty input", "command", "jam playlist")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
+		return
+	}
+
+	input := options[0].StringValue()
+
+	// DeferReply
+	if err := h.responder.DeferReply(s, i); err != nil {
+		slog.Error("failed to defer reply", "command", "jam playlist", "error", err)
+		return
+	}
+
+	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+	output, err := h.playlistUseCase.GetPlaylist(ctx, usecase.PlaylistInput{Input: input})
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
+
+	userID := getUserID(i)
+	playlist := output.Playlist
+	pageSize := pageSizeOf(h.guildSettings(i.GuildID))
+	totalPages := (len(playlist.Tracks) + pageSize - 1) / pageSize
+	emb := presenter.BuildPlaylistEmbed(loc, playlist, 0, pageSize)
+
+	// 初期ボタン（placeholderで仮設定）
+	components := presenter.BuildPaginationButtons(loc, "placeholder", 0, totalPages)
+
+	msg, err := h.responder.EditResponseWithComponents(s, i, emb, components)
+	if err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+
+	// キャッシュに保存（ヘッダー表示のためプレイリスト全体を保存する）
+	itemsJSON, _ := json.Marshal(playlist)
+	cacheData := &domain.PaginationData{
+		Command:  "playlist",
+		Query:    playlist.Name,
+		Type:     "track",
+		Items:    itemsJSON,
+		Total:    len(playlist.Tracks),
+		OwnerID:  userID,
+		PageSize: pageSize,
+	}
+	if err := h.cache.Set(ctx, msg.ID, cacheData); err != nil {
+		slog.Warn("failed to cache data", "error", err)
+	}
+
+	// ボタンのCustomIDを更新
+	updatedComponents := presenter.BuildPaginationButtons(loc, msg.ID, 0, totalPages)
+	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &updatedComponents,
+	})
+
+	slog.Info("command completed", "command", "jam playlist",
+		"playlist_name", playlist.Name,
+		"playlist_id", playlist.ID,
+		"track_count", len(playlist.Tracks),
+		"message_id", msg.ID)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"log/slog"
+	"math"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+)
+
+// checkRateLimit はサブコマンドのコストをレートリミットの枠から消費します
+// 上限を超えている場合は待ち時間を Ephemeral で通知し、false を返します
+func (h *Handler) checkRateLimit(s *discordgo.Session, i *discordgo.InteractionCreate, command, source string) bool {
+	userID := getUserID(i)
+	decision := h.limiter.Take(ratelimit.Request{
+		UserID:  userID,
+		GuildID: i.GuildID,
+		Cost:    h.costs.Of(command),
+	})
+	if decision.Allowed {
+		slog.Debug("rate limit passed",
+			"user_id", userID,
+			"guild_id", i.GuildID,
+			"command", command,
+			"cost", decision.Cost,
+			"remaining", decision.Remaining(),
+		)
+		return true
+	}
+
+	slog.Warn("rate limit exceeded",
+		"user_id", userID,
+		"guild_id", i.GuildID,
+		"source", source,
+		"command", command,
+		"scope", decision.Scope,
+		"cost", decision.Cost,
+		"retry_after", decision.RetryAfter,
+	)
+	h.responder.RespondEphemeral(s, i, rateLimitMessage(h.locale(i), decision))
+	return false
+}
+
+// rateLimitMessage はレートリミット超過時のメッセージを指定した言語で構築します
+func rateLimitMessage(loc i18n.Locale, d ratelimit.Decision) string {
+	seconds := int(math.Ceil(d.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	var msg string
+	switch d.Scope {
+	case ratelimit.ScopeGuild:
+		msg = i18n.Sprintf(loc, "⏳ このサーバーでのリクエストが集中しています。%d 秒後に再試行してください。", seconds)
+	default:
+		msg = i18n.Sprintf(loc, "⏳ リクエストが多すぎます。%d 秒後に再試行してください。", seconds)
+	}
+
+	remaining := d.Remaining()
+	if remaining < 0 {
+		remaining = 0
+	}
+	return msg + i18n.Sprintf(loc, "\n（このコマンドのコスト: %d / 残り: %d）", d.Cost, remaining)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/ratelimit"
+)
+
+func TestRateLimitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision ratelimit.Decision
+		want     string
+	}{
+		{
+			name: "user scope",
+			decision: ratelimit.Decision{
+				Scope:          ratelimit.ScopeUser,
+				Cost:           3,
+				UserRemaining:  1,
+				GuildRemaining: 20,
+				RetryAfter:     4200 * time.Millisecond,
+			},
+			want: "⏳ リクエストが多すぎます。5 秒後に再試行してください。\n（このコマンドのコスト: 3 / 残り: 1）",
+		},
+		{
+			name: "guild scope",
+			decision: ratelimit.Decision{
+				Scope:          ratelimit.ScopeGuild,
+				Cost:           1,
+				UserRemaining:  5,
+				GuildRemaining: 0,
+				RetryAfter:     2 * time.Second,
+			},
+			want: "⏳ このサーバーでのリクエストが集中しています。2 秒後に再試行してください。\n（このコマンドのコスト: 1 / 残り: 0）",
+		},
+		{
+			name: "sub-second wait is rounded up to one second",
+			decision: ratelimit.Decision{
+				Scope:          ratelimit.ScopeUser,
+				Cost:           1,
+				GuildRemaining: -1,
+				RetryAfter:     10 * time.Millisecond,
+			},
+			want: "⏳ リクエストが多すぎます。1 秒後に再試行してください。\n（このコマンドのコスト: 1 / 残り: 0）",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rateLimitMessage(i18n.Japanese, tt.decision); got != tt.want {
+				t.Errorf("rateLimitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitMessage_English(t *testing.T) {
+	decision := ratelimit.Decision{
+		Scope:          ratelimit.ScopeGuild,
+		Cost:           3,
+		UserRemaining:  5,
+		GuildRemaining: 1,
+		RetryAfter:     2 * time.Second,
+	}
+
+	want := "⏳ Too many requests from this server. Please try again in 2 seconds.\n(Cost of this command: 3 / Remaining: 1)"
+	if got := rateLimitMessage(i18n.English, decision); got != want {
+		t.Errorf("rateLimitMessage() = %q, want %q", got, want)
+	}
+}
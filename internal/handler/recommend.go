@@ -7,20 +7,22 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/presenter"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 const (
-	// PageSize は1ページあたりの表示件数です
+	// PageSize は1ページあたりの表示件数のデフォルト値です（ギルドごとに /jam config page-size で変更できます）
 	PageSize = 5
 )
 
 // handleRecommend はレコメンド取得コマンドを処理します
 func (h *Handler) handleRecommend(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
 	if len(options) == 0 {
 		slog.Info("validation failed: empty input", "command", "jam recommend")
-		h.responder.RespondEphemeral(s, i, "❌ URL を入力してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
 		return
 	}
 
@@ -39,55 +41,73 @@ func (h *Handler) handleRecommend(s *discordgo.Session, i *discordgo.Interaction
 
 	if input == "" {
 		slog.Info("validation failed: empty input", "command", "jam recommend")
-		h.responder.RespondEphemeral(s, i, "❌ URL を入力してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
 		return
 	}
 
+	h.respondRecommend(s, i, input, mode, false)
+}
+
+// respondRecommend はレコメンドを取得し、ページングボタン付きのEmbedで応答します
+// ephemeral が true の場合は実行者のみに表示し、ページングボタンもエフェメラル用にします
+func (h *Handler) respondRecommend(s *discordgo.Session, i *discordgo.InteractionCreate, input string, mode domain.RecommendMode, ephemeral bool) {
+	loc := h.locale(i)
+
+	// モード未指定の場合はギルドのデフォルトモードを使う（未設定の場合はユースケースで balanced になる）
+	settings := h.guildSettings(i.GuildID)
+	if mode == "" {
+		mode = settings.RecommendMode
+	}
+
 	// DeferReply
-	if err := h.responder.DeferReply(s, i); err != nil {
+	deferReply := h.responder.DeferReply
+	buildButtons := presenter.BuildPaginationButtons
+	if ephemeral {
+		deferReply = h.responder.DeferReplyEphemeral
+		buildButtons = presenter.BuildEphemeralPaginationButtons
+	}
+	if err := deferReply(s, i); err != nil {
 		slog.Error("failed to defer reply", "command", "jam recommend", "error", err)
 		return
 	}
 
 	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
 	output, err := h.recommendUseCase.GetRecommend(ctx, usecase.RecommendInput{
 		Input: input,
 		Mode:  mode,
 	})
 	if err != nil {
-		h.responder.EditResponse(s, i, err.Error())
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
 		return
 	}
 
 	userID := getUserID(i)
-	totalPages := (len(output.Items) + PageSize - 1) / PageSize
-	emb := presenter.BuildRecommendEmbed(output.SeedTrack.Name, output.Items, 0, PageSize, len(output.Items), output.Mode)
-
-	// 初期ボタン（placeholderで仮設定）
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "◀ 前へ",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "page_prev:placeholder:0",
-					Disabled: true,
-				},
-				discordgo.Button{
-					Label:    "次へ ▶",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "page_next:placeholder:0",
-					Disabled: totalPages <= 1,
-				},
-				discordgo.Button{
-					Label:    "👁 自分も見る",
-					Style:    discordgo.PrimaryButton,
-					CustomID: "view_own:placeholder",
-				},
-			},
-		},
+
+	// アルバム/プレイリスト起点の場合はシード元の名前とシード曲を表示する
+	query := output.SeedTrack.Name
+	var seedNames []string
+	if output.IsMultiSeed() {
+		query = output.SourceName
+		seedNames = make([]string, len(output.Seeds))
+		for idx, seed := range output.Seeds {
+			seedNames[idx] = seed.Name
+		}
 	}
 
+	pageSize := pageSizeOf(settings)
+	totalPages := (len(output.Items) + pageSize - 1) / pageSize
+	emb := presenter.BuildRecommendEmbedWithSeeds(loc, query, seedNames, output.Items, 0, pageSize, len(output.Items), output.Mode)
+
+	// 初期ボタン（placeholderで仮設定）と、表示中の曲の詳細を選ぶセレクトメニュー
+	selectMenu := presenter.BuildRecommendSelectMenu(loc, output.Items, 0, pageSize)
+	components := buildButtons(loc, "placeholder", 0, totalPages)
+	components = append(components, selectMenu...)
+
 	msg, err := h.responder.EditResponseWithComponents(s, i, emb, components)
 	if err != nil {
 		slog.Error("failed to send response", "error", err)
@@ -97,26 +117,31 @@ func (h *Handler) handleRecommend(s *discordgo.Session, i *discordgo.Interaction
 	// キャッシュに保存
 	itemsJSON, _ := json.Marshal(output.Items)
 	cacheData := &domain.PaginationData{
-		Command: "recommend",
-		Query:   output.SeedTrack.Name,
-		Type:    "track",
-		Items:   itemsJSON,
-		Total:   len(output.Items),
-		OwnerID: userID,
-		Mode:    string(output.Mode),
+		Command:  "recommend",
+		Query:    query,
+		Type:     "track",
+		Items:    itemsJSON,
+		Total:    len(output.Items),
+		OwnerID:  userID,
+		PageSize: pageSize,
+		Mode:     string(output.Mode),
+		Seeds:    seedNames,
 	}
 	if err := h.cache.Set(ctx, msg.ID, cacheData); err != nil {
 		slog.Warn("failed to cache data", "error", err)
 	}
 
 	// ボタンのCustomIDを更新
-	updatedComponents := presenter.BuildPaginationButtons(msg.ID, 0, totalPages)
+	updatedComponents := buildButtons(loc, msg.ID, 0, totalPages)
+	updatedComponents = append(updatedComponents, selectMenu...)
 	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Components: &updatedComponents,
 	})
 
+	h.recordHistory(i, usecase.HistoryRecordInput{Command: "recommend", Input: input, Mode: string(output.Mode), Label: query})
 	slog.Info("command completed", "command", "recommend",
-		"track_name", output.SeedTrack.Name,
+		"track_name", query,
+		"seed_count", len(seedNames),
 		"mode", output.Mode,
 		"result_count", len(output.Items),
 		"message_id", msg.ID)
@@ -30,6 +30,16 @@ func (r *Responder) DeferReply(s *discordgo.Session, i *discordgo.InteractionCre
 	})
 }
 
+// DeferReplyEphemeral は実行者のみに表示される遅延レスポンスを開始します
+func (r *Responder) DeferReplyEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
 // EditResponse はDeferred応答を編集します
 func (r *Responder) EditResponse(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
 	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -85,3 +95,13 @@ func (r *Responder) UpdateEphemeralMessage(s *discordgo.Session, i *discordgo.In
 		},
 	})
 }
+
+// RespondAutocomplete はオートコンプリートの候補で応答します
+func (r *Responder) RespondAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, choices []*discordgo.ApplicationCommandOptionChoice) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
@@ -7,20 +7,34 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/presenter"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 // handleSearch は検索コマンドを処理します
 func (h *Handler) handleSearch(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
-	if len(options) == 0 {
+	loc := h.locale(i)
+
+	// オプションの解析
+	var query string
+	var searchType domain.SearchType
+
+	for _, opt := range options {
+		switch opt.Name {
+		case "query":
+			query = opt.StringValue()
+		case "type":
+			searchType = domain.SearchType(opt.StringValue())
+		}
+	}
+
+	if query == "" {
 		slog.Info("validation failed: empty input", "command", "jam search")
-		h.responder.RespondEphemeral(s, i, "❌ 検索キーワードを入力してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyQuery))
 		return
 	}
 
-	query := options[0].StringValue()
-
 	// DeferReply
 	if err := h.responder.DeferReply(s, i); err != nil {
 		slog.Error("failed to defer reply", "command", "jam search", "error", err)
@@ -28,40 +42,29 @@ func (h *Handler) handleSearch(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 
 	ctx := context.Background()
-	output, err := h.searchUseCase.SearchTracks(ctx, usecase.SearchInput{Query: query})
+	output, err := h.searchUseCase.Search(ctx, usecase.SearchInput{Query: query, Type: searchType})
 	if err != nil {
-		h.responder.EditResponse(s, i, err.Error())
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
 		return
 	}
 
-	userID := getUserID(i)
-	totalPages := (len(output.Tracks) + PageSize - 1) / PageSize
-	emb := presenter.BuildSearchEmbed(output.Query, output.Tracks, 0, PageSize, len(output.Tracks))
+	// 種別ごとの検索結果をキャッシュに保存する形式にまとめ、保存したデータと同じ方法でEmbedを構築する
+	itemsJSON, _ := json.Marshal(searchResultItems(output))
+	cacheData := &domain.PaginationData{
+		Command:  "search",
+		Query:    output.Query,
+		Type:     string(output.Type),
+		Items:    itemsJSON,
+		Total:    output.Count(),
+		OwnerID:  getUserID(i),
+		PageSize: pageSizeOf(h.guildSettings(i.GuildID)),
+	}
+	totalPages := (cacheData.Total + cacheData.PageSize - 1) / cacheData.PageSize
+	emb := buildEmbedFromCache(loc, cacheData, 0)
 
 	// 初期ボタン（placeholderで仮設定）
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "◀ 前へ",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "page_prev:placeholder:0",
-					Disabled: true,
-				},
-				discordgo.Button{
-					Label:    "次へ ▶",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "page_next:placeholder:0",
-					Disabled: totalPages <= 1,
-				},
-				discordgo.Button{
-					Label:    "👁 自分も見る",
-					Style:    discordgo.PrimaryButton,
-					CustomID: "view_own:placeholder",
-				},
-			},
-		},
-	}
+	components := presenter.BuildPaginationButtons(loc, "placeholder", 0, totalPages)
+	components = append(components, buildExtraComponentsFromCache(loc, cacheData, 0)...)
 
 	msg, err := h.responder.EditResponseWithComponents(s, i, emb, components)
 	if err != nil {
@@ -70,24 +73,31 @@ func (h *Handler) handleSearch(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 
 	// キャッシュに保存
-	itemsJSON, _ := json.Marshal(output.Tracks)
-	cacheData := &domain.PaginationData{
-		Command: "search",
-		Query:   output.Query,
-		Type:    "track",
-		Items:   itemsJSON,
-		Total:   len(output.Tracks),
-		OwnerID: userID,
-	}
 	if err := h.cache.Set(ctx, msg.ID, cacheData); err != nil {
 		slog.Warn("failed to cache data", "error", err)
 	}
 
 	// ボタンのCustomIDを更新
-	updatedComponents := presenter.BuildPaginationButtons(msg.ID, 0, totalPages)
+	updatedComponents := presenter.BuildPaginationButtons(loc, msg.ID, 0, totalPages)
+	updatedComponents = append(updatedComponents, buildExtraComponentsFromCache(loc, cacheData, 0)...)
 	_, _ = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Components: &updatedComponents,
 	})
 
-	slog.Info("command completed", "command", "search", "query", output.Query, "result_count", len(output.Tracks), "message_id", msg.ID)
+	h.recordHistory(i, usecase.HistoryRecordInput{Command: "search", Input: output.Query, SearchType: string(output.Type), Label: output.Query})
+	slog.Info("command completed", "command", "search", "query", output.Query, "type", output.Type, "result_count", output.Count(), "message_id", msg.ID)
+}
+
+// searchResultItems は検索の種別に応じた結果の一覧を返します
+func searchResultItems(output *usecase.SearchOutput) any {
+	switch output.Type {
+	case domain.SearchTypeArtist:
+		return output.Artists
+	case domain.SearchTypeAlbum:
+		return output.Albums
+	case domain.SearchTypeAll:
+		return output.Items
+	default:
+		return output.Tracks
+	}
 }
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// trackServiceLinks はトラックを他のサービス（Apple Music など）で開くリンクを返します
+// リンクの取得が設定されていない場合は nil を返します
+func (h *Handler) trackServiceLinks(ctx context.Context, track *domain.Track) []domain.ServiceLink {
+	if h.serviceLinks == nil {
+		return nil
+	}
+	return h.serviceLinks.TrackLinks(ctx, track)
+}
+
+// albumServiceLinks はアルバムを他のサービス（Apple Music など）で開くリンクを返します
+// リンクの取得が設定されていない場合は nil を返します
+func (h *Handler) albumServiceLinks(ctx context.Context, album *domain.AlbumDetail) []domain.ServiceLink {
+	if h.serviceLinks == nil {
+		return nil
+	}
+	return h.serviceLinks.AlbumLinks(ctx, album)
+}
@@ -5,15 +5,17 @@ import (
 	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/presenter"
 	"github.com/t1nyb0x/jamberry/internal/usecase"
 )
 
 // handleTrack はトラック情報取得コマンドを処理します
 func (h *Handler) handleTrack(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	loc := h.locale(i)
 	if len(options) == 0 {
 		slog.Info("validation failed: empty input", "command", "jam track")
-		h.responder.RespondEphemeral(s, i, "❌ URL を入力してください。")
+		h.responder.RespondEphemeral(s, i, i18n.T(loc, msgEmptyURL))
 		return
 	}
 
@@ -26,18 +28,24 @@ func (h *Handler) handleTrack(s *discordgo.Session, i *discordgo.InteractionCrea
 	}
 
 	ctx := context.Background()
+	input, err := h.resolveLink(ctx, input)
+	if err != nil {
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
+		return
+	}
 	output, err := h.trackUseCase.GetTrack(ctx, usecase.TrackInput{Input: input})
 	if err != nil {
-		if usecase.IsValidationError(err) {
-			h.responder.EditResponse(s, i, err.Error())
-			return
-		}
-		h.responder.EditResponse(s, i, err.Error())
+		h.responder.EditResponse(s, i, errorMessage(loc, err))
 		return
 	}
 
-	// Embed構築・返信
-	emb := presenter.BuildTrackEmbed(output.Track)
-	h.responder.EditResponseEmbed(s, i, emb)
+	// Embed構築・返信（お気に入り登録ボタン付き）
+	emb := presenter.BuildTrackEmbed(loc, output.Track, h.trackServiceLinks(ctx, output.Track))
+	components := presenter.BuildFavoriteButton("track", output.Track.ID)
+	if _, err := h.responder.EditResponseWithComponents(s, i, emb, components); err != nil {
+		slog.Error("failed to send response", "error", err)
+		return
+	}
+	h.recordHistory(i, usecase.HistoryRecordInput{Command: "track", Input: input, Label: output.Track.Name})
 	slog.Info("command completed", "command", "jam track", "track_name", output.Track.Name, "track_id", output.Track.ID)
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+	"github.com/t1nyb0x/jamberry/internal/infrastructure/tracktaste"
 	"github.com/t1nyb0x/jamberry/internal/version"
 )
 
@@ -27,10 +31,13 @@ func (h *Handler) handleTrackTaste(s *discordgo.Session, i *discordgo.Interactio
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	loc := h.locale(i)
+	breakerField := buildBreakerField(loc, h.ttClient.BreakerStatuses())
+
 	health, err := h.ttClient.FetchHealth(ctx)
 	if err != nil {
 		slog.Warn("TrackTaste health check failed", "error", err)
-		h.responder.EditResponse(s, i, "❌ TrackTaste API への接続に失敗しました。")
+		h.responder.EditResponse(s, i, i18n.Sprintf(loc, "❌ TrackTaste API への接続に失敗しました。\n\n**%s**\n%s", breakerField.Name, breakerField.Value))
 		return
 	}
 
@@ -96,6 +103,8 @@ func (h *Handler) handleTrackTaste(s *discordgo.Session, i *discordgo.Interactio
 		})
 	}
 
+	embed.Fields = append(embed.Fields, breakerField)
+
 	// 応答を編集
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Embeds: &[]*discordgo.MessageEmbed{embed},
@@ -103,3 +112,24 @@ func (h *Handler) handleTrackTaste(s *discordgo.Session, i *discordgo.Interactio
 		slog.Error("failed to edit response", "error", err)
 	}
 }
+
+// buildBreakerField はエンドポイント系統ごとのサーキットブレーカーの状態を表示するフィールドを指定した言語で構築します
+func buildBreakerField(loc i18n.Locale, statuses []tracktaste.BreakerStatus) *discordgo.MessageEmbedField {
+	var sb strings.Builder
+	for _, st := range statuses {
+		switch st.State {
+		case tracktaste.BreakerOpen:
+			sb.WriteString(i18n.Sprintf(loc, "⛔ %s: %s（%d 秒後に再試行）\n", st.Family, st.State, int(math.Ceil(st.RetryIn.Seconds()))))
+		case tracktaste.BreakerHalfOpen:
+			fmt.Fprintf(&sb, "🟡 %s: %s\n", st.Family, st.State)
+		default:
+			fmt.Fprintf(&sb, "✅ %s: %s\n", st.Family, st.State)
+		}
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   "Circuit Breaker",
+		Value:  sb.String(),
+		Inline: false,
+	}
+}
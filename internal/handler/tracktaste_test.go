@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 	"github.com/t1nyb0x/jamberry/internal/infrastructure/tracktaste"
 )
 
@@ -114,3 +116,20 @@ func TestHealthResult_Services(t *testing.T) {
 		t.Errorf("Expected 2 disabled services, got %d", disabledCount)
 	}
 }
+
+func TestBuildBreakerField(t *testing.T) {
+	statuses := []tracktaste.BreakerStatus{
+		{Family: tracktaste.FamilyFetch, State: tracktaste.BreakerClosed},
+		{Family: tracktaste.FamilySearch, State: tracktaste.BreakerOpen, Failures: 5, RetryIn: 12300 * time.Millisecond},
+		{Family: tracktaste.FamilyRecommend, State: tracktaste.BreakerHalfOpen},
+	}
+
+	field := buildBreakerField(i18n.Japanese, statuses)
+	if field.Name != "Circuit Breaker" {
+		t.Errorf("Name = %q, want Circuit Breaker", field.Name)
+	}
+	want := "✅ fetch: closed\n⛔ search: open（13 秒後に再試行）\n🟡 recommend: half-open\n"
+	if field.Value != want {
+		t.Errorf("Value = %q, want %q", field.Value, want)
+	}
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCheckTimeout は依存先1件あたりのチェックのタイムアウトです
+	DefaultCheckTimeout = 3 * time.Second
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusError       = "error"
+)
+
+// CheckFunc は依存先の状態を確認する関数です（利用できない場合はエラーを返します）
+type CheckFunc func(ctx context.Context) error
+
+// namedCheck は名前付きの依存先チェックです
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker はliveness/readinessのHTTPハンドラーを提供します
+type Checker struct {
+	checks  []namedCheck
+	timeout time.Duration
+}
+
+// CheckResult は依存先1件のチェック結果です
+type CheckResult struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Report はreadinessのレスポンスです
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// NewChecker は新しいCheckerを作成します
+func NewChecker() *Checker {
+	return &Checker{timeout: DefaultCheckTimeout}
+}
+
+// Add はreadinessで確認する依存先を追加します
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Check はすべての依存先を並行して確認し、結果をまとめて返します
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status: statusOK,
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			result := CheckResult{Status: statusOK, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = statusError
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = result
+			if err != nil {
+				report.Status = statusUnavailable
+			}
+		}(nc)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// LivenessHandler はプロセスが稼働していることを返すハンドラーです（/healthz）
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": statusOK})
+	})
+}
+
+// ReadinessHandler は依存先ごとの状態をJSONで返すハンドラーです（/readyz）
+// いずれかの依存先が利用できない場合は 503 を返します
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+
+		status := http.StatusOK
+		if report.Status != statusOK {
+			status = http.StatusServiceUnavailable
+			slog.Warn("readiness check failed", "checks", report.Checks)
+		}
+		writeJSON(w, status, report)
+	})
+}
+
+// writeJSON はJSONレスポンスを書き込みます
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("failed to write health response", "error", err)
+	}
+}
@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	checker := NewChecker()
+	checker.Add("failing", func(ctx context.Context) error { return errors.New("down") })
+
+	rec := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	// livenessは依存先の状態に関係なく200を返す
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]CheckFunc
+		wantStatus int
+		wantReport string
+		wantChecks map[string]string
+	}{
+		{
+			name: "all ok",
+			checks: map[string]CheckFunc{
+				"discord": func(ctx context.Context) error { return nil },
+				"redis":   func(ctx context.Context) error { return nil },
+			},
+			wantStatus: http.StatusOK,
+			wantReport: "ok",
+			wantChecks: map[string]string{"discord": "ok", "redis": "ok"},
+		},
+		{
+			name: "one dependency down",
+			checks: map[string]CheckFunc{
+				"discord":    func(ctx context.Context) error { return nil },
+				"tracktaste": func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantReport: "unavailable",
+			wantChecks: map[string]string{"discord": "ok", "tracktaste": "error"},
+		},
+		{
+			name: "timeout",
+			checks: map[string]CheckFunc{
+				"slow": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantReport: "unavailable",
+			wantChecks: map[string]string{"slow": "error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker()
+			checker.timeout = 50 * time.Millisecond
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+
+			rec := httptest.NewRecorder()
+			checker.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %s, want application/json", ct)
+			}
+
+			var report Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			if report.Status != tt.wantReport {
+				t.Errorf("report.Status = %s, want %s", report.Status, tt.wantReport)
+			}
+			for name, want := range tt.wantChecks {
+				got, ok := report.Checks[name]
+				if !ok {
+					t.Errorf("check %s is missing", name)
+					continue
+				}
+				if got.Status != want {
+					t.Errorf("check %s status = %s, want %s", name, got.Status, want)
+				}
+				if want == "error" && got.Error == "" {
+					t.Errorf("check %s should include error message", name)
+				}
+			}
+		})
+	}
+}
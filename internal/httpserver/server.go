@@ -0,0 +1,40 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Server は運用向けエンドポイント（メトリクス・ヘルスチェック）を公開するHTTPサーバーです
+type Server struct {
+	srv *http.Server
+}
+
+// New は指定したハンドラーを公開する新しいServerを作成します
+func New(addr string, handler http.Handler) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Start はバックグラウンドでHTTPサーバーを起動します
+func (s *Server) Start() {
+	go func() {
+		slog.Info("http server listening", "addr", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server stopped unexpectedly", "addr", s.srv.Addr, "error", err)
+		}
+	}()
+}
+
+// Shutdown はHTTPサーバーを停止します
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
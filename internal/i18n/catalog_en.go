@@ -0,0 +1,309 @@
+package i18n
+
+// english は英語のメッセージカタログです（キーは日本語の原文）
+var english = map[string]string{
+	// コマンド定義（説明・選択肢名）
+	"Spotify の URL, URI, または ID を入力":                           "Spotify URL, URI, or ID",
+	"Spotify の URL, URI, ID を入力（キーワードを入力すると候補を表示）":             "Spotify URL, URI, or ID (type keywords to see suggestions)",
+	"jamberry - Spotify 情報取得 Bot":                              "jamberry - Spotify info bot",
+	"トラックの詳細情報を取得します":                                          "Show details of a track",
+	"アーティストの詳細情報を取得します":                                        "Show details of an artist",
+	"アルバムの詳細情報を取得します":                                          "Show details of an album",
+	"プレイリストの詳細情報と収録曲を取得します":                                    "Show details and tracks of a playlist",
+	"トラック・アルバム・プレイリストに基づくおすすめ楽曲を取得します":                         "Get recommendations based on a track, album, or playlist",
+	"レコメンドモード（similar: 雰囲気重視, related: 関連性重視, balanced: バランス）": "Recommendation mode (similar: vibe, related: relevance, balanced: both)",
+	"バランス（デフォルト）":                                              "Balanced (default)",
+	"トラック・アーティスト・アルバムを検索します":                                   "Search for tracks, artists, and albums",
+	"検索する種別（省略時はトラック）":                                         "Type to search for (default: track)",
+	"すべて":         "All",
+	"検索キーワードを入力":  "Search keywords",
+	"お気に入りを管理します": "Manage your favorites",
+	"トラック・アーティスト・アルバムをお気に入りに登録します": "Save a track, artist, or album to your favorites",
+	"ID のみを入力した場合の種別（省略時はトラック）":    "Type to use when only an ID is given (default: track)",
+	"トラック":   "Track",
+	"アーティスト": "Artist",
+	"お気に入りの一覧を表示します":                          "List your favorites",
+	"お気に入りから削除します":                            "Remove an item from your favorites",
+	"コマンドの実行履歴を管理します":                         "Manage your command history",
+	"実行履歴を表示し、ボタンから再実行します":                    "Show your command history and re-run commands from buttons",
+	"実行履歴をすべて削除します":                           "Delete all of your command history",
+	"TrackTaste API のステータスを確認します":             "Check the status of the TrackTaste API",
+	"jamberry のヘルプを表示します":                     "Show jamberry help",
+	"サーバーごとの設定を管理します（サーバー管理権限が必要です）":          "Manage server settings (requires Manage Server)",
+	"現在のサーバー設定を表示します":                         "Show the current server settings",
+	"レコメンドのデフォルトモードを設定します":                    "Set the default recommendation mode",
+	"モードを指定しない /jam recommend で使うモード":         "Mode used by /jam recommend when no mode is given",
+	"一覧の1ページあたりの表示件数を設定します":                   "Set the number of items per page in lists",
+	"1ページあたりの表示件数":                            "Items per page",
+	"メッセージ中の Spotify リンクの自動展開を切り替えます":         "Turn automatic expansion of Spotify links in messages on or off",
+	"自動展開を有効にするか":                             "Whether to expand links automatically",
+	"Discord の言語設定が日本語・英語以外のメンバーへの応答言語を設定します": "Set the response language for members whose Discord language is neither Japanese nor English",
+	"応答言語": "Response language",
+	"自動（Discord のサーバーの言語）": "Auto (the server's Discord language)",
+	"日本語":     "Japanese",
+	"English": "English",
+	"コマンドを利用できるチャンネルを設定します（未設定の場合は全チャンネル）": "Set the channels where commands can be used (all channels if unset)",
+	"操作":    "Action",
+	"追加":    "Add",
+	"削除":    "Remove",
+	"すべて解除": "Clear all",
+	"対象のチャンネル（追加・削除の場合）":                 "Target channel (for add/remove)",
+	"サーバー設定をすべてデフォルトに戻します":               "Reset all server settings to their defaults",
+	"検索・レコメンドの一覧から選択した曲の詳細を表示する範囲を設定します": "Set who sees the details of a track picked from search or recommendation results",
+	"詳細を表示する範囲":                          "Who sees the details",
+	"自分のみ":                               "Only you",
+	"チャンネル全体":                            "Whole channel",
+	"Spotify の情報を表示":                     "Get track info",
+	"この曲でおすすめを表示":                        "Recommend from this",
+
+	// トラック・アーティスト・アルバムの Embed
+	"アルバム":                "Album",
+	"再生時間":                "Duration",
+	"リリース日":               "Release date",
+	"リンク":                 "Link",
+	"[🔗 Spotify で開く](%s)": "[🔗 Open in Spotify](%s)",
+	"%s で検索":              "Search on %s",
+	"人気度":                 "Popularity",
+	"フォロワー":               "Followers",
+	"ジャンル":                "Genres",
+	"なし":                  "None",
+	"トラック数":               "Tracks",
+	"%d 曲":                "%d tracks",
+	"収録曲":                 "Tracks",
+	"⚠️ TrackTaste に接続できないため、次の日時に取得したキャッシュデータを表示しています": "⚠️ TrackTaste is unavailable, so this shows cached data fetched at",
+
+	// 一覧（レコメンド・検索・プレイリスト・お気に入り・履歴）とページング
+	"雰囲気重視": "Vibe",
+	"関連性重視": "Relevance",
+	"バランス":  "Balanced",
+	"「%s」の %d 曲に基づくレコメンド\n🌱 %s\n**モード**: %s (%d-%d / %d 件)": "Recommendations based on %[2]d tracks from \"%[1]s\"\n🌱 %[3]s\n**Mode**: %[4]s (%[5]d-%[6]d / %[7]d)",
+	"「%s」に基づくレコメンド\n**モード**: %s (%d-%d / %d 件)":             "Recommendations based on \"%s\"\n**Mode**: %s (%d-%d / %d)",
+	"🎶 おすすめトラック":                   "🎶 Recommended tracks",
+	"「%s」の検索結果 (%d-%d / %d 件)":     "Search results for \"%s\" (%d-%d / %d)",
+	"🔍 検索結果":                       "🔍 Search results",
+	"👥 フォロワー %s":                   "👥 %s followers",
+	"👤 %s\n収録曲 (%d-%d / %d 曲)":     "👤 %s\nTracks (%d-%d / %d)",
+	"\n※ 全 %d 曲のうち先頭 %d 曲を表示しています": "\n※ Showing the first %[2]d of %[1]d tracks",
+	"お気に入り (%d-%d / %d 件)":         "Favorites (%d-%d / %d)",
+	"⭐ お気に入り":                      "⭐ Favorites",
+	"実行履歴 (%d-%d / %d 件)\n番号ボタンで同じコマンドを再実行できます": "History (%d-%d / %d)\nPress a number button to run the same command again",
+	"（%s）":         " (%s)",
+	"🕘 履歴":         "🕘 History",
+	"◀ 前へ":         "◀ Prev",
+	"次へ ▶":         "Next ▶",
+	"👁 自分も見る":      "👁 View for me",
+	"🔎 曲を選んで詳細を表示": "🔎 Pick a track to see details",
+	"✨ この曲でおすすめ":   "✨ Recommend from this",
+	"データの有効期限が切れました。再度コマンドを実行してください。":                       "This data has expired. Please run the command again.",
+	"この操作はコマンド実行者のみが使用できます。『👁 自分も見る』ボタンを押すと、あなた専用の表示ができます。": "Only the user who ran the command can do this. Press \"👁 View for me\" to get your own copy.",
+
+	// コマンドの応答
+	"⭐ 「%s」をお気に入りに登録しました。": "⭐ Saved \"%s\" to your favorites.",
+	"🗑 お気に入りから削除しました。":     "🗑 Removed from your favorites.",
+	"🗑 履歴を %d 件削除しました。":    "🗑 Deleted %d history entries.",
+	"❌ この履歴は再実行できません。":     "❌ This history entry cannot be re-run.",
+	"こんにちは！ 🍇\n" +
+		"jamberry の使い方は </help:%s> で確認できます。": "Hi! 🍇\n" +
+		"See </help:%s> to learn how to use jamberry.",
+
+	// レートリミット
+	"⏳ このサーバーでのリクエストが集中しています。%d 秒後に再試行してください。": "⏳ Too many requests from this server. Please try again in %d seconds.",
+	"⏳ リクエストが多すぎます。%d 秒後に再試行してください。":           "⏳ Too many requests. Please try again in %d seconds.",
+	"\n（このコマンドのコスト: %d / 残り: %d）":              "\n(Cost of this command: %d / Remaining: %d)",
+
+	// /tracktaste
+	"❌ TrackTaste API への接続に失敗しました。\n\n**%s**\n%s": "❌ Failed to connect to the TrackTaste API.\n\n**%s**\n%s",
+	"⛔ %s: %s（%d 秒後に再試行）\n":                       "⛔ %s: %s (retrying in %d seconds)\n",
+
+	// エラーメッセージ
+	"❌ URL を入力してください。":                                  "❌ Please enter a URL.",
+	"❌ 検索キーワードを入力してください。":                               "❌ Please enter search keywords.",
+	"❌ 検索の種別が正しくありません。":                                 "❌ Invalid search type.",
+	"❌ 入力形式が不正です。":                                      "❌ The input format is invalid.",
+	"❌ Spotify の URL を入力してください。":                        "❌ Please enter a Spotify URL.",
+	"❌ 正しい種類の URL を入力してください。":                           "❌ Please enter a URL of the correct type.",
+	"❌ このリンクに対応する Spotify の曲・アルバムが見つかりませんでした。":          "❌ Couldn't find a matching Spotify track or album for this link.",
+	"⏳ リクエスト制限中です。しばらくしてから再試行してください。":                   "⏳ Requests are being rate limited. Please try again later.",
+	"❌ リクエストがタイムアウトしました。しばらくしてから再試行してください。":             "❌ The request timed out. Please try again later.",
+	"❌ 接続エラーが発生しました。":                                   "❌ A connection error occurred.",
+	"⚠️ TrackTaste API が一時的に利用できません。しばらくしてから再試行してください。": "⚠️ The TrackTaste API is temporarily unavailable. Please try again later.",
+	"❌ サーバーエラーが発生しました。しばらくしてから再試行してください。":               "❌ A server error occurred. Please try again later.",
+	"❌ エラーが発生しました。しばらくしてから再試行してください。":                   "❌ An error occurred. Please try again later.",
+	"❌ Spotify の URL / ID として認識できませんでした。":               "❌ Could not recognize this as a Spotify URL / ID.",
+	"❌ Spotify の TrackURL を入力してください":                    "❌ Please enter a Spotify track URL",
+	"❌ Spotify の ArtistURL を入力してください":                   "❌ Please enter a Spotify artist URL",
+	"❌ Spotify の AlbumURL を入力してください":                    "❌ Please enter a Spotify album URL",
+	"❌ Spotify の PlaylistURL を入力してください":                 "❌ Please enter a Spotify playlist URL",
+	"❌ お気に入りに登録できるのはトラック・アーティスト・アルバムのみです。":              "❌ Only tracks, artists, and albums can be saved to favorites.",
+	"❌ お気に入りの保存に失敗しました。":                                "❌ Failed to save the favorite.",
+	"❌ お気に入りは最大 %d 件までです。不要なものを削除してください。":               "❌ You can save up to %d favorites. Please remove some first.",
+	"⭐ すでにお気に入りに登録されています。":                              "⭐ This is already in your favorites.",
+	"❌ お気に入りの取得に失敗しました。":                                "❌ Failed to load your favorites.",
+	"⭐ お気に入りはまだ登録されていません。":                              "⭐ You have no favorites yet.",
+	"🔍 お気に入りに登録されていません。":                                "🔍 This is not in your favorites.",
+	"❌ お気に入りの削除に失敗しました。":                                "❌ Failed to remove the favorite.",
+	"❌ 履歴の取得に失敗しました。":                                   "❌ Failed to load your history.",
+	"🕘 履歴はまだありません。":                                     "🕘 You have no history yet.",
+	"🔍 履歴が見つかりません。削除された可能性があります。":                       "🔍 History entry not found. It may have been deleted.",
+	"❌ 履歴の削除に失敗しました。":                                   "❌ Failed to delete your history.",
+	"🔍 プレイリストに曲が含まれていません。":                              "🔍 This playlist has no tracks.",
+	"🔍 該当する結果は見つかりませんでした。":                              "🔍 No results found.",
+	"🔍 シードにできる曲が見つかりませんでした。":                            "🔍 No tracks could be used as seeds.",
+
+	// /help
+	"🍇 jamberry ヘルプ": "🍇 jamberry Help",
+	"Spotify の楽曲・アーティスト・アルバム情報を Discord で検索・共有できる Bot です。": "A bot for looking up and sharing Spotify tracks, artists, and albums on Discord.",
+	"指定した Spotify トラックの詳細情報を表示します。\n" +
+		"• 曲名、アーティスト、アルバム、リリース日\n" +
+		"• 再生時間、人気度\n" +
+		"• Spotify / KKBOX へのリンク": "Shows details of a Spotify track.\n" +
+		"• Title, artists, album, release date\n" +
+		"• Duration, popularity\n" +
+		"• Links to Spotify / KKBOX",
+	"指定した Spotify アーティストの詳細情報を表示します。\n" +
+		"• アーティスト名、ジャンル\n" +
+		"• フォロワー数、人気度\n" +
+		"• 代表曲（トップトラック）": "Shows details of a Spotify artist.\n" +
+		"• Name, genres\n" +
+		"• Followers, popularity\n" +
+		"• Top tracks",
+	"指定した Spotify アルバムの詳細情報を表示します。\n" +
+		"• アルバム名、アーティスト、リリース日\n" +
+		"• 収録曲数、総再生時間\n" +
+		"• 収録トラック一覧": "Shows details of a Spotify album.\n" +
+		"• Title, artists, release date\n" +
+		"• Number of tracks, total duration\n" +
+		"• Track list",
+	"指定した Spotify プレイリストの詳細情報を表示します。\n" +
+		"• プレイリスト名、作成者、フォロワー数\n" +
+		"• 収録曲一覧（ページネーション対応）": "Shows details of a Spotify playlist.\n" +
+		"• Name, owner, followers\n" +
+		"• Track list (paginated)",
+	"指定したトラックに基づくおすすめ楽曲を5件表示します。\n" +
+		"アルバム/プレイリストの URL を指定すると、収録曲から最大5曲をシードにして結果を統合します（複数のシードで推薦された曲ほど上位に表示し、スコアはシードごとの平均です）。\n" +
+		"一覧のメニューで曲を選ぶと、その曲の詳細を表示します。\n" +
+		"• **バランス**: 雰囲気と関連性の両方を考慮（デフォルト）\n" +
+		"• **雰囲気重視**: BPM や音圧など音楽的特徴が似た曲\n" +
+		"• **関連性重視**: 同じアーティストやジャンルの関連曲\n\n" +
+		"📊 **スコアについて**\n" +
+		"• 0〜100 の数値で類似度を表します\n" +
+		"• 雰囲気スコア: 音楽的特徴（BPM/音圧等）の一致度\n" +
+		"• 関連スコア: アーティスト/ジャンルの関連度\n\n" +
+		"🎯 **ボーナス倍率**\n" +
+		"• **×2.5**: 同一アーティストの別曲\n" +
+		"• **×1.3**: 同じグループ/ユニットのメンバー\n" +
+		"• **×1.2**: コラボ経験あり / 同じ声優\n" +
+		"• **×1.1**: 同じレーベル/プロデューサー\n" +
+		"• **×0.5**: 無関係なジャンル（ペナルティ）": "Shows 5 recommended tracks based on a track.\n" +
+		"With an album/playlist URL, up to 5 of its tracks are used as seeds and the results are merged (tracks recommended by more seeds rank higher, and the score is the average across those seeds).\n" +
+		"Pick a track from the menu under the list to see its details.\n" +
+		"• **Balanced**: considers both vibe and relevance (default)\n" +
+		"• **Vibe**: tracks with similar musical features such as BPM and loudness\n" +
+		"• **Relevance**: related tracks by the same artists or genres\n\n" +
+		"📊 **About scores**\n" +
+		"• Similarity is shown as a number from 0 to 100\n" +
+		"• Vibe score: how closely musical features (BPM, loudness, etc.) match\n" +
+		"• Relevance score: how closely artists/genres are related\n\n" +
+		"🎯 **Bonus multipliers**\n" +
+		"• **×2.5**: another track by the same artist\n" +
+		"• **×1.3**: members of the same group/unit\n" +
+		"• **×1.2**: past collaborations / same voice actor\n" +
+		"• **×1.1**: same label/producer\n" +
+		"• **×0.5**: unrelated genre (penalty)",
+	"キーワードでトラック・アーティスト・アルバムを検索します。\n" +
+		"• `type` で種別を指定（track / artist / album / all、省略時はトラック）\n" +
+		"• 最大10件の検索結果を表示\n" +
+		"• ページネーション対応\n" +
+		"• 一覧のメニューで曲を選ぶと詳細を表示（詳細からおすすめも表示可能）": "Searches for tracks, artists, and albums by keywords.\n" +
+		"• Choose what to search for with `type` (track / artist / album / all, default: track)\n" +
+		"• Shows up to 10 results\n" +
+		"• Paginated\n" +
+		"• Pick a track from the menu under the list to see its details (and get recommendations from it)",
+	"トラック・アーティスト・アルバムをお気に入りとして保存します（本人のみに表示）。\n" +
+		"• `add <url> [type]`: お気に入りに登録（最大100件）\n" +
+		"• `list`: お気に入り一覧を表示（ページネーション対応）\n" +
+		"• `remove <url>`: お気に入りから削除\n" +
+		"• 詳細表示の「⭐ Save」ボタンからも登録できます": "Saves tracks, artists, and albums as favorites (only visible to you).\n" +
+		"• `add <url> [type]`: save to favorites (up to 100)\n" +
+		"• `list`: list favorites (paginated)\n" +
+		"• `remove <url>`: remove from favorites\n" +
+		"• You can also save from the \"⭐ Save\" button on detail views",
+	"track / artist / album / recommend / search の実行履歴を扱います（本人のみに表示）。\n" +
+		"• `list`: 履歴を新しい順に表示し、番号ボタンで再実行\n" +
+		"• `clear`: 履歴をすべて削除\n" +
+		"• 古い履歴は保持件数（デフォルト50件）を超えると自動で削除されます": "Manages your track / artist / album / recommend / search history (only visible to you).\n" +
+		"• `list`: show history, newest first, and re-run with number buttons\n" +
+		"• `clear`: delete all history\n" +
+		"• Old entries are deleted automatically beyond the retention limit (default 50)",
+	"バックエンド API（TrackTaste）のステータスを確認します。\n" +
+		"• API のバージョン、稼働時間\n" +
+		"• 各サービスの接続状況": "Checks the status of the backend API (TrackTaste).\n" +
+		"• API version, uptime\n" +
+		"• Connection status of each service",
+	"このヘルプメッセージを表示します。": "Shows this help message.",
+	"📝 対応する入力形式":        "📝 Supported input formats",
+	"• **Spotify URL**: `https://open.spotify.com/track/xxxxx`\n" +
+		"• **Spotify URI**: `spotify:track:xxxxx`\n" +
+		"• **Spotify ID**: `xxxxx`（22文字の英数字）\n" +
+		"• **短縮 URL**: `https://spotify.link/xxxxx`\n" +
+		"• **Apple Music / YouTube Music / Deezer**: トラック・アルバムの URL（対応する Spotify の曲を検索）": "• **Spotify URL**: `https://open.spotify.com/track/xxxxx`\n" +
+		"• **Spotify URI**: `spotify:track:xxxxx`\n" +
+		"• **Spotify ID**: `xxxxx` (22 alphanumeric characters)\n" +
+		"• **Short URL**: `https://spotify.link/xxxxx`\n" +
+		"• **Apple Music / YouTube Music / Deezer**: track or album URLs (looked up on Spotify)",
+
+	// サーバー設定（/jam config）
+	"⚙️ サーバー設定":  "⚙️ Server settings",
+	"レコメンドモード":   "Recommendation mode",
+	"1ページの表示件数":  "Items per page",
+	"リンク自動展開":    "Link auto-expansion",
+	"言語":         "Language",
+	"利用できるチャンネル": "Allowed channels",
+	"選択した項目の詳細":  "Details of picked items",
+	"すべてのチャンネル":  "All channels",
+	"%s（デフォルト）":  "%s (default)",
+	"有効":         "Enabled",
+	"無効":         "Disabled",
+	"無効（Bot 全体で無効化されています）":                                          "Disabled (turned off for the whole bot)",
+	"/jam config の各サブコマンドで変更できます（サーバー管理権限が必要です）":                    "Change these with the /jam config subcommands (requires Manage Server)",
+	"❌ このコマンドはサーバー内でのみ使用できます。":                                      "❌ This command can only be used in a server.",
+	"❌ この操作には「サーバー管理」権限が必要です。":                                      "❌ This action requires the Manage Server permission.",
+	"✅ サーバー設定を更新しました。":                                              "✅ Server settings updated.",
+	"✅ サーバー設定をデフォルトに戻しました。":                                         "✅ Server settings have been reset to their defaults.",
+	"❌ チャンネルを指定してください。":                                             "❌ Please specify a channel.",
+	"❌ このチャンネルでは jamberry のコマンドを利用できません。":                           "❌ jamberry commands cannot be used in this channel.",
+	"利用できるチャンネル: %s":                                                "Allowed channels: %s",
+	"⚠️ Bot 全体でリンク自動展開が無効（AUTO_LINK_EXPAND=false）のため、この設定は反映されません。": "⚠️ Link auto-expansion is disabled for the whole bot (AUTO_LINK_EXPAND=false), so this setting has no effect.",
+	"❌ サーバー設定の取得に失敗しました。":                                           "❌ Failed to load the server settings.",
+	"❌ サーバー設定の保存に失敗しました。":                                           "❌ Failed to save the server settings.",
+	"❌ レコメンドモードは similar / related / balanced のいずれかを指定してください。":      "❌ The recommendation mode must be one of similar / related / balanced.",
+	"❌ 表示件数は %d〜%d 件の範囲で指定してください。":                                  "❌ The number of items per page must be between %d and %d.",
+	"❌ 詳細の表示範囲は ephemeral / public のいずれかを指定してください。":                 "❌ The details visibility must be either ephemeral or public.",
+	"❌ 言語は ja / en のいずれかを指定してください。":                                 "❌ The language must be either ja or en.",
+	"❌ 許可チャンネルは最大 %d 件までです。":                                        "❌ You can allow up to %d channels.",
+	"ℹ️ このチャンネルはすでに許可されています。":                                       "ℹ️ This channel is already allowed.",
+	"🔍 このチャンネルは許可リストに登録されていません。":                                    "🔍 This channel is not in the allowed list.",
+	"サーバーごとの設定を変更します（「サーバー管理」権限が必要）。\n" +
+		"• `show`: 現在の設定を表示\n" +
+		"• `mode` / `page-size`: レコメンドのデフォルトモード、一覧の表示件数\n" +
+		"• `autolink` / `language`: リンク自動展開、応答言語\n" +
+		"• `detail`: 一覧から選択した曲の詳細を表示する範囲（自分のみ / チャンネル全体）\n" +
+		"• `channel`: コマンドを利用できるチャンネルを制限\n" +
+		"• `reset`: すべての設定をデフォルトに戻す": "Changes per-server settings (requires the Manage Server permission).\n" +
+		"• `show`: show the current settings\n" +
+		"• `mode` / `page-size`: default recommendation mode, items per page in lists\n" +
+		"• `autolink` / `language`: link auto-expansion, response language\n" +
+		"• `detail`: who sees the details of a track picked from a list (only you / the whole channel)\n" +
+		"• `channel`: restrict the channels where commands can be used\n" +
+		"• `reset`: reset all settings to their defaults",
+
+	// メッセージのコンテキストメニュー
+	"❌ このメッセージに Spotify のリンクが見つかりませんでした。":            "❌ No Spotify link was found in this message.",
+	"❌ このメッセージにトラックまたはアルバムの Spotify リンクが見つかりませんでした。": "❌ No Spotify track or album link was found in this message.",
+	"🖱️ メッセージのメニュー（アプリ）":                             "🖱️ Message menu (Apps)",
+	"メッセージを右クリック（長押し）して「アプリ」から実行します（本人のみに表示）。\n" +
+		"• `Spotify の情報を表示`: メッセージ中の最初のリンクの詳細情報を表示\n" +
+		"• `この曲でおすすめを表示`: メッセージ中の最初のトラック・アルバムのリンクからおすすめ楽曲を表示": "Right-click (or long-press) a message and choose Apps (only visible to you).\n" +
+		"• `Get track info`: show details of the first link in the message\n" +
+		"• `Recommend from this`: show recommendations based on the first track or album link in the message",
+}
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Locale は応答メッセージの言語です
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+
+	// DefaultLocale はユーザー・ギルドの言語が判定できない場合の言語です
+	DefaultLocale = Japanese
+)
+
+// catalogs は言語ごとのメッセージカタログです
+// キーは日本語の原文（fmt の書式文字列を含む）で、日本語はカタログを持たず原文をそのまま使います
+var catalogs = map[Locale]map[string]string{
+	English: english,
+}
+
+// discordLocales は Discord のロケールと対応する言語です
+var discordLocales = map[discordgo.Locale]Locale{
+	discordgo.Japanese:  Japanese,
+	discordgo.EnglishUS: English,
+	discordgo.EnglishGB: English,
+}
+
+// Parse は "ja" / "en" / "en-US" 形式の文字列を言語に変換します
+func Parse(s string) (Locale, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if lang, _, ok := strings.Cut(s, "-"); ok {
+		s = lang
+	}
+	switch Locale(s) {
+	case Japanese, English:
+		return Locale(s), true
+	default:
+		return "", false
+	}
+}
+
+// FromDiscord は Discord のロケールを言語に変換します（未対応のロケールの場合は false）
+func FromDiscord(l discordgo.Locale) (Locale, bool) {
+	loc, ok := discordLocales[l]
+	return loc, ok
+}
+
+// T はメッセージを指定した言語に翻訳します
+// カタログに訳がない場合は原文（日本語）を返します
+func T(loc Locale, msg string) string {
+	if translated, ok := catalogs[loc][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf は書式文字列を翻訳してから fmt.Sprintf で整形します
+// 訳文では %[2]s のように引数の位置を指定して語順を入れ替えられます
+func Sprintf(loc Locale, format string, args ...any) string {
+	return fmt.Sprintf(T(loc, format), args...)
+}
+
+// DiscordLocalizations はコマンドの説明・選択肢名に設定する Discord ロケールごとの訳を返します
+// 原文（日本語）はコマンド定義のデフォルト値として使うため含めません
+func DiscordLocalizations(msg string) map[discordgo.Locale]string {
+	localizations := make(map[discordgo.Locale]string)
+	for discordLocale, loc := range discordLocales {
+		if translated, ok := catalogs[loc][msg]; ok {
+			localizations[discordLocale] = translated
+		}
+	}
+	if len(localizations) == 0 {
+		return nil
+	}
+	return localizations
+}
@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Locale
+		wantOK bool
+	}{
+		{input: "ja", want: Japanese, wantOK: true},
+		{input: "en", want: English, wantOK: true},
+		{input: "en-US", want: English, wantOK: true},
+		{input: " EN-gb ", want: English, wantOK: true},
+		{input: "fr", wantOK: false},
+		{input: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := Parse(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Parse(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFromDiscord(t *testing.T) {
+	tests := []struct {
+		locale discordgo.Locale
+		want   Locale
+		wantOK bool
+	}{
+		{locale: discordgo.Japanese, want: Japanese, wantOK: true},
+		{locale: discordgo.EnglishUS, want: English, wantOK: true},
+		{locale: discordgo.EnglishGB, want: English, wantOK: true},
+		{locale: discordgo.Korean, wantOK: false},
+		{locale: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			got, ok := FromDiscord(tt.locale)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FromDiscord(%q) = (%q, %v), want (%q, %v)", tt.locale, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  Locale
+		msg  string
+		want string
+	}{
+		{name: "japanese returns source", loc: Japanese, msg: "アルバム", want: "アルバム"},
+		{name: "english translation", loc: English, msg: "アルバム", want: "Album"},
+		{name: "missing translation falls back to source", loc: English, msg: "未登録のメッセージ", want: "未登録のメッセージ"},
+		{name: "unknown locale falls back to source", loc: Locale("fr"), msg: "アルバム", want: "アルバム"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.loc, tt.msg); got != tt.want {
+				t.Errorf("T() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	format := "\n※ 全 %d 曲のうち先頭 %d 曲を表示しています"
+
+	if got, want := Sprintf(Japanese, format, 250, 100), "\n※ 全 250 曲のうち先頭 100 曲を表示しています"; got != want {
+		t.Errorf("Sprintf(ja) = %q, want %q", got, want)
+	}
+	// 訳文では引数の位置を指定して語順を入れ替える
+	if got, want := Sprintf(English, format, 250, 100), "\n※ Showing the first 100 of 250 tracks"; got != want {
+		t.Errorf("Sprintf(en) = %q, want %q", got, want)
+	}
+}
+
+func TestDiscordLocalizations(t *testing.T) {
+	got := DiscordLocalizations("トラック・アーティスト・アルバムを検索します")
+	if len(got) != 2 || got[discordgo.EnglishUS] != "Search for tracks, artists, and albums" || got[discordgo.EnglishGB] != "Search for tracks, artists, and albums" {
+		t.Errorf("DiscordLocalizations() = %v, want en-US and en-GB translations", got)
+	}
+	if _, ok := got[discordgo.Japanese]; ok {
+		t.Error("DiscordLocalizations() should not include the source language")
+	}
+
+	if got := DiscordLocalizations("未登録のメッセージ"); got != nil {
+		t.Errorf("DiscordLocalizations() = %v, want nil for untranslated message", got)
+	}
+}
+
+// verbRegex は fmt の書式指定子（引数の位置指定を含む）にマッチします
+var verbRegex = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+
+// formatArgs は書式文字列が参照する引数の位置と書式指定子の対応を返します
+func formatArgs(format string) map[int]string {
+	args := make(map[int]string)
+	next := 1
+	for _, m := range verbRegex.FindAllStringSubmatch(format, -1) {
+		if m[2] == "%" {
+			continue
+		}
+		if m[1] != "" {
+			next, _ = strconv.Atoi(m[1])
+		}
+		args[next] = m[2]
+		next++
+	}
+	return args
+}
+
+func TestCatalogFormatArgs(t *testing.T) {
+	for loc, catalog := range catalogs {
+		for src, translated := range catalog {
+			want := formatArgs(src)
+			got := formatArgs(translated)
+			if len(got) != len(want) {
+				t.Errorf("[%s] %q: translation uses %d args, source uses %d", loc, src, len(got), len(want))
+				continue
+			}
+			for idx, verb := range want {
+				if got[idx] != verb {
+					t.Errorf("[%s] %q: arg %d formatted as %%%s, source uses %%%s", loc, src, idx, got[idx], verb)
+				}
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
 )
 
 const (
@@ -17,30 +18,35 @@ const (
 	L1TTL = 10 * time.Minute
 	// L2TTL はL2キャッシュ（Redis）のTTLです
 	L2TTL = 30 * 24 * time.Hour // 30日
-)
 
-// l1Entry はL1キャッシュのエントリを表します
-type l1Entry struct {
-	data      *domain.PaginationData
-	expiresAt time.Time
-}
+	// maxRedisFailures はRedisを利用不可とみなすまでの連続失敗回数です
+	maxRedisFailures = 3
+	// redisPingTimeout はRedisの疎通確認のタイムアウトです
+	redisPingTimeout = 2 * time.Second
+)
 
 // Manager はキャッシュマネージャーです
 // domain.CacheRepository インターフェースを実装します
 type Manager struct {
-	l1       sync.Map
-	redis    *redis.Client
-	redisOK  bool
-	redisMux sync.RWMutex
+	l1            *lruCache
+	redisOpts     *redis.Options // nil の場合はRedisを使用しない（URL不正）
+	redis         *redis.Client
+	redisOK       bool
+	redisFailures int // Get/Set/Delete の連続失敗回数
+	redisMux      sync.RWMutex
+	now           func() time.Time
 }
 
 // インターフェース実装の確認
 var _ domain.CacheRepository = (*Manager)(nil)
 
 // NewManager は新しいキャッシュマネージャーを作成します
-func NewManager(redisURL string) *Manager {
+// L1キャッシュは l1Config の上限（エントリ数・データ量）を超えると古く使われたものから退避されます
+func NewManager(redisURL string, l1Config L1Config) *Manager {
 	m := &Manager{
+		l1:      newLRUCache(l1Config),
 		redisOK: false,
+		now:     time.Now,
 	}
 
 	// Redis接続
@@ -50,16 +56,18 @@ func NewManager(redisURL string) *Manager {
 		return m
 	}
 
-	client := redis.NewClient(opts)
+	// 起動時に接続できなくても、ヘルスチェックで復旧できるようにクライアントは保持する
+	m.redisOpts = opts
+	m.redis = redis.NewClient(opts)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := client.Ping(ctx).Err(); err != nil {
-		slog.Warn("failed to connect to redis, running without L2 cache", "error", err)
+	if err := m.redis.Ping(ctx).Err(); err != nil {
+		slog.Warn("failed to connect to redis, running without L2 cache until it recovers", "error", err)
 		return m
 	}
 
-	m.redis = client
 	m.redisOK = true
 	slog.Info("connected to redis")
 
@@ -76,21 +84,23 @@ func (m *Manager) Set(ctx context.Context, key string, data *domain.PaginationDa
 	cacheKey := makeKey(key)
 
 	// L1に保存
-	m.l1.Store(cacheKey, &l1Entry{
-		data:      data,
-		expiresAt: time.Now().Add(L1TTL),
-	})
-	slog.Debug("cache set to L1", "key", cacheKey, "command", data.Command, "total", data.Total)
+	if m.l1.set(cacheKey, data, estimateSize(cacheKey, data), m.now().Add(L1TTL)) {
+		slog.Debug("cache set to L1", "key", cacheKey, "command", data.Command, "total", data.Total)
+	} else {
+		slog.Debug("cache entry too large for L1, skipped", "key", cacheKey, "command", data.Command, "total", data.Total)
+	}
 
 	// L2に保存
-	if m.isRedisOK() {
+	if client := m.l2Client(); client != nil {
 		jsonData, err := json.Marshal(data)
 		if err != nil {
 			slog.Warn("failed to marshal cache data", "key", cacheKey, "error", err)
 			return nil // L1には保存済みなのでエラーは返さない
 		}
 
-		if err := m.redis.Set(ctx, cacheKey, jsonData, L2TTL).Err(); err != nil {
+		err = client.Set(ctx, cacheKey, jsonData, L2TTL).Err()
+		m.recordRedisResult(err)
+		if err != nil {
 			slog.Warn("failed to set cache in redis", "key", cacheKey, "error", err)
 		} else {
 			slog.Debug("cache set to L2", "key", cacheKey)
@@ -105,34 +115,34 @@ func (m *Manager) Get(ctx context.Context, key string) (*domain.PaginationData,
 	cacheKey := makeKey(key)
 
 	// L1から取得
-	if entry, ok := m.l1.Load(cacheKey); ok {
-		l1e := entry.(*l1Entry)
-		if time.Now().Before(l1e.expiresAt) {
+	if value, ok := m.l1.get(cacheKey, m.now()); ok {
+		if data, ok := value.(*domain.PaginationData); ok {
+			metrics.ObserveCache(metrics.CacheLayerL1, true)
 			slog.Debug("cache hit L1", "key", cacheKey)
-			return l1e.data, nil
+			return data, nil
 		}
-		// 期限切れの場合は削除
-		m.l1.Delete(cacheKey)
-		slog.Debug("cache expired L1", "key", cacheKey)
 	}
+	metrics.ObserveCache(metrics.CacheLayerL1, false)
 
 	// L2から取得
-	if m.isRedisOK() {
-		jsonData, err := m.redis.Get(ctx, cacheKey).Bytes()
+	if client := m.l2Client(); client != nil {
+		jsonData, err := client.Get(ctx, cacheKey).Bytes()
+		if err != redis.Nil {
+			m.recordRedisResult(err)
+		}
 		if err == nil {
 			var data domain.PaginationData
 			if err := json.Unmarshal(jsonData, &data); err == nil {
 				// L1に書き戻し
-				m.l1.Store(cacheKey, &l1Entry{
-					data:      &data,
-					expiresAt: time.Now().Add(L1TTL),
-				})
+				m.l1.set(cacheKey, &data, estimateSize(cacheKey, &data), m.now().Add(L1TTL))
+				metrics.ObserveCache(metrics.CacheLayerL2, true)
 				slog.Debug("cache hit L2, restored to L1", "key", cacheKey)
 				return &data, nil
 			}
 		} else if err != redis.Nil {
 			slog.Warn("failed to get cache from redis", "key", cacheKey, "error", err)
 		}
+		metrics.ObserveCache(metrics.CacheLayerL2, false)
 	}
 
 	slog.Debug("cache miss", "key", cacheKey)
@@ -142,23 +152,91 @@ func (m *Manager) Get(ctx context.Context, key string) (*domain.PaginationData,
 // Delete はキャッシュからデータを削除します
 func (m *Manager) Delete(ctx context.Context, key string) {
 	cacheKey := makeKey(key)
-	m.l1.Delete(cacheKey)
+	m.l1.delete(cacheKey)
 
-	if m.isRedisOK() {
-		if err := m.redis.Del(ctx, cacheKey).Err(); err != nil {
+	if client := m.l2Client(); client != nil {
+		err := client.Del(ctx, cacheKey).Err()
+		m.recordRedisResult(err)
+		if err != nil {
 			slog.Warn("failed to delete cache from redis", "error", err)
 		}
 	}
 }
 
+// GetRaw はキー key に保存されたJSONデータをL1 → L2の順に取得します
+// ページネーション以外のデータ（エンティティのレスポンスなど）のキャッシュに使用します
+func (m *Manager) GetRaw(ctx context.Context, key string) ([]byte, bool) {
+	// L1から取得
+	if value, ok := m.l1.get(key, m.now()); ok {
+		if raw, ok := value.([]byte); ok {
+			slog.Debug("raw cache hit L1", "key", key)
+			return raw, true
+		}
+	}
+
+	// L2から取得（L1には残りの有効期限とともに書き戻す）
+	client := m.l2Client()
+	if client == nil {
+		return nil, false
+	}
+	pipe := client.Pipeline()
+	getCmd := pipe.Get(ctx, key)
+	ttlCmd := pipe.PTTL(ctx, key)
+	_, _ = pipe.Exec(ctx)
+	raw, err := getCmd.Bytes()
+	if err != redis.Nil {
+		m.recordRedisResult(err)
+	}
+	if err != nil {
+		if err != redis.Nil {
+			slog.Warn("failed to get raw cache from redis", "key", key, "error", err)
+		}
+		return nil, false
+	}
+
+	// 残りの有効期限が取得できない場合はL1TTLとする
+	ttl := ttlCmd.Val()
+	if ttl <= 0 {
+		ttl = L1TTL
+	}
+	m.l1.set(key, raw, estimateRawSize(key, raw), m.now().Add(ttl))
+	slog.Debug("raw cache hit L2, restored to L1", "key", key)
+	return raw, true
+}
+
+// SetRaw はJSONデータをキー key でL1とL2に保存します
+// L1にもL2と同じ ttl の期間保持します（L2を利用できない場合も、TTL経過後の古いデータへのフォールバックに使えるようにするため）
+// L1は上限（エントリ数・データ量）を超えると古く使われたものから退避されるため、長いTTLでもメモリは増え続けません
+func (m *Manager) SetRaw(ctx context.Context, key string, raw []byte, ttl time.Duration) {
+	if !m.l1.set(key, raw, estimateRawSize(key, raw), m.now().Add(ttl)) {
+		slog.Debug("raw cache entry too large for L1, skipped", "key", key, "bytes", len(raw))
+	}
+
+	if client := m.l2Client(); client != nil {
+		err := client.Set(ctx, key, raw, ttl).Err()
+		m.recordRedisResult(err)
+		if err != nil {
+			slog.Warn("failed to set raw cache in redis", "key", key, "error", err)
+		}
+	}
+}
+
 // Close はキャッシュマネージャーをクローズします
 func (m *Manager) Close() error {
+	m.redisMux.Lock()
+	defer m.redisMux.Unlock()
+
 	if m.redis != nil {
 		return m.redis.Close()
 	}
 	return nil
 }
 
+// RedisOK はL2キャッシュ（Redis）が利用可能かどうかを返します
+func (m *Manager) RedisOK() bool {
+	return m.isRedisOK()
+}
+
 // isRedisOK はRedisが利用可能かどうかを返します
 func (m *Manager) isRedisOK() bool {
 	m.redisMux.RLock()
@@ -166,16 +244,120 @@ func (m *Manager) isRedisOK() bool {
 	return m.redisOK
 }
 
+// l2Client はRedisが利用可能な場合にクライアントを返します（利用不可の場合は nil）
+func (m *Manager) l2Client() *redis.Client {
+	m.redisMux.RLock()
+	defer m.redisMux.RUnlock()
+	if !m.redisOK {
+		return nil
+	}
+	return m.redis
+}
+
+// recordRedisResult はRedis操作の結果を記録し、連続して失敗した場合はRedisを利用不可とします
+func (m *Manager) recordRedisResult(err error) {
+	m.redisMux.Lock()
+	defer m.redisMux.Unlock()
+
+	if err == nil {
+		m.redisFailures = 0
+		return
+	}
+
+	m.redisFailures++
+	if m.redisOK && m.redisFailures >= maxRedisFailures {
+		m.redisOK = false
+		slog.Warn("redis marked unhealthy, falling back to L1 cache only",
+			"consecutive_failures", m.redisFailures,
+			"error", err,
+		)
+	}
+}
+
+// CheckRedis はRedisの疎通を確認し、利用可否を更新します
+// 利用不可の状態から復旧する場合は、古いコネクションプールを破棄して新しいクライアントに差し替えます
+func (m *Manager) CheckRedis(ctx context.Context) {
+	if m.redisOpts == nil {
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, redisPingTimeout)
+	defer cancel()
+
+	if m.isRedisOK() {
+		m.redisMux.RLock()
+		client := m.redis
+		m.redisMux.RUnlock()
+
+		err := client.Ping(pingCtx).Err()
+		if err != nil {
+			m.redisMux.Lock()
+			if m.redisOK {
+				m.redisOK = false
+				slog.Warn("redis health check failed, falling back to L1 cache only", "error", err)
+			}
+			m.redisMux.Unlock()
+		}
+		return
+	}
+
+	// 再接続
+	client := redis.NewClient(m.redisOpts)
+	if err := client.Ping(pingCtx).Err(); err != nil {
+		_ = client.Close()
+		slog.Debug("redis is still unavailable", "error", err)
+		return
+	}
+
+	m.redisMux.Lock()
+	old := m.redis
+	m.redis = client
+	m.redisOK = true
+	m.redisFailures = 0
+	m.redisMux.Unlock()
+
+	if old != nil {
+		_ = old.Close()
+	}
+	slog.Info("reconnected to redis, L2 cache restored")
+}
+
+// StartRedisHealthCheck はRedisの定期ヘルスチェック（自動再接続）を開始します
+func (m *Manager) StartRedisHealthCheck(ctx context.Context, interval time.Duration) {
+	if m.redisOpts == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				m.CheckRedis(ctx)
+			}
+		}
+	}()
+}
+
 // CleanupL1 は期限切れのL1キャッシュエントリを削除します
 func (m *Manager) CleanupL1() {
-	now := time.Now()
-	m.l1.Range(func(key, value interface{}) bool {
-		entry := value.(*l1Entry)
-		if now.After(entry.expiresAt) {
-			m.l1.Delete(key)
-		}
-		return true
-	})
+	if removed := m.l1.removeExpired(m.now()); removed > 0 {
+		stats := m.l1.stats()
+		slog.Debug("expired L1 cache entries removed",
+			"removed", removed,
+			"entries", stats.Entries,
+			"bytes", stats.Bytes,
+			"evictions", stats.Evictions,
+		)
+	}
+}
+
+// L1Stats はL1キャッシュの使用状況と退避数を返します
+func (m *Manager) L1Stats() L1Stats {
+	return m.l1.stats()
 }
 
 // StartL1Cleanup はL1キャッシュの定期クリーンアップを開始します
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestManager_L2Fallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	m := NewManager(testRedisURL(mr.Addr()), L1Config{})
+	defer m.Close()
+
+	if !m.RedisOK() {
+		t.Fatal("RedisOK() = false, want true")
+	}
+
+	ctx := context.Background()
+	data := &domain.PaginationData{Command: "search", Query: "YOASOBI", Total: 1}
+	if err := m.Set(ctx, "msg1", data); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if !mr.Exists("pagination:msg1") {
+		t.Fatal("data should be stored in L2")
+	}
+
+	// L1から消えてもL2から復元される
+	m.l1.delete(makeKey("msg1"))
+	got, err := m.Get(ctx, "msg1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Query != "YOASOBI" {
+		t.Errorf("Get().Query = %s, want YOASOBI", got.Query)
+	}
+}
+
+func TestManager_MarkUnhealthyAfterFailures(t *testing.T) {
+	mr := miniredis.RunT(t)
+	m := NewManager(testRedisURL(mr.Addr()), L1Config{})
+	defer m.Close()
+
+	mr.Close()
+
+	ctx := context.Background()
+	for i := 0; i < maxRedisFailures; i++ {
+		if !m.RedisOK() {
+			t.Fatalf("redis marked unhealthy after %d failures, want %d", i, maxRedisFailures)
+		}
+		_, _ = m.Get(ctx, "missing")
+	}
+
+	if m.RedisOK() {
+		t.Error("RedisOK() = true after repeated failures, want false")
+	}
+
+	// 利用不可の間もL1で動作する
+	if err := m.Set(ctx, "msg1", &domain.PaginationData{Command: "search"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := m.Get(ctx, "msg1"); err != nil {
+		t.Errorf("Get() from L1 error = %v", err)
+	}
+}
+
+func TestManager_CheckRedisReconnects(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+
+	// 起動時にRedisが停止していてもL1のみで起動する
+	m := NewManager(testRedisURL(addr), L1Config{})
+	defer m.Close()
+	if m.RedisOK() {
+		t.Fatal("RedisOK() = true while redis is down")
+	}
+
+	ctx := context.Background()
+	m.CheckRedis(ctx)
+	if m.RedisOK() {
+		t.Fatal("RedisOK() = true while redis is still down")
+	}
+
+	// 同じアドレスでRedisが復旧するとヘルスチェックで再接続される
+	mr2 := miniredis.NewMiniRedis()
+	if err := mr2.StartAddr(addr); err != nil {
+		t.Skipf("failed to restart redis on %s: %v", addr, err)
+	}
+	defer mr2.Close()
+
+	m.CheckRedis(ctx)
+	if !m.RedisOK() {
+		t.Fatal("RedisOK() = false after redis recovered")
+	}
+
+	if err := m.Set(ctx, "msg1", &domain.PaginationData{Command: "search"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if !mr2.Exists("pagination:msg1") {
+		t.Error("data should be stored in L2 after reconnection")
+	}
+
+	// 稼働中に停止した場合もヘルスチェックで検知する
+	mr2.Close()
+	m.CheckRedis(ctx)
+	if m.RedisOK() {
+		t.Error("RedisOK() = true after redis went down")
+	}
+}
+
+func TestManager_InvalidURL(t *testing.T) {
+	m := NewManager("invalid://url", L1Config{})
+	defer m.Close()
+
+	if m.RedisOK() {
+		t.Error("RedisOK() = true for invalid URL")
+	}
+	// URLが不正な場合はヘルスチェックを行わない
+	m.CheckRedis(context.Background())
+	if m.RedisOK() {
+		t.Error("RedisOK() = true after CheckRedis with invalid URL")
+	}
+}
+
+// testRedisURL はテスト用のRedis URLを返します（停止時の検知を速くするためリトライを無効化する）
+func testRedisURL(addr string) string {
+	return "redis://" + addr + "?max_retries=-1&dial_timeout=200ms"
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
+)
+
+const (
+	// DefaultL1MaxEntries はL1キャッシュに保持する最大エントリ数のデフォルト値です
+	DefaultL1MaxEntries = 1000
+	// DefaultL1MaxBytes はL1キャッシュに保持するデータ量（概算）のデフォルト値です
+	DefaultL1MaxBytes int64 = 64 << 20 // 64MiB
+
+	// entryOverhead はエントリ1件あたりの固定オーバーヘッド（構造体・マップ・リスト要素）の概算バイト数です
+	entryOverhead = 256
+)
+
+// L1の退避理由（メトリクスのラベル値）
+const (
+	evictReasonEntries = "max_entries"
+	evictReasonBytes   = "max_bytes"
+	evictReasonExpired = "expired"
+)
+
+// L1Config はL1キャッシュ（インメモリ）の上限設定です
+// 0以下の値はデフォルト値として扱います
+type L1Config struct {
+	MaxEntries int
+	MaxBytes   int64
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完した設定を返します
+func (c L1Config) withDefaults() L1Config {
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = DefaultL1MaxEntries
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultL1MaxBytes
+	}
+	return c
+}
+
+// L1Stats はL1キャッシュの使用状況と退避数を表します
+type L1Stats struct {
+	Entries     int    // 現在のエントリ数
+	Bytes       int64  // 現在のデータ量（概算）
+	Evictions   uint64 // 上限超過により退避したエントリ数
+	Expirations uint64 // TTL切れで削除したエントリ数
+}
+
+// l1Entry はL1キャッシュのエントリを表します
+type l1Entry struct {
+	key       string
+	value     any // *domain.PaginationData またはエンティティのJSON（[]byte）
+	expiresAt time.Time
+	size      int64
+}
+
+// lruCache はエントリ数とデータ量で上限を設けたLRUキャッシュです
+type lruCache struct {
+	mu          sync.Mutex
+	ll          *list.List // 先頭が最近使われたエントリ
+	items       map[string]*list.Element
+	maxEntries  int
+	maxBytes    int64
+	bytes       int64
+	evictions   uint64
+	expirations uint64
+}
+
+// newLRUCache は新しいLRUキャッシュを作成します
+func newLRUCache(cfg L1Config) *lruCache {
+	cfg = cfg.withDefaults()
+	return &lruCache{
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		maxEntries: cfg.MaxEntries,
+		maxBytes:   cfg.MaxBytes,
+	}
+}
+
+// estimateSize はエントリのおおよそのメモリ使用量を見積もります
+// Items（json.RawMessage）が大半を占めるため、文字列長とバイト長の合計で近似します
+func estimateSize(key string, data *domain.PaginationData) int64 {
+	size := entryOverhead + len(key) + len(data.Items) +
+		len(data.Command) + len(data.Query) + len(data.Type) + len(data.OwnerID) + len(data.Mode)
+	for _, seed := range data.Seeds {
+		size += len(seed) + 16 // 文字列ヘッダー分
+	}
+	return int64(size)
+}
+
+// estimateRawSize はJSONのまま保持するエントリのおおよそのメモリ使用量を見積もります
+func estimateRawSize(key string, value []byte) int64 {
+	return int64(entryOverhead + len(key) + len(value))
+}
+
+// get はエントリを取得し、最近使われたものとして先頭に移動します
+// 期限切れの場合は削除して false を返します
+func (c *lruCache) get(key string, now time.Time) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*l1Entry)
+	if !now.Before(entry.expiresAt) {
+		c.removeElement(elem)
+		c.expirations++
+		metrics.AddCacheEvictions(evictReasonExpired, 1)
+		c.reportUsage()
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set はエントリを保存し、上限を超えた場合は最も古く使われたエントリから退避します
+// size は estimateSize / estimateRawSize で見積もったメモリ使用量です
+// 1件で上限データ量を超えるエントリは保存せず false を返します
+func (c *lruCache) set(key string, value any, size int64, expiresAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+	if size > c.maxBytes {
+		c.reportUsage()
+		return false
+	}
+
+	elem := c.ll.PushFront(&l1Entry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+		size:      size,
+	})
+	c.items[key] = elem
+	c.bytes += size
+
+	for c.ll.Len() > c.maxEntries {
+		c.evictOldest(evictReasonEntries)
+	}
+	for c.bytes > c.maxBytes {
+		c.evictOldest(evictReasonBytes)
+	}
+
+	c.reportUsage()
+	return true
+}
+
+// delete はエントリを削除します
+func (c *lruCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+		c.reportUsage()
+	}
+}
+
+// removeExpired は期限切れのエントリをすべて削除し、削除件数を返します
+func (c *lruCache) removeExpired(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for elem := c.ll.Back(); elem != nil; {
+		prev := elem.Prev()
+		if entry := elem.Value.(*l1Entry); !now.Before(entry.expiresAt) {
+			c.removeElement(elem)
+			removed++
+		}
+		elem = prev
+	}
+
+	if removed > 0 {
+		c.expirations += uint64(removed)
+		metrics.AddCacheEvictions(evictReasonExpired, removed)
+		c.reportUsage()
+	}
+	return removed
+}
+
+// stats は現在の使用状況を返します
+func (c *lruCache) stats() L1Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return L1Stats{
+		Entries:     c.ll.Len(),
+		Bytes:       c.bytes,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+	}
+}
+
+// evictOldest は最も古く使われたエントリを退避します（ロック取得済みで呼び出すこと）
+func (c *lruCache) evictOldest(reason string) {
+	elem := c.ll.Back()
+	if elem == nil {
+		return
+	}
+	c.removeElement(elem)
+	c.evictions++
+	metrics.AddCacheEvictions(reason, 1)
+}
+
+// removeElement はリストとマップからエントリを取り除きます（ロック取得済みで呼び出すこと）
+func (c *lruCache) removeElement(elem *list.Element) {
+	entry := c.ll.Remove(elem).(*l1Entry)
+	delete(c.items, entry.key)
+	c.bytes -= entry.size
+}
+
+// reportUsage は現在の使用状況をメトリクスに反映します（ロック取得済みで呼び出すこと）
+func (c *lruCache) reportUsage() {
+	metrics.SetL1Usage(c.ll.Len(), c.bytes)
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func newTestData(itemsSize int) *domain.PaginationData {
+	return &domain.PaginationData{
+		Command: "recommend",
+		Items:   json.RawMessage(`"` + strings.Repeat("x", itemsSize) + `"`),
+	}
+}
+
+// setData はページネーションデータをサイズを見積もって保存します
+func setData(c *lruCache, key string, data *domain.PaginationData, expiresAt time.Time) bool {
+	return c.set(key, data, estimateSize(key, data), expiresAt)
+}
+
+func TestLRUCache_EvictByEntries(t *testing.T) {
+	c := newLRUCache(L1Config{MaxEntries: 2})
+	expiresAt := time.Now().Add(time.Minute)
+
+	setData(c, "a", newTestData(10), expiresAt)
+	setData(c, "b", newTestData(10), expiresAt)
+
+	// a を参照して最近使われたものにする
+	if _, ok := c.get("a", time.Now()); !ok {
+		t.Fatal("a should exist")
+	}
+	setData(c, "c", newTestData(10), expiresAt)
+
+	if _, ok := c.get("b", time.Now()); ok {
+		t.Error("b should be evicted as least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key, time.Now()); !ok {
+			t.Errorf("%s should exist", key)
+		}
+	}
+
+	stats := c.stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("stats = %+v, want Entries=2 Evictions=1", stats)
+	}
+}
+
+func TestLRUCache_EvictByBytes(t *testing.T) {
+	dataSize := estimateSize("k0", newTestData(1000))
+	c := newLRUCache(L1Config{MaxEntries: 100, MaxBytes: dataSize * 3})
+	expiresAt := time.Now().Add(time.Minute)
+
+	for i := 0; i < 5; i++ {
+		setData(c, fmt.Sprintf("k%d", i), newTestData(1000), expiresAt)
+	}
+
+	stats := c.stats()
+	if stats.Entries != 3 {
+		t.Errorf("Entries = %d, want 3", stats.Entries)
+	}
+	if stats.Bytes > dataSize*3 {
+		t.Errorf("Bytes = %d, want <= %d", stats.Bytes, dataSize*3)
+	}
+	if stats.Evictions != 2 {
+		t.Errorf("Evictions = %d, want 2", stats.Evictions)
+	}
+	if _, ok := c.get("k0", time.Now()); ok {
+		t.Error("k0 should be evicted")
+	}
+}
+
+func TestLRUCache_TooLargeEntry(t *testing.T) {
+	c := newLRUCache(L1Config{MaxBytes: 512})
+	expiresAt := time.Now().Add(time.Minute)
+
+	setData(c, "small", newTestData(10), expiresAt)
+	if ok := setData(c, "large", newTestData(1024), expiresAt); ok {
+		t.Error("set() should reject an entry larger than MaxBytes")
+	}
+	if _, ok := c.get("small", time.Now()); !ok {
+		t.Error("existing entry should not be evicted by a rejected entry")
+	}
+}
+
+func TestLRUCache_Overwrite(t *testing.T) {
+	c := newLRUCache(L1Config{})
+	expiresAt := time.Now().Add(time.Minute)
+
+	setData(c, "a", newTestData(100), expiresAt)
+	setData(c, "a", newTestData(10), expiresAt)
+
+	stats := c.stats()
+	if stats.Entries != 1 {
+		t.Errorf("Entries = %d, want 1", stats.Entries)
+	}
+	if want := estimateSize("a", newTestData(10)); stats.Bytes != want {
+		t.Errorf("Bytes = %d, want %d", stats.Bytes, want)
+	}
+}
+
+func TestLRUCache_Expiration(t *testing.T) {
+	c := newLRUCache(L1Config{})
+	now := time.Now()
+
+	setData(c, "expired", newTestData(10), now.Add(-time.Second))
+	setData(c, "alive", newTestData(10), now.Add(time.Minute))
+	setData(c, "expired2", newTestData(10), now.Add(-time.Second))
+
+	if _, ok := c.get("expired", now); ok {
+		t.Error("expired entry should not be returned")
+	}
+	if removed := c.removeExpired(now); removed != 1 {
+		t.Errorf("removeExpired() = %d, want 1", removed)
+	}
+
+	stats := c.stats()
+	if stats.Entries != 1 || stats.Expirations != 2 || stats.Evictions != 0 {
+		t.Errorf("stats = %+v, want Entries=1 Expirations=2 Evictions=0", stats)
+	}
+}
+
+func TestL1Config_WithDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  L1Config
+		want L1Config
+	}{
+		{"zero", L1Config{}, L1Config{MaxEntries: DefaultL1MaxEntries, MaxBytes: DefaultL1MaxBytes}},
+		{"negative", L1Config{MaxEntries: -1, MaxBytes: -1}, L1Config{MaxEntries: DefaultL1MaxEntries, MaxBytes: DefaultL1MaxBytes}},
+		{"custom", L1Config{MaxEntries: 10, MaxBytes: 1024}, L1Config{MaxEntries: 10, MaxBytes: 1024}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.withDefaults(); got != tt.want {
+				t.Errorf("withDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
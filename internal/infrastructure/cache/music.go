@@ -0,0 +1,355 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTrackTTL はトラック情報のキャッシュTTLのデフォルト値です
+	DefaultTrackTTL = 24 * time.Hour
+	// DefaultArtistTTL はアーティスト情報のキャッシュTTLのデフォルト値です
+	// フォロワー数や人気度が変動するため、トラック・アルバムより短くします
+	DefaultArtistTTL = 6 * time.Hour
+	// DefaultAlbumTTL はアルバム情報のキャッシュTTLのデフォルト値です
+	DefaultAlbumTTL = 24 * time.Hour
+	// DefaultStaleTTL はTTL経過後も tracktaste 障害時のフォールバック用に保持する期間のデフォルト値です
+	DefaultStaleTTL = 7 * 24 * time.Hour
+
+	// entityKeyPrefix はエンティティキャッシュのキーの接頭辞です
+	entityKeyPrefix = "entity:"
+	// maxPendingRefresh はバックグラウンドで再取得を待つエントリ数の上限です
+	maxPendingRefresh = 1000
+)
+
+// EntityTTL はエンティティ種別ごとのキャッシュTTLです
+// 0以下の値はデフォルト値として扱います
+type EntityTTL struct {
+	Track  time.Duration
+	Artist time.Duration
+	Album  time.Duration
+	Stale  time.Duration // TTL経過後も古いデータとして保持する期間
+}
+
+// DefaultEntityTTL はデフォルトのキャッシュTTLを返します
+func DefaultEntityTTL() EntityTTL {
+	return EntityTTL{
+		Track:  DefaultTrackTTL,
+		Artist: DefaultArtistTTL,
+		Album:  DefaultAlbumTTL,
+		Stale:  DefaultStaleTTL,
+	}
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完したTTLを返します
+func (t EntityTTL) withDefaults() EntityTTL {
+	if t.Track <= 0 {
+		t.Track = DefaultTrackTTL
+	}
+	if t.Artist <= 0 {
+		t.Artist = DefaultArtistTTL
+	}
+	if t.Album <= 0 {
+		t.Album = DefaultAlbumTTL
+	}
+	if t.Stale <= 0 {
+		t.Stale = DefaultStaleTTL
+	}
+	return t
+}
+
+// MusicRepository はトラック・アーティスト・アルバムの取得結果をL1/L2キャッシュに保存するデコレーターです
+// キーは正規化したSpotify URLで、同じキーへの同時のキャッシュミスは1回のリクエストにまとめます
+// TTLを過ぎたエントリも一定期間保持し、tracktaste が利用できない場合は古いデータとして返します（stale-while-revalidate）
+// レコメンド・検索・プレイリストは結果が変わりやすいためキャッシュせず、そのまま委譲します
+type MusicRepository struct {
+	next  domain.MusicRepository
+	store *Manager
+	ttl   EntityTTL
+	group singleflight.Group
+
+	pendingMu sync.Mutex
+	pending   map[string]refreshFunc // 古いデータを返したため再取得を待っているエントリ
+	now       func() time.Time
+}
+
+// refreshFunc はエントリを tracktaste から再取得してキャッシュに保存する関数です
+type refreshFunc func(ctx context.Context) error
+
+// entityEnvelope はエンティティキャッシュに保存する値です（取得日時で鮮度を判定する）
+type entityEnvelope struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// インターフェース実装の確認
+var _ domain.MusicRepository = (*MusicRepository)(nil)
+
+// NewMusicRepository は next の取得結果をキャッシュするリポジトリを作成します
+func NewMusicRepository(next domain.MusicRepository, store *Manager, ttl EntityTTL) *MusicRepository {
+	return &MusicRepository{
+		next:    next,
+		store:   store,
+		ttl:     ttl.withDefaults(),
+		pending: make(map[string]refreshFunc),
+		now:     time.Now,
+	}
+}
+
+// FetchTrack はトラック情報をキャッシュから取得し、なければ取得してキャッシュします
+func (r *MusicRepository) FetchTrack(ctx context.Context, spotifyURL string) (*domain.Track, error) {
+	return fetchEntity(ctx, r, spotify.EntityTrack, spotifyURL, r.ttl.Track, r.next.FetchTrack)
+}
+
+// FetchArtist はアーティスト情報をキャッシュから取得し、なければ取得してキャッシュします
+func (r *MusicRepository) FetchArtist(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error) {
+	return fetchEntity(ctx, r, spotify.EntityArtist, spotifyURL, r.ttl.Artist, r.next.FetchArtist)
+}
+
+// FetchAlbum はアルバム情報をキャッシュから取得し、なければ取得してキャッシュします
+func (r *MusicRepository) FetchAlbum(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
+	return fetchEntity(ctx, r, spotify.EntityAlbum, spotifyURL, r.ttl.Album, r.next.FetchAlbum)
+}
+
+// FetchSimilar は類似トラックを取得します（キャッシュしない）
+func (r *MusicRepository) FetchSimilar(ctx context.Context, spotifyURL string) ([]domain.SimilarTrack, error) {
+	return r.next.FetchSimilar(ctx, spotifyURL)
+}
+
+// FetchRecommend はレコメンドトラックを取得します（キャッシュしない）
+func (r *MusicRepository) FetchRecommend(ctx context.Context, spotifyURL string, mode domain.RecommendMode, limit int) (*domain.RecommendResult, error) {
+	return r.next.FetchRecommend(ctx, spotifyURL, mode, limit)
+}
+
+// SearchTracks はトラックを検索します（キャッシュしない）
+func (r *MusicRepository) SearchTracks(ctx context.Context, query string) ([]domain.Track, error) {
+	return r.next.SearchTracks(ctx, query)
+}
+
+// SearchArtists はアーティストを検索します（キャッシュしない）
+func (r *MusicRepository) SearchArtists(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+	return r.next.SearchArtists(ctx, query)
+}
+
+// SearchAlbums はアルバムを検索します（キャッシュしない）
+func (r *MusicRepository) SearchAlbums(ctx context.Context, query string) ([]domain.Album, error) {
+	return r.next.SearchAlbums(ctx, query)
+}
+
+// FetchPlaylist はプレイリスト情報を取得します（キャッシュしない）
+func (r *MusicRepository) FetchPlaylist(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+	return r.next.FetchPlaylist(ctx, spotifyURL)
+}
+
+// entityKey はエンティティキャッシュのキーを返します
+func entityKey(normalizedURL string) string {
+	return entityKeyPrefix + normalizedURL
+}
+
+// fetchEntity はエンティティをキャッシュから取得し、なければ fetch で取得してキャッシュします
+// TTLを過ぎたエントリは再取得し、tracktaste が利用できない場合は古いデータに取得日時を付けて返します
+// 同じキーへの同時のキャッシュミスは singleflight で1回の取得にまとめ、結果のJSONを呼び出し元ごとにデコードします
+// （呼び出し元の間で同じポインタを共有しないため）
+func fetchEntity[T any](
+	ctx context.Context,
+	r *MusicRepository,
+	entity spotify.EntityType,
+	spotifyURL string,
+	ttl time.Duration,
+	fetch func(context.Context, string) (*T, error),
+) (*T, error) {
+	// 正規化できない入力はキャッシュせずに委譲する（エラーの扱いは委譲先に任せる）
+	v := spotify.ValidateInput(spotifyURL, entity)
+	if !v.Valid {
+		return fetch(ctx, spotifyURL)
+	}
+	key := entityKey(v.URL)
+	label := string(entity)
+
+	var stale *entityEnvelope
+	if raw, ok := r.store.GetRaw(ctx, key); ok {
+		var env entityEnvelope
+		if err := json.Unmarshal(raw, &env); err != nil || env.FetchedAt.IsZero() {
+			slog.Warn("failed to decode cached entity, refetching", "key", key)
+		} else if r.now().Sub(env.FetchedAt) < ttl {
+			var value T
+			if err := json.Unmarshal(env.Data, &value); err == nil {
+				metrics.ObserveEntityCache(label, metrics.EntityCacheHit)
+				return &value, nil
+			}
+			slog.Warn("failed to decode cached entity, refetching", "key", key)
+		} else {
+			stale = &env
+		}
+	}
+
+	load := func(ctx context.Context) ([]byte, error) {
+		value, err := fetch(ctx, v.URL)
+		if err != nil {
+			return nil, err
+		}
+		return r.storeEntity(ctx, key, value, ttl)
+	}
+
+	raw, shared, err := r.loadShared(ctx, key, load)
+	if err != nil {
+		if stale == nil || !domain.IsUpstreamOutage(err) {
+			return nil, err
+		}
+		var value T
+		if decodeErr := json.Unmarshal(stale.Data, &value); decodeErr != nil {
+			return nil, err
+		}
+		markStale(&value, stale.FetchedAt)
+		metrics.ObserveEntityCache(label, metrics.EntityCacheStale)
+		slog.Warn("tracktaste unavailable, serving stale entity",
+			"key", key,
+			"fetched_at", stale.FetchedAt,
+			"error", err,
+		)
+		r.scheduleRefresh(key, func(ctx context.Context) error {
+			_, _, err := r.loadShared(ctx, key, load)
+			return err
+		})
+		return &value, nil
+	}
+
+	if shared {
+		metrics.ObserveEntityCache(label, metrics.EntityCacheShared)
+	} else {
+		metrics.ObserveEntityCache(label, metrics.EntityCacheMiss)
+	}
+	var env entityEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, err
+	}
+	var value T
+	if err := json.Unmarshal(env.Data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// loadShared は同じキーへの同時の取得を1回にまとめて load を実行し、保存したエントリのJSONを返します
+// 先に待ち始めた呼び出し元がキャンセルしても、他の呼び出し元のために取得は続けます
+func (r *MusicRepository) loadShared(ctx context.Context, key string, load func(context.Context) ([]byte, error)) ([]byte, bool, error) {
+	ch := r.group.DoChan(key, func() (interface{}, error) {
+		return load(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Shared, res.Err
+		}
+		return res.Val.([]byte), res.Shared, nil
+	}
+}
+
+// storeEntity はエンティティを取得日時とともにキャッシュに保存し、保存したエントリのJSONを返します
+// TTL経過後も古いデータとして使えるよう、キャッシュには TTL + Stale の期間保持します
+func (r *MusicRepository) storeEntity(ctx context.Context, key string, value any, ttl time.Duration) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(entityEnvelope{FetchedAt: r.now(), Data: data})
+	if err != nil {
+		return nil, err
+	}
+	r.store.SetRaw(ctx, key, raw, ttl+r.ttl.Stale)
+	r.unscheduleRefresh(key)
+	return raw, nil
+}
+
+// markStale は古いキャッシュデータであることを示す取得日時を設定します
+func markStale(value any, fetchedAt time.Time) {
+	switch v := value.(type) {
+	case *domain.Track:
+		v.CachedAt = fetchedAt
+	case *domain.ArtistDetail:
+		v.CachedAt = fetchedAt
+	case *domain.AlbumDetail:
+		v.CachedAt = fetchedAt
+	}
+}
+
+// scheduleRefresh は古いデータを返したエントリをバックグラウンドでの再取得の対象に加えます
+func (r *MusicRepository) scheduleRefresh(key string, refresh refreshFunc) {
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	if _, ok := r.pending[key]; ok {
+		return
+	}
+	if len(r.pending) >= maxPendingRefresh {
+		slog.Debug("too many stale entities pending refresh, skipped", "key", key)
+		return
+	}
+	r.pending[key] = refresh
+}
+
+// unscheduleRefresh はエントリを再取得の対象から外します
+func (r *MusicRepository) unscheduleRefresh(key string) {
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+	delete(r.pending, key)
+}
+
+// PendingRefreshes は再取得を待っているエントリ数を返します
+func (r *MusicRepository) PendingRefreshes() int {
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+	return len(r.pending)
+}
+
+// RefreshStale は古いデータを返したエントリを tracktaste から再取得します
+// tracktaste がまだ利用できない場合は残りのエントリを次回に持ち越し、それ以外のエラーの場合は対象から外します
+func (r *MusicRepository) RefreshStale(ctx context.Context) {
+	r.pendingMu.Lock()
+	pending := make(map[string]refreshFunc, len(r.pending))
+	for key, refresh := range r.pending {
+		pending[key] = refresh
+	}
+	r.pendingMu.Unlock()
+
+	for key, refresh := range pending {
+		err := refresh(ctx)
+		switch {
+		case err == nil:
+			slog.Info("stale entity refreshed", "key", key)
+		case domain.IsUpstreamOutage(err), ctx.Err() != nil:
+			slog.Debug("tracktaste still unavailable, stale refresh postponed", "pending", len(pending), "error", err)
+			return
+		default:
+			r.unscheduleRefresh(key)
+			slog.Warn("failed to refresh stale entity, dropped", "key", key, "error", err)
+		}
+	}
+}
+
+// StartStaleRefresh は古いデータを返したエントリの定期的な再取得を開始します
+func (r *MusicRepository) StartStaleRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				r.RefreshStale(ctx)
+			}
+		}
+	}()
+}
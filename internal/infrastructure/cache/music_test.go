@@ -0,0 +1,448 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+const testTrackID = "4cOdK2wGLETKBW3PvgPWqT"
+
+// mockMusicRepository は呼び出し回数を記録するモックリポジトリです
+type mockMusicRepository struct {
+	calls   atomic.Int32
+	err     error
+	release chan struct{} // nil でない場合、閉じられるまで FetchTrack をブロックする
+	started chan struct{} // FetchTrack の開始を通知する
+	once    sync.Once
+}
+
+func (m *mockMusicRepository) FetchTrack(ctx context.Context, spotifyURL string) (*domain.Track, error) {
+	m.calls.Add(1)
+	if m.started != nil {
+		m.once.Do(func() { close(m.started) })
+	}
+	if m.release != nil {
+		<-m.release
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Track{ID: testTrackID, Name: "Test Track", URL: spotifyURL}, nil
+}
+
+func (m *mockMusicRepository) FetchSimilar(ctx context.Context, spotifyURL string) ([]domain.SimilarTrack, error) {
+	m.calls.Add(1)
+	return nil, nil
+}
+
+func (m *mockMusicRepository) FetchRecommend(ctx context.Context, spotifyURL string, mode domain.RecommendMode, limit int) (*domain.RecommendResult, error) {
+	m.calls.Add(1)
+	return &domain.RecommendResult{Mode: mode}, nil
+}
+
+func (m *mockMusicRepository) SearchTracks(ctx context.Context, query string) ([]domain.Track, error) {
+	m.calls.Add(1)
+	return nil, nil
+}
+
+func (m *mockMusicRepository) FetchArtist(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error) {
+	m.calls.Add(1)
+	return &domain.ArtistDetail{ID: testTrackID, Name: "Test Artist", URL: spotifyURL}, nil
+}
+
+func (m *mockMusicRepository) FetchAlbum(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
+	m.calls.Add(1)
+	return &domain.AlbumDetail{ID: testTrackID, Name: "Test Album", URL: spotifyURL}, nil
+}
+
+func (m *mockMusicRepository) SearchArtists(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+	m.calls.Add(1)
+	return nil, nil
+}
+
+func (m *mockMusicRepository) SearchAlbums(ctx context.Context, query string) ([]domain.Album, error) {
+	m.calls.Add(1)
+	return nil, nil
+}
+
+func (m *mockMusicRepository) FetchPlaylist(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+	m.calls.Add(1)
+	return &domain.Playlist{}, nil
+}
+
+// newTestMusicRepository はL1のみのキャッシュを使うリポジトリを作成します
+func newTestMusicRepository(t *testing.T, next *mockMusicRepository) *MusicRepository {
+	t.Helper()
+	m := NewManager("invalid://url", L1Config{})
+	t.Cleanup(func() { _ = m.Close() })
+	return NewMusicRepository(next, m, DefaultEntityTTL())
+}
+
+func TestMusicRepository_FetchTrackNormalizesKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "URL", input: "https://open.spotify.com/track/" + testTrackID},
+		{name: "URL with query", input: "https://open.spotify.com/track/" + testTrackID + "?si=abcdef"},
+		{name: "intl URL", input: "https://open.spotify.com/intl-ja/track/" + testTrackID},
+		{name: "URI", input: "spotify:track:" + testTrackID},
+		{name: "ID", input: testTrackID},
+	}
+
+	next := &mockMusicRepository{}
+	repo := newTestMusicRepository(t, next)
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FetchTrack(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("FetchTrack() error = %v", err)
+			}
+			if got.Name != "Test Track" {
+				t.Errorf("FetchTrack().Name = %s, want Test Track", got.Name)
+			}
+		})
+	}
+
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+}
+
+func TestMusicRepository_CachesEachEntity(t *testing.T) {
+	tests := []struct {
+		name  string
+		fetch func(r *MusicRepository) error
+	}{
+		{
+			name: "track",
+			fetch: func(r *MusicRepository) error {
+				_, err := r.FetchTrack(context.Background(), "https://open.spotify.com/track/"+testTrackID)
+				return err
+			},
+		},
+		{
+			name: "artist",
+			fetch: func(r *MusicRepository) error {
+				_, err := r.FetchArtist(context.Background(), "https://open.spotify.com/artist/"+testTrackID)
+				return err
+			},
+		},
+		{
+			name: "album",
+			fetch: func(r *MusicRepository) error {
+				_, err := r.FetchAlbum(context.Background(), "https://open.spotify.com/album/"+testTrackID)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &mockMusicRepository{}
+			repo := newTestMusicRepository(t, next)
+
+			for i := 0; i < 3; i++ {
+				if err := tt.fetch(repo); err != nil {
+					t.Fatalf("fetch() error = %v", err)
+				}
+			}
+			if calls := next.calls.Load(); calls != 1 {
+				t.Errorf("upstream calls = %d, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestMusicRepository_PassThrough(t *testing.T) {
+	next := &mockMusicRepository{}
+	repo := newTestMusicRepository(t, next)
+	ctx := context.Background()
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.FetchRecommend(ctx, url, domain.RecommendModeBalanced, 10); err != nil {
+			t.Fatalf("FetchRecommend() error = %v", err)
+		}
+	}
+	if calls := next.calls.Load(); calls != 2 {
+		t.Errorf("upstream calls = %d, want 2 (recommend is not cached)", calls)
+	}
+}
+
+func TestMusicRepository_ErrorsAreNotCached(t *testing.T) {
+	next := &mockMusicRepository{err: errors.New("upstream failure")}
+	repo := newTestMusicRepository(t, next)
+	ctx := context.Background()
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	if _, err := repo.FetchTrack(ctx, url); err == nil {
+		t.Fatal("FetchTrack() error = nil, want upstream error")
+	}
+
+	next.err = nil
+	if _, err := repo.FetchTrack(ctx, url); err != nil {
+		t.Fatalf("FetchTrack() after recovery error = %v", err)
+	}
+	if calls := next.calls.Load(); calls != 2 {
+		t.Errorf("upstream calls = %d, want 2", calls)
+	}
+}
+
+func TestMusicRepository_CollapsesConcurrentMisses(t *testing.T) {
+	next := &mockMusicRepository{
+		release: make(chan struct{}),
+		started: make(chan struct{}),
+	}
+	repo := newTestMusicRepository(t, next)
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	fetch := func() {
+		defer wg.Done()
+		track, err := repo.FetchTrack(context.Background(), url)
+		if err == nil && track.Name != "Test Track" {
+			err = errors.New("unexpected track: " + track.Name)
+		}
+		errs <- err
+	}
+
+	wg.Add(1)
+	go fetch()
+	<-next.started
+
+	// 最初の取得が完了する前に残りの呼び出しを開始する
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go fetch()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("FetchTrack() error = %v", err)
+		}
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+}
+
+func TestMusicRepository_CallerCancel(t *testing.T) {
+	next := &mockMusicRepository{
+		release: make(chan struct{}),
+		started: make(chan struct{}),
+	}
+	repo := newTestMusicRepository(t, next)
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := repo.FetchTrack(ctx, url)
+		done <- err
+	}()
+	<-next.started
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("FetchTrack() error = %v, want context.Canceled", err)
+	}
+
+	// キャンセル後も取得は続き、結果はキャッシュされる
+	close(next.release)
+	if _, err := repo.FetchTrack(context.Background(), url); err != nil {
+		t.Fatalf("FetchTrack() error = %v", err)
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+}
+
+func TestMusicRepository_StoresInL2WithEntityTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	m := NewManager(testRedisURL(mr.Addr()), L1Config{})
+	defer m.Close()
+
+	next := &mockMusicRepository{}
+	repo := NewMusicRepository(next, m, EntityTTL{Artist: time.Hour, Stale: time.Hour})
+	ctx := context.Background()
+	url := "https://open.spotify.com/artist/" + testTrackID
+
+	if _, err := repo.FetchArtist(ctx, url); err != nil {
+		t.Fatalf("FetchArtist() error = %v", err)
+	}
+
+	key := entityKey(url)
+	if !mr.Exists(key) {
+		t.Fatalf("%s should be stored in L2", key)
+	}
+	// TTL経過後も古いデータとして使えるよう TTL + Stale の期間保持する
+	if ttl := mr.TTL(key); ttl != 2*time.Hour {
+		t.Errorf("L2 TTL = %v, want %v", ttl, 2*time.Hour)
+	}
+
+	// L1から消えてもL2から復元される
+	m.l1.delete(key)
+	got, err := repo.FetchArtist(ctx, url)
+	if err != nil {
+		t.Fatalf("FetchArtist() error = %v", err)
+	}
+	if got.Name != "Test Artist" {
+		t.Errorf("FetchArtist().Name = %s, want Test Artist", got.Name)
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+}
+
+func TestMusicRepository_StaleFallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStale   bool
+		wantPending int
+	}{
+		{
+			name:        "upstream unavailable",
+			err:         &domain.UpstreamError{Kind: domain.ErrUpstreamUnavailable, Code: "HTTP_503", Status: 503},
+			wantStale:   true,
+			wantPending: 1,
+		},
+		{
+			name:        "upstream timeout",
+			err:         &domain.UpstreamError{Kind: domain.ErrUpstreamTimeout, Code: "CONNECTION_ERROR"},
+			wantStale:   true,
+			wantPending: 1,
+		},
+		{
+			name:        "invalid resource",
+			err:         &domain.UpstreamError{Kind: domain.ErrInvalidResource, Code: "INVALID_RESOURCE_TYPE", Status: 400},
+			wantStale:   false,
+			wantPending: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &mockMusicRepository{}
+			m := NewManager("invalid://url", L1Config{})
+			defer m.Close()
+			repo := NewMusicRepository(next, m, EntityTTL{Track: time.Millisecond})
+			ctx := context.Background()
+			url := "https://open.spotify.com/track/" + testTrackID
+
+			if _, err := repo.FetchTrack(ctx, url); err != nil {
+				t.Fatalf("FetchTrack() error = %v", err)
+			}
+			time.Sleep(5 * time.Millisecond) // TTLを経過させる
+
+			next.err = tt.err
+			got, err := repo.FetchTrack(ctx, url)
+			if !tt.wantStale {
+				if err == nil {
+					t.Fatal("FetchTrack() error = nil, want upstream error")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("FetchTrack() error = %v, want stale data", err)
+				}
+				if got.Name != "Test Track" || got.CachedAt.IsZero() {
+					t.Errorf("FetchTrack() = %+v, want stale track with CachedAt", got)
+				}
+			}
+			if calls := next.calls.Load(); calls != 2 {
+				t.Errorf("upstream calls = %d, want 2", calls)
+			}
+			if pending := repo.PendingRefreshes(); pending != tt.wantPending {
+				t.Errorf("PendingRefreshes() = %d, want %d", pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestMusicRepository_StaleFallbackWithoutL2AfterL1TTL(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	next := &mockMusicRepository{}
+	m := NewManager("invalid://url", L1Config{})
+	defer m.Close()
+	m.now = clock
+	repo := NewMusicRepository(next, m, EntityTTL{Track: 5 * time.Minute, Stale: time.Hour})
+	repo.now = clock
+	ctx := context.Background()
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	if _, err := repo.FetchTrack(ctx, url); err != nil {
+		t.Fatalf("FetchTrack() error = %v", err)
+	}
+	now = now.Add(L1TTL + time.Minute) // TTLとL1TTLの両方を経過させる
+
+	next.err = &domain.UpstreamError{Kind: domain.ErrUpstreamUnavailable, Code: "HTTP_503", Status: 503}
+	got, err := repo.FetchTrack(ctx, url)
+	if err != nil {
+		t.Fatalf("FetchTrack() error = %v, want stale data from L1", err)
+	}
+	if got.Name != "Test Track" || got.CachedAt.IsZero() {
+		t.Errorf("FetchTrack() = %+v, want stale track with CachedAt", got)
+	}
+}
+
+func TestMusicRepository_RefreshStale(t *testing.T) {
+	next := &mockMusicRepository{}
+	m := NewManager("invalid://url", L1Config{})
+	defer m.Close()
+	repo := NewMusicRepository(next, m, EntityTTL{Track: 50 * time.Millisecond})
+	ctx := context.Background()
+	url := "https://open.spotify.com/track/" + testTrackID
+
+	if _, err := repo.FetchTrack(ctx, url); err != nil {
+		t.Fatalf("FetchTrack() error = %v", err)
+	}
+	time.Sleep(60 * time.Millisecond) // TTLを経過させる
+
+	next.err = &domain.UpstreamError{Kind: domain.ErrUpstreamUnavailable, Code: "CIRCUIT_OPEN"}
+	if _, err := repo.FetchTrack(ctx, url); err != nil {
+		t.Fatalf("FetchTrack() error = %v, want stale data", err)
+	}
+
+	// tracktaste が利用できない間は再取得を持ち越す
+	repo.RefreshStale(ctx)
+	if pending := repo.PendingRefreshes(); pending != 1 {
+		t.Fatalf("PendingRefreshes() = %d, want 1 while upstream is down", pending)
+	}
+
+	// 復旧後の再取得で最新データに更新される
+	next.err = nil
+	repo.RefreshStale(ctx)
+	if pending := repo.PendingRefreshes(); pending != 0 {
+		t.Errorf("PendingRefreshes() = %d, want 0 after recovery", pending)
+	}
+	calls := next.calls.Load()
+
+	got, err := repo.FetchTrack(ctx, url)
+	if err != nil {
+		t.Fatalf("FetchTrack() error = %v", err)
+	}
+	if !got.CachedAt.IsZero() {
+		t.Error("refreshed data should not be marked stale")
+	}
+	if after := next.calls.Load(); after != calls {
+		t.Errorf("upstream calls = %d, want %d (served from refreshed cache)", after, calls)
+	}
+}
@@ -0,0 +1,137 @@
+package servicelink
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/lookup"
+)
+
+const (
+	iTunesSearchURL = "https://itunes.apple.com/search"
+	iTunesLookupURL = "https://itunes.apple.com/lookup"
+
+	// DefaultCountry は Apple Music のストアの国コードのデフォルト値です
+	DefaultCountry = "jp"
+
+	// appleSearchLimit は iTunes Search API の検索結果の取得件数です
+	appleSearchLimit = 10
+)
+
+// AppleMusic は iTunes Search API（認証不要）で Apple Music のリンクを検索します
+// domain.ServiceLinker インターフェースを実装します
+// アルバムは UPC で検索し、トラックと UPC のないアルバムは名前で検索します
+type AppleMusic struct {
+	client  HTTPClient
+	country string
+}
+
+// NewAppleMusic は新しい AppleMusic を作成します
+// country が空の場合は DefaultCountry のストアを検索します
+func NewAppleMusic(client HTTPClient, country string) *AppleMusic {
+	if country == "" {
+		country = DefaultCountry
+	}
+	return &AppleMusic{client: client, country: country}
+}
+
+// インターフェース実装の確認
+var _ domain.ServiceLinker = (*AppleMusic)(nil)
+
+// iTunesResponse は iTunes Search API の search / lookup のレスポンスです
+type iTunesResponse struct {
+	Results []struct {
+		WrapperType       string `json:"wrapperType"`
+		TrackName         string `json:"trackName"`
+		CollectionName    string `json:"collectionName"`
+		ArtistName        string `json:"artistName"`
+		TrackViewURL      string `json:"trackViewUrl"`
+		CollectionViewURL string `json:"collectionViewUrl"`
+	} `json:"results"`
+}
+
+// Service はサービス名を返します
+func (a *AppleMusic) Service() string {
+	return "Apple Music"
+}
+
+// FindTrack はトラック名・アーティスト名で検索し、一致するトラックのURLを返します
+func (a *AppleMusic) FindTrack(ctx context.Context, track *domain.Track) (string, error) {
+	resp, err := a.get(ctx, iTunesSearchURL, url.Values{
+		"term":   {searchTerm(track.Name, track.Artists)},
+		"entity": {"song"},
+		"limit":  {strconv.Itoa(appleSearchLimit)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	i := lookup.Best(len(resp.Results), func(i int) lookup.Level {
+		return lookup.Match(resp.Results[i].TrackName, resp.Results[i].ArtistName, track.Name, track.Artists)
+	})
+	if i < 0 {
+		return "", nil
+	}
+	return trimAppleURL(resp.Results[i].TrackViewURL), nil
+}
+
+// FindAlbum は UPC、次にアルバム名・アーティスト名で検索し、一致するアルバムのURLを返します
+func (a *AppleMusic) FindAlbum(ctx context.Context, album *domain.AlbumDetail) (string, error) {
+	if album.UPC != "" {
+		resp, err := a.get(ctx, iTunesLookupURL, url.Values{"upc": {album.UPC}})
+		if err != nil {
+			return "", err
+		}
+		for _, result := range resp.Results {
+			if result.WrapperType == "collection" && result.CollectionViewURL != "" {
+				return trimAppleURL(result.CollectionViewURL), nil
+			}
+		}
+	}
+
+	resp, err := a.get(ctx, iTunesSearchURL, url.Values{
+		"term":   {searchTerm(album.Name, album.Artists)},
+		"entity": {"album"},
+		"limit":  {strconv.Itoa(appleSearchLimit)},
+	})
+	if err != nil {
+		return "", err
+	}
+	i := lookup.Best(len(resp.Results), func(i int) lookup.Level {
+		return lookup.Match(resp.Results[i].CollectionName, resp.Results[i].ArtistName, album.Name, album.Artists)
+	})
+	if i < 0 {
+		return "", nil
+	}
+	return trimAppleURL(resp.Results[i].CollectionViewURL), nil
+}
+
+// get は iTunes Search API を呼び出します
+func (a *AppleMusic) get(ctx context.Context, endpoint string, query url.Values) (*iTunesResponse, error) {
+	query.Set("country", a.country)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp iTunesResponse
+	if err := lookup.GetJSON(a.client, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// trimAppleURL は iTunes Search API のURLからアフィリエイト用のパラメータ（uo）を取り除きます
+func trimAppleURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	query.Del("uo")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,60 @@
+package servicelink
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestAppleMusic(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("country"); got != "jp" {
+			t.Errorf("country = %q, want jp", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/search" && query.Get("entity") == "song":
+			_, _ = w.Write([]byte(`{"results":[
+				{"wrapperType":"track","trackName":"Lemon Tree","artistName":"Fools Garden","trackViewUrl":"https://music.apple.com/jp/album/x/9?i=9&uo=4"},
+				{"wrapperType":"track","trackName":"Lemon (Instrumental)","artistName":"米津玄師","trackViewUrl":"https://music.apple.com/jp/album/x/8?i=8&uo=4"},
+				{"wrapperType":"track","trackName":"Lemon","artistName":"米津玄師","trackViewUrl":"https://music.apple.com/jp/album/lemon/1361329931?i=1361329935&uo=4"}
+			]}`))
+		case r.URL.Path == "/lookup" && query.Get("upc") == "4547366353463":
+			_, _ = w.Write([]byte(`{"results":[{"wrapperType":"collection","collectionName":"Lemon","artistName":"米津玄師","collectionViewUrl":"https://music.apple.com/jp/album/lemon/1361329931?uo=4"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		}
+	})
+	apple := NewAppleMusic(client, "")
+	artists := []domain.Artist{{Name: "米津玄師"}}
+
+	t.Run("track by name", func(t *testing.T) {
+		got, err := apple.FindTrack(context.Background(), &domain.Track{Name: "Lemon", Artists: artists})
+		if err != nil {
+			t.Fatalf("FindTrack() error = %v", err)
+		}
+		if want := "https://music.apple.com/jp/album/lemon/1361329931?i=1361329935"; got != want {
+			t.Errorf("FindTrack() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("album by UPC", func(t *testing.T) {
+		got, err := apple.FindAlbum(context.Background(), &domain.AlbumDetail{Name: "Lemon", UPC: "4547366353463", Artists: artists})
+		if err != nil {
+			t.Fatalf("FindAlbum() error = %v", err)
+		}
+		if want := "https://music.apple.com/jp/album/lemon/1361329931"; got != want {
+			t.Errorf("FindAlbum() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("album not found", func(t *testing.T) {
+		got, err := apple.FindAlbum(context.Background(), &domain.AlbumDetail{Name: "Unknown", Artists: artists})
+		if err != nil || got != "" {
+			t.Errorf("FindAlbum() = (%q, %v), want empty", got, err)
+		}
+	})
+}
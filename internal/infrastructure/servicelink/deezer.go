@@ -0,0 +1,84 @@
+package servicelink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/lookup"
+)
+
+const deezerAPIBaseURL = "https://api.deezer.com"
+
+// Deezer は Deezer API（認証不要）で ISRC / UPC が一致するトラック・アルバムのリンクを検索します
+// domain.ServiceLinker インターフェースを実装します
+// ISRC / UPC がない場合は検索しません（名前での検索は誤った対応付けになりやすいため）
+type Deezer struct {
+	client HTTPClient
+}
+
+// NewDeezer は新しい Deezer を作成します
+func NewDeezer(client HTTPClient) *Deezer {
+	return &Deezer{client: client}
+}
+
+// インターフェース実装の確認
+var _ domain.ServiceLinker = (*Deezer)(nil)
+
+// deezerResponse は Deezer API のトラック・アルバムのレスポンスです
+type deezerResponse struct {
+	Link  string `json:"link"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// deezerNoDataCode は該当するデータがないことを表す Deezer API のエラーコードです
+const deezerNoDataCode = 800
+
+// Service はサービス名を返します
+func (d *Deezer) Service() string {
+	return "Deezer"
+}
+
+// FindTrack は ISRC が一致するトラックのURLを返します
+func (d *Deezer) FindTrack(ctx context.Context, track *domain.Track) (string, error) {
+	if track.ISRC == nil || *track.ISRC == "" {
+		return "", nil
+	}
+	return d.find(ctx, "track", "isrc:"+*track.ISRC)
+}
+
+// FindAlbum は UPC が一致するアルバムのURLを返します
+func (d *Deezer) FindAlbum(ctx context.Context, album *domain.AlbumDetail) (string, error) {
+	if album.UPC == "" {
+		return "", nil
+	}
+	return d.find(ctx, "album", "upc:"+album.UPC)
+}
+
+// find は Deezer API の /{entityType}/{id} を呼び出し、リンクを返します
+func (d *Deezer) find(ctx context.Context, entityType, id string) (string, error) {
+	endpoint := fmt.Sprintf("%s/%s/%s", deezerAPIBaseURL, entityType, url.PathEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var resp deezerResponse
+	if err := lookup.GetJSON(d.client, req, &resp); err != nil {
+		return "", err
+	}
+	// Deezer API は該当なしの場合もステータス200でエラーを返す
+	if resp.Error != nil {
+		if resp.Error.Code == deezerNoDataCode {
+			return "", nil
+		}
+		return "", fmt.Errorf("deezer API error: %s (code: %d)", resp.Error.Message, resp.Error.Code)
+	}
+	return resp.Link, nil
+}
@@ -0,0 +1,80 @@
+package servicelink
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestDeezer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/track/isrc:GBDUW0000059":
+			_, _ = w.Write([]byte(`{"id":3135556,"link":"https://www.deezer.com/track/3135556"}`))
+		case "/album/upc:724384960650":
+			_, _ = w.Write([]byte(`{"id":302127,"link":"https://www.deezer.com/album/302127"}`))
+		case "/track/isrc:BROKEN":
+			_, _ = w.Write([]byte(`{"error":{"type":"Exception","message":"Quota limit exceeded","code":4}}`))
+		default:
+			_, _ = w.Write([]byte(`{"error":{"type":"DataException","message":"no data","code":800}}`))
+		}
+	})
+	deezer := NewDeezer(client)
+	isrc := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		find    func() (string, error)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "track by ISRC",
+			find: func() (string, error) {
+				return deezer.FindTrack(context.Background(), &domain.Track{ISRC: isrc("GBDUW0000059")})
+			},
+			want: "https://www.deezer.com/track/3135556",
+		},
+		{
+			name: "album by UPC",
+			find: func() (string, error) {
+				return deezer.FindAlbum(context.Background(), &domain.AlbumDetail{UPC: "724384960650"})
+			},
+			want: "https://www.deezer.com/album/302127",
+		},
+		{
+			name: "no data",
+			find: func() (string, error) {
+				return deezer.FindTrack(context.Background(), &domain.Track{ISRC: isrc("JPXX00000000")})
+			},
+		},
+		{
+			name: "without ISRC",
+			find: func() (string, error) {
+				return deezer.FindTrack(context.Background(), &domain.Track{Name: "Lemon"})
+			},
+		},
+		{
+			name: "api error",
+			find: func() (string, error) {
+				return deezer.FindTrack(context.Background(), &domain.Track{ISRC: isrc("BROKEN")})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.find()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package servicelink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/lookup"
+)
+
+const (
+	kkboxTokenURL  = "https://account.kkbox.com/oauth2/token"
+	kkboxSearchURL = "https://api.kkbox.com/v1.1/search"
+
+	// DefaultTerritory は KKBOX の検索対象の地域のデフォルト値です
+	DefaultTerritory = "JP"
+
+	// kkboxSearchLimit は KKBOX の検索結果の取得件数です
+	kkboxSearchLimit = 10
+	// kkboxTokenMargin はアクセストークンを期限より前に更新するための余裕です
+	kkboxTokenMargin = time.Minute
+)
+
+// KKBox は KKBOX Open API で KKBOX のリンクを検索します
+// domain.ServiceLinker インターフェースを実装します
+// アクセストークンは Client Credentials で取得し、期限まで再利用します
+type KKBox struct {
+	client       HTTPClient
+	clientID     string
+	clientSecret string
+	territory    string
+	now          func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewKKBox は新しい KKBox を作成します
+// territory が空の場合は DefaultTerritory を検索します
+func NewKKBox(client HTTPClient, clientID, clientSecret, territory string) *KKBox {
+	if territory == "" {
+		territory = DefaultTerritory
+	}
+	return &KKBox{
+		client:       client,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		territory:    territory,
+		now:          time.Now,
+	}
+}
+
+// インターフェース実装の確認
+var _ domain.ServiceLinker = (*KKBox)(nil)
+
+// kkboxArtist は KKBOX のアーティストです
+type kkboxArtist struct {
+	Name string `json:"name"`
+}
+
+// kkboxSearchResponse は KKBOX の検索のレスポンスです
+type kkboxSearchResponse struct {
+	Tracks struct {
+		Data []struct {
+			Name  string `json:"name"`
+			URL   string `json:"url"`
+			ISRC  string `json:"isrc"`
+			Album struct {
+				Artist kkboxArtist `json:"artist"`
+			} `json:"album"`
+		} `json:"data"`
+	} `json:"tracks"`
+	Albums struct {
+		Data []struct {
+			Name   string      `json:"name"`
+			URL    string      `json:"url"`
+			Artist kkboxArtist `json:"artist"`
+		} `json:"data"`
+	} `json:"albums"`
+}
+
+// kkboxTokenResponse は KKBOX のアクセストークンのレスポンスです
+type kkboxTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Service はサービス名を返します
+func (k *KKBox) Service() string {
+	return "KKBOX"
+}
+
+// FindTrack はトラック名・アーティスト名で検索し、ISRC、次に名前が一致するトラックのURLを返します
+func (k *KKBox) FindTrack(ctx context.Context, track *domain.Track) (string, error) {
+	resp, err := k.search(ctx, searchTerm(track.Name, track.Artists), "track")
+	if err != nil {
+		return "", err
+	}
+
+	if track.ISRC != nil && *track.ISRC != "" {
+		for _, t := range resp.Tracks.Data {
+			if strings.EqualFold(t.ISRC, *track.ISRC) {
+				return t.URL, nil
+			}
+		}
+	}
+	tracks := resp.Tracks.Data
+	i := lookup.Best(len(tracks), func(i int) lookup.Level {
+		return lookup.Match(tracks[i].Name, tracks[i].Album.Artist.Name, track.Name, track.Artists)
+	})
+	if i < 0 {
+		return "", nil
+	}
+	return tracks[i].URL, nil
+}
+
+// FindAlbum はアルバム名・アーティスト名で検索し、一致するアルバムのURLを返します
+func (k *KKBox) FindAlbum(ctx context.Context, album *domain.AlbumDetail) (string, error) {
+	resp, err := k.search(ctx, searchTerm(album.Name, album.Artists), "album")
+	if err != nil {
+		return "", err
+	}
+
+	albums := resp.Albums.Data
+	i := lookup.Best(len(albums), func(i int) lookup.Level {
+		return lookup.Match(albums[i].Name, albums[i].Artist.Name, album.Name, album.Artists)
+	})
+	if i < 0 {
+		return "", nil
+	}
+	return albums[i].URL, nil
+}
+
+// search は KKBOX の検索APIを呼び出します
+func (k *KKBox) search(ctx context.Context, term, searchType string) (*kkboxSearchResponse, error) {
+	token, err := k.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{
+		"q":         {term},
+		"type":      {searchType},
+		"territory": {k.territory},
+		"limit":     {strconv.Itoa(kkboxSearchLimit)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, kkboxSearchURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var resp kkboxSearchResponse
+	if err := lookup.GetJSON(k.client, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// accessToken は有効なアクセストークンを返します（期限が近い場合は再取得します）
+func (k *KKBox) accessToken(ctx context.Context) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.token != "" && k.now().Before(k.expiresAt) {
+		return k.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {k.clientID},
+		"client_secret": {k.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, kkboxTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp kkboxTokenResponse
+	if err := lookup.GetJSON(k.client, req, &resp); err != nil {
+		return "", fmt.Errorf("failed to get kkbox access token: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("failed to get kkbox access token: empty token")
+	}
+
+	k.token = resp.AccessToken
+	k.expiresAt = k.now().Add(time.Duration(resp.ExpiresIn)*time.Second - kkboxTokenMargin)
+	return k.token, nil
+}
@@ -0,0 +1,77 @@
+package servicelink
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestKKBox(t *testing.T) {
+	var tokenRequests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Host + r.URL.Path {
+		case "account.kkbox.com/oauth2/token":
+			tokenRequests.Add(1)
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != "id" || r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+		case "api.kkbox.com/v1.1/search":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("type") == "album" {
+				_, _ = w.Write([]byte(`{"albums":{"data":[{"name":"Discovery","url":"https://www.kkbox.com/jp/ja/album/discovery","artist":{"name":"Daft Punk"}}]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"tracks":{"data":[
+				{"name":"Harder, Better, Faster, Stronger (Live)","url":"https://www.kkbox.com/jp/ja/song/live","isrc":"GBDUW0700001","album":{"artist":{"name":"Daft Punk"}}},
+				{"name":"Harder, Better, Faster, Stronger","url":"https://www.kkbox.com/jp/ja/song/hbfs","isrc":"GBDUW0000059","album":{"artist":{"name":"Daft Punk"}}}
+			]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	kkbox := NewKKBox(client, "id", "secret", "")
+	artists := []domain.Artist{{Name: "Daft Punk"}}
+	isrc := "GBDUW0000059"
+
+	got, err := kkbox.FindTrack(context.Background(), &domain.Track{Name: "Harder, Better, Faster, Stronger", ISRC: &isrc, Artists: artists})
+	if err != nil {
+		t.Fatalf("FindTrack() error = %v", err)
+	}
+	// 名前が一致する結果が複数ある場合は ISRC が一致する結果を優先する
+	if want := "https://www.kkbox.com/jp/ja/song/hbfs"; got != want {
+		t.Errorf("FindTrack() = %q, want %q", got, want)
+	}
+
+	got, err = kkbox.FindAlbum(context.Background(), &domain.AlbumDetail{Name: "Discovery", Artists: artists})
+	if err != nil {
+		t.Fatalf("FindAlbum() error = %v", err)
+	}
+	if want := "https://www.kkbox.com/jp/ja/album/discovery"; got != want {
+		t.Errorf("FindAlbum() = %q, want %q", got, want)
+	}
+
+	// アクセストークンは期限まで再利用する
+	if got := tokenRequests.Load(); got != 1 {
+		t.Errorf("token requests = %d, want 1", got)
+	}
+}
+
+func TestKKBox_InvalidCredentials(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	kkbox := NewKKBox(client, "id", "wrong", "")
+
+	if _, err := kkbox.FindTrack(context.Background(), &domain.Track{Name: "Lemon"}); err == nil {
+		t.Error("FindTrack() error = nil, want token error")
+	}
+}
@@ -0,0 +1,184 @@
+package servicelink
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTimeout は全サービスのリンクの検索（並行して実行する）のタイムアウトのデフォルト値です
+	// 応答の Embed の表示を待たせすぎないよう短めにし、時間内に見つからないサービスは表示しません
+	DefaultTimeout = 3 * time.Second
+	// DefaultCacheTTL は検索結果をキャッシュする期間のデフォルト値です
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// HTTPClient は各サービスのAPIの呼び出しに使うHTTPクライアントです
+// テストでは httptest のサーバーに接続するクライアントに差し替えます
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient は各サービスのAPIの呼び出し用のHTTPクライアントを作成します
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+// RawStore は検索結果を保存するキャッシュです（cache.Manager が実装します）
+type RawStore interface {
+	GetRaw(ctx context.Context, key string) ([]byte, bool)
+	SetRaw(ctx context.Context, key string, raw []byte, ttl time.Duration)
+}
+
+// Config は Resolver の設定です
+// 0以下の値はデフォルト値として扱います
+type Config struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完した設定を返します
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = DefaultCacheTTL
+	}
+	return c
+}
+
+// Resolver は登録された domain.ServiceLinker で他サービスのリンクを並行して検索します
+// domain.ServiceLinkRepository インターフェースを実装します
+// 結果はサービスの登録順に並べ、L1/L2 キャッシュに保存します（同じトラック・アルバムの同時の検索は1回にまとめます）
+type Resolver struct {
+	linkers []domain.ServiceLinker
+	store   RawStore
+	cfg     Config
+	group   singleflight.Group
+}
+
+// NewResolver は新しい Resolver を作成します
+// store が nil の場合はキャッシュしません
+func NewResolver(store RawStore, cfg Config, linkers ...domain.ServiceLinker) *Resolver {
+	return &Resolver{
+		linkers: linkers,
+		store:   store,
+		cfg:     cfg.withDefaults(),
+	}
+}
+
+// インターフェース実装の確認
+var _ domain.ServiceLinkRepository = (*Resolver)(nil)
+
+// TrackLinks はトラックに対応する他サービスのリンクを返します
+func (r *Resolver) TrackLinks(ctx context.Context, track *domain.Track) []domain.ServiceLink {
+	if track == nil || track.ID == "" {
+		return nil
+	}
+	return r.links(ctx, "track", track.ID, func(ctx context.Context, linker domain.ServiceLinker) (string, error) {
+		return linker.FindTrack(ctx, track)
+	})
+}
+
+// AlbumLinks はアルバムに対応する他サービスのリンクを返します
+func (r *Resolver) AlbumLinks(ctx context.Context, album *domain.AlbumDetail) []domain.ServiceLink {
+	if album == nil || album.ID == "" {
+		return nil
+	}
+	return r.links(ctx, "album", album.ID, func(ctx context.Context, linker domain.ServiceLinker) (string, error) {
+		return linker.FindAlbum(ctx, album)
+	})
+}
+
+// links はキャッシュ、なければ全サービスの検索結果を返します
+func (r *Resolver) links(
+	ctx context.Context,
+	entityType, id string,
+	find func(context.Context, domain.ServiceLinker) (string, error),
+) []domain.ServiceLink {
+	if len(r.linkers) == 0 {
+		return nil
+	}
+
+	key := cacheKey(entityType, id)
+	if r.store != nil {
+		if raw, ok := r.store.GetRaw(ctx, key); ok {
+			var links []domain.ServiceLink
+			if err := json.Unmarshal(raw, &links); err == nil {
+				return links
+			}
+		}
+	}
+
+	v, _, _ := r.group.Do(key, func() (any, error) {
+		// 呼び出し元のキャンセルで同じトラック・アルバムを待つ他の呼び出しまで失敗しないよう、専用のタイムアウトで検索する
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.Timeout)
+		defer cancel()
+
+		urls := make([]string, len(r.linkers))
+		failed := make([]bool, len(r.linkers))
+		var wg sync.WaitGroup
+		for i, linker := range r.linkers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				url, err := find(ctx, linker)
+				if err != nil {
+					slog.Warn("failed to find service link", "service", linker.Service(), "type", entityType, "spotify_id", id, "error", err)
+					failed[i] = true
+					return
+				}
+				urls[i] = url
+			}()
+		}
+		wg.Wait()
+
+		links := make([]domain.ServiceLink, 0, len(r.linkers))
+		complete := true
+		for i, linker := range r.linkers {
+			if failed[i] {
+				complete = false
+				continue
+			}
+			if urls[i] != "" {
+				links = append(links, domain.ServiceLink{Service: linker.Service(), URL: urls[i], Search: isSearchOnly(linker)})
+			}
+		}
+
+		// 失敗したサービスがある場合は次回に再検索するためキャッシュしない
+		if complete && r.store != nil {
+			if raw, err := json.Marshal(links); err == nil {
+				r.store.SetRaw(context.WithoutCancel(ctx), key, raw, r.cfg.CacheTTL)
+			}
+		}
+		return links, nil
+	})
+	return v.([]domain.ServiceLink)
+}
+
+// isSearchOnly はリンカーが検索結果へのリンクのみを返すかどうかを返します
+func isSearchOnly(linker domain.ServiceLinker) bool {
+	s, ok := linker.(domain.SearchLinker)
+	return ok && s.SearchOnly()
+}
+
+// cacheKey は検索結果のキャッシュのキーを返します
+func cacheKey(entityType, id string) string {
+	return "servicelinks:" + entityType + ":" + id
+}
+
+// searchTerm はトラック名・アルバム名とアーティスト名から検索キーワードを作成します
+func searchTerm(name string, artists []domain.Artist) string {
+	if len(artists) == 0 {
+		return name
+	}
+	return name + " " + artists[0].Name
+}
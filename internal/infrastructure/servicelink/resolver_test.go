@@ -0,0 +1,157 @@
+package servicelink
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// rewriteTransport はリクエストのホストを保ったまま、接続先を httptest のサーバーに差し替えます
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestClient は各サービスのAPIを模擬するサーバーに接続するHTTPクライアントを作成します
+func newTestClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	return &http.Client{Transport: rewriteTransport{target: target}}
+}
+
+// fakeLinker はテスト用の domain.ServiceLinker です
+type fakeLinker struct {
+	service string
+	url     string
+	err     error
+	delay   time.Duration
+	calls   atomic.Int32
+}
+
+func (f *fakeLinker) Service() string { return f.service }
+
+func (f *fakeLinker) FindTrack(ctx context.Context, track *domain.Track) (string, error) {
+	f.calls.Add(1)
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return f.url, f.err
+}
+
+func (f *fakeLinker) FindAlbum(ctx context.Context, album *domain.AlbumDetail) (string, error) {
+	return f.FindTrack(ctx, nil)
+}
+
+// fakeStore はテスト用の RawStore です
+type fakeStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (f *fakeStore) GetRaw(ctx context.Context, key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	raw, ok := f.entries[key]
+	return raw, ok
+}
+
+func (f *fakeStore) SetRaw(ctx context.Context, key string, raw []byte, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[key] = raw
+}
+
+func TestResolver_TrackLinks(t *testing.T) {
+	apple := &fakeLinker{service: "Apple Music", url: "https://music.apple.com/jp/album/x/1?i=2"}
+	kkbox := &fakeLinker{service: "KKBOX", url: ""}
+	deezer := &fakeLinker{service: "Deezer", url: "https://www.deezer.com/track/3"}
+	store := &fakeStore{entries: make(map[string][]byte)}
+	resolver := NewResolver(store, Config{}, apple, kkbox, deezer)
+	track := &domain.Track{ID: "4iV5W9uYEdYUVa79Axb7Rh", Name: "Test"}
+
+	want := []domain.ServiceLink{
+		{Service: "Apple Music", URL: "https://music.apple.com/jp/album/x/1?i=2"},
+		{Service: "Deezer", URL: "https://www.deezer.com/track/3"},
+	}
+	for range 2 {
+		if got := resolver.TrackLinks(context.Background(), track); !reflect.DeepEqual(got, want) {
+			t.Fatalf("TrackLinks() = %+v, want %+v", got, want)
+		}
+	}
+
+	// 2回目はキャッシュから返す
+	if got := apple.calls.Load(); got != 1 {
+		t.Errorf("linker calls = %d, want 1 (cached)", got)
+	}
+	if _, ok := store.entries["servicelinks:track:4iV5W9uYEdYUVa79Axb7Rh"]; !ok {
+		t.Error("links were not stored in the cache")
+	}
+}
+
+func TestResolver_FailuresAreOmitted(t *testing.T) {
+	apple := &fakeLinker{service: "Apple Music", err: errors.New("unexpected status 503")}
+	slow := &fakeLinker{service: "KKBOX", url: "https://www.kkbox.com/jp/ja/song/x", delay: time.Second}
+	deezer := &fakeLinker{service: "Deezer", url: "https://www.deezer.com/album/3"}
+	store := &fakeStore{entries: make(map[string][]byte)}
+	resolver := NewResolver(store, Config{Timeout: 50 * time.Millisecond}, apple, slow, deezer)
+
+	start := time.Now()
+	got := resolver.AlbumLinks(context.Background(), &domain.AlbumDetail{ID: "4aawyAB9vmqN3uQ7FjRGTy"})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("AlbumLinks() took %v, want it bounded by the timeout", elapsed)
+	}
+
+	want := []domain.ServiceLink{{Service: "Deezer", URL: "https://www.deezer.com/album/3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AlbumLinks() = %+v, want %+v", got, want)
+	}
+	// 失敗したサービスがある場合はキャッシュしない
+	if len(store.entries) != 0 {
+		t.Errorf("cache entries = %d, want 0", len(store.entries))
+	}
+}
+
+func TestResolver_NoLinkers(t *testing.T) {
+	resolver := NewResolver(nil, Config{})
+	if got := resolver.TrackLinks(context.Background(), &domain.Track{ID: "x"}); got != nil {
+		t.Errorf("TrackLinks() = %+v, want nil", got)
+	}
+}
+
+func TestResolver_SearchLinks(t *testing.T) {
+	deezer := &fakeLinker{service: "Deezer", url: "https://www.deezer.com/track/3"}
+	resolver := NewResolver(nil, Config{}, NewYouTubeMusic(), deezer)
+
+	got := resolver.TrackLinks(context.Background(), &domain.Track{ID: "4iV5W9uYEdYUVa79Axb7Rh", Name: "Lemon", Artists: []domain.Artist{{Name: "米津玄師"}}})
+	want := []domain.ServiceLink{
+		{Service: "YouTube Music", URL: "https://music.youtube.com/search?q=Lemon+%E7%B1%B3%E6%B4%A5%E7%8E%84%E5%B8%AB", Search: true},
+		{Service: "Deezer", URL: "https://www.deezer.com/track/3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TrackLinks() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package servicelink
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+const youTubeMusicSearchURL = "https://music.youtube.com/search"
+
+// YouTubeMusic は YouTube Music の検索結果へのリンクを作成します
+// domain.SearchLinker インターフェースを実装します
+// YouTube Music には認証なしで使える検索APIがないため、一致を確認できず、トラック名・アルバム名とアーティスト名の検索ページを開きます
+type YouTubeMusic struct{}
+
+// NewYouTubeMusic は新しい YouTubeMusic を作成します
+func NewYouTubeMusic() *YouTubeMusic {
+	return &YouTubeMusic{}
+}
+
+// インターフェース実装の確認
+var _ domain.SearchLinker = (*YouTubeMusic)(nil)
+
+// Service はサービス名を返します
+func (y *YouTubeMusic) Service() string {
+	return "YouTube Music"
+}
+
+// SearchOnly は検索結果へのリンクのみを返すため true を返します
+func (y *YouTubeMusic) SearchOnly() bool {
+	return true
+}
+
+// FindTrack はトラック名・アーティスト名の検索ページのURLを返します
+func (y *YouTubeMusic) FindTrack(ctx context.Context, track *domain.Track) (string, error) {
+	return youTubeMusicSearch(searchTerm(track.Name, track.Artists)), nil
+}
+
+// FindAlbum はアルバム名・アーティスト名の検索ページのURLを返します
+func (y *YouTubeMusic) FindAlbum(ctx context.Context, album *domain.AlbumDetail) (string, error) {
+	return youTubeMusicSearch(searchTerm(album.Name, album.Artists)), nil
+}
+
+// youTubeMusicSearch は検索ページのURLを返します（キーワードが空の場合は空文字）
+func youTubeMusicSearch(term string) string {
+	if term == "" {
+		return ""
+	}
+	return youTubeMusicSearchURL + "?" + url.Values{"q": {term}}.Encode()
+}
@@ -0,0 +1,178 @@
+package shortlink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/ttlcache"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTimeout は短縮URL1件の展開（リダイレクトをすべて辿るまで）のタイムアウトのデフォルト値です
+	// スラッシュコマンドの DeferReply 前に展開する場合もあるため、Discord の3秒ルールより短くします
+	DefaultTimeout = 2 * time.Second
+	// DefaultMaxRedirects は辿るリダイレクトの最大回数のデフォルト値です
+	DefaultMaxRedirects = 5
+	// DefaultCacheTTL は展開結果をキャッシュする期間のデフォルト値です（短縮URLのリダイレクト先は変わらないため長めにします）
+	DefaultCacheTTL = 24 * time.Hour
+	// DefaultCacheMaxEntries はキャッシュに保持する短縮URLの最大数のデフォルト値です
+	DefaultCacheMaxEntries = 1000
+
+	// maxDrainBytes は接続を再利用するために読み捨てるレスポンスボディの最大バイト数です
+	maxDrainBytes = 64 << 10
+)
+
+var (
+	// ErrNotShortLink は展開対象の短縮URLではないことを表します
+	ErrNotShortLink = errors.New("not a spotify short link")
+	// ErrTooManyRedirects はリダイレクトの回数が上限を超えたことを表します
+	ErrTooManyRedirects = errors.New("too many redirects")
+	// ErrUnexpectedRedirect はリダイレクト先が Spotify 以外のホスト、またはリダイレクト以外の応答だったことを表します
+	ErrUnexpectedRedirect = errors.New("unexpected redirect")
+)
+
+// HTTPClient は短縮URLのリダイレクト先の取得に使うHTTPクライアントです
+// テストでは httptest のサーバーに接続するクライアントに差し替えます
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient は短縮URLの展開用のHTTPクライアントを作成します
+// リダイレクトは Resolver が1回ずつ宛先を確認しながら辿るため、自動では追いません
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Config は Resolver の設定です
+// 0以下の値はデフォルト値として扱います
+type Config struct {
+	Timeout         time.Duration
+	MaxRedirects    int
+	CacheTTL        time.Duration
+	CacheMaxEntries int
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完した設定を返します
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.MaxRedirects <= 0 {
+		c.MaxRedirects = DefaultMaxRedirects
+	}
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = DefaultCacheTTL
+	}
+	if c.CacheMaxEntries <= 0 {
+		c.CacheMaxEntries = DefaultCacheMaxEntries
+	}
+	return c
+}
+
+// Resolver は Spotify の短縮URLをリダイレクトを辿って展開します
+// domain.LinkResolver インターフェースを実装します
+// 展開結果はインメモリにキャッシュし、同じ短縮URLの同時の展開は1回のリクエストにまとめます
+type Resolver struct {
+	client HTTPClient
+	cfg    Config
+	now    func() time.Time
+	group  singleflight.Group
+	cache  *ttlcache.Cache[string]
+}
+
+// NewResolver は新しい Resolver を作成します
+func NewResolver(client HTTPClient, cfg Config) *Resolver {
+	cfg = cfg.withDefaults()
+	return &Resolver{
+		client: client,
+		cfg:    cfg,
+		now:    time.Now,
+		cache:  ttlcache.New[string](cfg.CacheTTL, cfg.CacheMaxEntries),
+	}
+}
+
+// インターフェース実装の確認
+var _ domain.LinkResolver = (*Resolver)(nil)
+
+// ResolveLink は短縮URLを展開したURLを返します
+// 展開後のURLは呼び出し元が spotify.ValidateInput に渡してバリデーション・正規化（?si= などの除去）します
+func (r *Resolver) ResolveLink(ctx context.Context, shortURL string) (string, error) {
+	shortURL = strings.TrimSpace(shortURL)
+	if !spotify.IsShortLink(shortURL) {
+		return "", ErrNotShortLink
+	}
+
+	if resolved, ok := r.cache.Get(shortURL, r.now()); ok {
+		return resolved, nil
+	}
+
+	v, err, _ := r.group.Do(shortURL, func() (any, error) {
+		// 呼び出し元のキャンセルで同じ短縮URLを待つ他の呼び出しまで失敗しないよう、専用のタイムアウトで展開する
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.Timeout)
+		defer cancel()
+
+		start := time.Now()
+		resolved, err := r.follow(ctx, shortURL)
+		if err != nil {
+			slog.Warn("failed to resolve short link", "url", shortURL, "duration", time.Since(start), "error", err)
+			return "", err
+		}
+		slog.Debug("short link resolved", "url", shortURL, "resolved", resolved, "duration", time.Since(start))
+		r.cache.Set(shortURL, resolved, r.now())
+		return resolved, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// follow はリダイレクトを1回ずつ辿り、Spotify のエンティティのURLに到達したらそのURLを返します
+// 任意のホストへのリクエストを防ぐため、短縮URLのホスト以外へのリダイレクトは辿りません
+func (r *Resolver) follow(ctx context.Context, current string) (string, error) {
+	for range r.cfg.MaxRedirects {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, current, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to request %s: %w", current, err)
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+		resp.Body.Close()
+
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+			return "", fmt.Errorf("%w: status %d from %s", ErrUnexpectedRedirect, resp.StatusCode, current)
+		}
+		location, err := resp.Location()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUnexpectedRedirect, err)
+		}
+
+		next := location.String()
+		if spotify.IsEntityURL(next) {
+			return next, nil
+		}
+		if !spotify.IsShortLink(next) {
+			return "", fmt.Errorf("%w: %s", ErrUnexpectedRedirect, location.Host)
+		}
+		current = next
+	}
+	return "", ErrTooManyRedirects
+}
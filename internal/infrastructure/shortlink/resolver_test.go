@@ -0,0 +1,149 @@
+package shortlink
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const resolvedTrackURL = "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc123&_branch_match_id=1"
+
+// rewriteTransport はリクエストのホストを保ったまま、接続先を httptest のサーバーに差し替えます
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestResolver は短縮URLのリダイレクトを模擬するサーバーに接続する Resolver を作成します
+func newTestResolver(t *testing.T, cfg Config) (*Resolver, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.Host + r.URL.Path {
+		case "spotify.link/track":
+			http.Redirect(w, r, resolvedTrackURL, http.StatusTemporaryRedirect)
+		case "spotify.link/chain":
+			http.Redirect(w, r, "https://spotify.app.link/next?_p=c", http.StatusFound)
+		case "spotify.app.link/next":
+			http.Redirect(w, r, "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy", http.StatusFound)
+		case "spotify.link/other":
+			http.Redirect(w, r, "https://example.com/", http.StatusFound)
+		case "spotify.link/loop":
+			http.Redirect(w, r, "https://spotify.link/loop", http.StatusFound)
+		case "spotify.link/slow":
+			time.Sleep(200 * time.Millisecond)
+			http.Redirect(w, r, resolvedTrackURL, http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	client := NewHTTPClient(time.Second)
+	client.Transport = rewriteTransport{target: target}
+	return NewResolver(client, cfg), &requests
+}
+
+func TestResolver_ResolveLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "single redirect", input: "https://spotify.link/track", want: resolvedTrackURL},
+		{name: "redirect chain", input: " https://spotify.link/chain ", want: "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy"},
+		{name: "redirect to other host", input: "https://spotify.link/other", wantErr: ErrUnexpectedRedirect},
+		{name: "not found", input: "https://spotify.link/missing", wantErr: ErrUnexpectedRedirect},
+		{name: "redirect loop", input: "https://spotify.link/loop", wantErr: ErrTooManyRedirects},
+		{name: "not a short link", input: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", wantErr: ErrNotShortLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, _ := newTestResolver(t, Config{})
+			got, err := resolver.ResolveLink(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ResolveLink() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveLink() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_Cache(t *testing.T) {
+	resolver, requests := newTestResolver(t, Config{CacheTTL: time.Minute, CacheMaxEntries: 1})
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := resolver.ResolveLink(ctx, "https://spotify.link/track"); err != nil {
+			t.Fatalf("ResolveLink() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1 (cached)", got)
+	}
+
+	// TTL を過ぎると再度展開する
+	now = now.Add(time.Minute)
+	if _, err := resolver.ResolveLink(ctx, "https://spotify.link/track"); err != nil {
+		t.Fatalf("ResolveLink() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests after expiry = %d, want 2", got)
+	}
+
+	// 上限を超えるエントリは保持しない
+	if _, err := resolver.ResolveLink(ctx, "https://spotify.link/chain"); err != nil {
+		t.Fatalf("ResolveLink() error = %v", err)
+	}
+	if got := resolver.cache.Len(); got != 1 {
+		t.Errorf("entries = %d, want 1", got)
+	}
+
+	// 失敗はキャッシュしない
+	before := requests.Load()
+	for range 2 {
+		_, _ = resolver.ResolveLink(ctx, "https://spotify.link/missing")
+	}
+	if got := requests.Load() - before; got != 2 {
+		t.Errorf("requests for failing link = %d, want 2 (not cached)", got)
+	}
+}
+
+func TestResolver_Timeout(t *testing.T) {
+	resolver, _ := newTestResolver(t, Config{Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := resolver.ResolveLink(context.Background(), "https://spotify.link/slow"); err == nil {
+		t.Fatal("ResolveLink() error = nil, want timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("ResolveLink() took %v, want it bounded by the timeout", elapsed)
+	}
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite" // database/sql ドライバの登録
+)
+
+// migrations はスキーマ定義です（先頭から順に適用され、適用済みのバージョンはスキップされます）
+var migrations = []string{
+	// v1: お気に入り
+	`CREATE TABLE IF NOT EXISTS favorites (
+		user_id     TEXT    NOT NULL,
+		entity_type TEXT    NOT NULL,
+		spotify_id  TEXT    NOT NULL,
+		url         TEXT    NOT NULL,
+		name        TEXT    NOT NULL,
+		subtitle    TEXT    NOT NULL DEFAULT '',
+		created_at  INTEGER NOT NULL,
+		PRIMARY KEY (user_id, spotify_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_favorites_user_created ON favorites (user_id, created_at DESC);`,
+	// v2: コマンド実行履歴
+	`CREATE TABLE IF NOT EXISTS history (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id     TEXT    NOT NULL,
+		command     TEXT    NOT NULL,
+		entity_type TEXT    NOT NULL DEFAULT '',
+		url         TEXT    NOT NULL DEFAULT '',
+		query       TEXT    NOT NULL DEFAULT '',
+		mode        TEXT    NOT NULL DEFAULT '',
+		label       TEXT    NOT NULL DEFAULT '',
+		created_at  INTEGER NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_history_user_created ON history (user_id, created_at DESC, id DESC);`,
+	// v3: ギルドごとの設定
+	`CREATE TABLE IF NOT EXISTS guild_settings (
+		guild_id         TEXT    PRIMARY KEY,
+		recommend_mode   TEXT    NOT NULL DEFAULT '',
+		page_size        INTEGER NOT NULL DEFAULT 0,
+		auto_link        INTEGER,
+		locale           TEXT    NOT NULL DEFAULT '',
+		allowed_channels TEXT    NOT NULL DEFAULT '',
+		updated_at       INTEGER NOT NULL
+	);`,
+	// v4: 一覧から選択した項目の詳細の表示範囲
+	`ALTER TABLE guild_settings ADD COLUMN detail_visibility TEXT NOT NULL DEFAULT '';`,
+}
+
+// DB はユーザーデータ・ギルド設定を保存する組み込みSQLiteデータベースです
+type DB struct {
+	db *sql.DB
+}
+
+// Open はSQLiteデータベースを開き、マイグレーションを適用します
+func Open(path string) (*DB, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	// WALモードとbusy_timeoutで同時アクセス時のロック競合を緩和する
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(ON)", path)
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLiteは単一ライターのため接続を1本に制限する
+	sqlDB.SetMaxOpenConns(1)
+
+	d := &DB{db: sqlDB}
+	if err := d.migrate(context.Background()); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	slog.Info("opened sqlite database", "path", path)
+	return d, nil
+}
+
+// migrate は未適用のマイグレーションを適用します
+func (d *DB) migrate(ctx context.Context) error {
+	var version int
+	if err := d.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := d.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to apply migration v%d: %w", i+1, err)
+		}
+		// PRAGMA はプレースホルダを使えないため数値を埋め込む
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to update schema version: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration v%d: %w", i+1, err)
+		}
+		slog.Info("applied sqlite migration", "version", i+1)
+	}
+
+	return nil
+}
+
+// Close はデータベースをクローズします
+func (d *DB) Close() error {
+	return d.db.Close()
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// FavoritesStore はSQLiteに保存するお気に入りリポジトリです
+// domain.FavoritesRepository インターフェースを実装します
+type FavoritesStore struct {
+	db *DB
+}
+
+// インターフェース実装の確認
+var _ domain.FavoritesRepository = (*FavoritesStore)(nil)
+
+// NewFavoritesStore は新しいFavoritesStoreを作成します
+func NewFavoritesStore(db *DB) *FavoritesStore {
+	return &FavoritesStore{db: db}
+}
+
+// AddFavorite はお気に入りを登録します
+// 同時に登録しても上限を超えないよう、登録済みの確認・件数の確認・登録を1つのトランザクションで行います
+func (s *FavoritesStore) AddFavorite(ctx context.Context, fav *domain.Favorite, limit int) error {
+	createdAt := fav.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	tx, err := s.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin favorite insert: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists, count int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM favorites WHERE user_id = ? AND spotify_id = ?`, fav.UserID, fav.SpotifyID,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check favorite: %w", err)
+	}
+	if exists > 0 {
+		return domain.ErrFavoriteExists
+	}
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM favorites WHERE user_id = ?`, fav.UserID,
+	).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count favorites: %w", err)
+	}
+	if count >= limit {
+		return domain.ErrFavoriteLimitReached
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO favorites (user_id, entity_type, spotify_id, url, name, subtitle, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		fav.UserID, fav.EntityType, fav.SpotifyID, fav.URL, fav.Name, fav.Subtitle, createdAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to insert favorite: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit favorite: %w", err)
+	}
+
+	return nil
+}
+
+// ListFavorites はユーザーのお気に入りを新しい順に取得します
+func (s *FavoritesStore) ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error) {
+	rows, err := s.db.db.QueryContext(ctx,
+		`SELECT user_id, entity_type, spotify_id, url, name, subtitle, created_at
+		 FROM favorites WHERE user_id = ? ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query favorites: %w", err)
+	}
+	defer rows.Close()
+
+	var favorites []domain.Favorite
+	for rows.Next() {
+		var fav domain.Favorite
+		var createdAt int64
+		if err := rows.Scan(&fav.UserID, &fav.EntityType, &fav.SpotifyID, &fav.URL, &fav.Name, &fav.Subtitle, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan favorite: %w", err)
+		}
+		fav.CreatedAt = time.UnixMilli(createdAt)
+		favorites = append(favorites, fav)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate favorites: %w", err)
+	}
+
+	return favorites, nil
+}
+
+// CountFavorites はユーザーのお気に入り件数を返します
+func (s *FavoritesStore) CountFavorites(ctx context.Context, userID string) (int, error) {
+	var count int
+	if err := s.db.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM favorites WHERE user_id = ?`, userID,
+	).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count favorites: %w", err)
+	}
+	return count, nil
+}
+
+// RemoveFavorite はお気に入りを削除します
+func (s *FavoritesStore) RemoveFavorite(ctx context.Context, userID, spotifyID string) error {
+	res, err := s.db.db.ExecContext(ctx,
+		`DELETE FROM favorites WHERE user_id = ? AND spotify_id = ?`, userID, spotifyID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete favorite: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete favorite: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrFavoriteNotFound
+	}
+
+	return nil
+}
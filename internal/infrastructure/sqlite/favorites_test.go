@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// openTestDB はテスト用の一時データベースを開きます
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "jamberry.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestFavoritesStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFavoritesStore(openTestDB(t))
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	favs := []*domain.Favorite{
+		{UserID: "user1", EntityType: "track", SpotifyID: "track1", URL: "https://open.spotify.com/track/track1", Name: "Track 1", Subtitle: "Artist", CreatedAt: base},
+		{UserID: "user1", EntityType: "album", SpotifyID: "album1", URL: "https://open.spotify.com/album/album1", Name: "Album 1", CreatedAt: base.Add(time.Minute)},
+		{UserID: "user2", EntityType: "track", SpotifyID: "track1", URL: "https://open.spotify.com/track/track1", Name: "Track 1", CreatedAt: base},
+	}
+	for _, f := range favs {
+		if err := store.AddFavorite(ctx, f, 100); err != nil {
+			t.Fatalf("AddFavorite() error = %v", err)
+		}
+	}
+
+	// 重複登録
+	if err := store.AddFavorite(ctx, favs[0], 100); !errors.Is(err, domain.ErrFavoriteExists) {
+		t.Errorf("AddFavorite() duplicate error = %v, want ErrFavoriteExists", err)
+	}
+
+	// 一覧（新しい順）
+	list, err := store.ListFavorites(ctx, "user1")
+	if err != nil {
+		t.Fatalf("ListFavorites() error = %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListFavorites() count = %d, want 2", len(list))
+	}
+	if list[0].SpotifyID != "album1" || list[1].SpotifyID != "track1" {
+		t.Errorf("ListFavorites() order = [%s, %s], want [album1, track1]", list[0].SpotifyID, list[1].SpotifyID)
+	}
+	if list[1].Subtitle != "Artist" || !list[1].CreatedAt.Equal(base) {
+		t.Errorf("ListFavorites() fields not restored: %+v", list[1])
+	}
+
+	count, err := store.CountFavorites(ctx, "user1")
+	if err != nil || count != 2 {
+		t.Errorf("CountFavorites() = %d, %v, want 2", count, err)
+	}
+
+	// 削除
+	if err := store.RemoveFavorite(ctx, "user1", "track1"); err != nil {
+		t.Errorf("RemoveFavorite() error = %v", err)
+	}
+	if err := store.RemoveFavorite(ctx, "user1", "track1"); !errors.Is(err, domain.ErrFavoriteNotFound) {
+		t.Errorf("RemoveFavorite() missing error = %v, want ErrFavoriteNotFound", err)
+	}
+
+	// 他ユーザーのお気に入りには影響しない
+	if count, _ := store.CountFavorites(ctx, "user2"); count != 1 {
+		t.Errorf("CountFavorites(user2) = %d, want 1", count)
+	}
+}
+
+func TestFavoritesStore_Limit(t *testing.T) {
+	ctx := context.Background()
+	store := NewFavoritesStore(openTestDB(t))
+	const limit = 5
+
+	// 同時に登録しても上限を超えない
+	var wg sync.WaitGroup
+	errs := make(chan error, limit*2)
+	for i := range limit * 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.AddFavorite(ctx, &domain.Favorite{
+				UserID: "user1", EntityType: "track", SpotifyID: fmt.Sprintf("track%d", i), URL: "https://open.spotify.com/track/x", Name: "Track",
+			}, limit)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	added := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case !errors.Is(err, domain.ErrFavoriteLimitReached):
+			t.Errorf("AddFavorite() error = %v, want ErrFavoriteLimitReached", err)
+		}
+	}
+	if added != limit {
+		t.Errorf("added = %d, want %d", added, limit)
+	}
+	if count, _ := store.CountFavorites(ctx, "user1"); count != limit {
+		t.Errorf("CountFavorites() = %d, want %d", count, limit)
+	}
+
+	// 上限に達していても登録済みのものは ErrFavoriteExists を返す
+	if err := store.AddFavorite(ctx, &domain.Favorite{UserID: "user1", EntityType: "track", SpotifyID: "track0"}, limit); !errors.Is(err, domain.ErrFavoriteExists) {
+		t.Errorf("AddFavorite() duplicate error = %v, want ErrFavoriteExists", err)
+	}
+}
+
+func TestOpen_Migrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "jamberry.db")
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	_ = db.Close()
+
+	// 再オープン時は適用済みのマイグレーションがスキップされる
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() second time error = %v", err)
+	}
+	defer db.Close()
+
+	var version int
+	if err := db.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("failed to read user_version: %v", err)
+	}
+	if version != len(migrations) {
+		t.Errorf("user_version = %d, want %d", version, len(migrations))
+	}
+}
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// GuildSettingsStore はSQLiteに保存するギルド設定リポジトリです
+// domain.GuildSettingsRepository インターフェースを実装します
+type GuildSettingsStore struct {
+	db *DB
+}
+
+// インターフェース実装の確認
+var _ domain.GuildSettingsRepository = (*GuildSettingsStore)(nil)
+
+// NewGuildSettingsStore は新しいGuildSettingsStoreを作成します
+func NewGuildSettingsStore(db *DB) *GuildSettingsStore {
+	return &GuildSettingsStore{db: db}
+}
+
+// GetGuildSettings はギルドの設定を取得します
+func (s *GuildSettingsStore) GetGuildSettings(ctx context.Context, guildID string) (*domain.GuildSettings, error) {
+	var (
+		settings         = domain.GuildSettings{GuildID: guildID}
+		mode             string
+		autoLink         sql.NullBool
+		allowedChannels  string
+		detailVisibility string
+		updatedAt        int64
+	)
+	err := s.db.db.QueryRowContext(ctx,
+		`SELECT recommend_mode, page_size, auto_link, locale, allowed_channels, detail_visibility, updated_at
+		 FROM guild_settings WHERE guild_id = ?`,
+		guildID,
+	).Scan(&mode, &settings.PageSize, &autoLink, &settings.Locale, &allowedChannels, &detailVisibility, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrGuildSettingsNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query guild settings: %w", err)
+	}
+
+	settings.RecommendMode = domain.RecommendMode(mode)
+	settings.DetailVisibility = domain.DetailVisibility(detailVisibility)
+	if autoLink.Valid {
+		settings.AutoLink = &autoLink.Bool
+	}
+	// チャンネルIDはカンマ区切りで保存する（Snowflake はカンマを含まない）
+	if allowedChannels != "" {
+		settings.AllowedChannels = strings.Split(allowedChannels, ",")
+	}
+	settings.UpdatedAt = time.UnixMilli(updatedAt)
+
+	return &settings, nil
+}
+
+// SaveGuildSettings はギルドの設定を保存します
+func (s *GuildSettingsStore) SaveGuildSettings(ctx context.Context, settings *domain.GuildSettings) error {
+	updatedAt := settings.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	var autoLink sql.NullBool
+	if settings.AutoLink != nil {
+		autoLink = sql.NullBool{Bool: *settings.AutoLink, Valid: true}
+	}
+
+	if _, err := s.db.db.ExecContext(ctx,
+		`INSERT INTO guild_settings (guild_id, recommend_mode, page_size, auto_link, locale, allowed_channels, detail_visibility, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (guild_id) DO UPDATE SET
+		   recommend_mode = excluded.recommend_mode,
+		   page_size = excluded.page_size,
+		   auto_link = excluded.auto_link,
+		   locale = excluded.locale,
+		   allowed_channels = excluded.allowed_channels,
+		   detail_visibility = excluded.detail_visibility,
+		   updated_at = excluded.updated_at`,
+		settings.GuildID, string(settings.RecommendMode), settings.PageSize, autoLink, settings.Locale,
+		strings.Join(settings.AllowedChannels, ","), string(settings.DetailVisibility), updatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("failed to save guild settings: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGuildSettings はギルドの設定を削除します
+func (s *GuildSettingsStore) DeleteGuildSettings(ctx context.Context, guildID string) error {
+	if _, err := s.db.db.ExecContext(ctx,
+		`DELETE FROM guild_settings WHERE guild_id = ?`, guildID,
+	); err != nil {
+		return fmt.Errorf("failed to delete guild settings: %w", err)
+	}
+	return nil
+}
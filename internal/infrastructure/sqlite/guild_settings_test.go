@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestGuildSettingsStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewGuildSettingsStore(openTestDB(t))
+
+	// 未保存
+	if _, err := store.GetGuildSettings(ctx, "guild1"); !errors.Is(err, domain.ErrGuildSettingsNotFound) {
+		t.Fatalf("GetGuildSettings() error = %v, want ErrGuildSettingsNotFound", err)
+	}
+
+	// 保存と取得
+	disabled := false
+	updatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	settings := &domain.GuildSettings{
+		GuildID:          "guild1",
+		RecommendMode:    domain.RecommendModeSimilar,
+		PageSize:         8,
+		AutoLink:         &disabled,
+		Locale:           "en",
+		AllowedChannels:  []string{"ch1", "ch2"},
+		DetailVisibility: domain.DetailVisibilityPublic,
+		UpdatedAt:        updatedAt,
+	}
+	if err := store.SaveGuildSettings(ctx, settings); err != nil {
+		t.Fatalf("SaveGuildSettings() error = %v", err)
+	}
+
+	got, err := store.GetGuildSettings(ctx, "guild1")
+	if err != nil {
+		t.Fatalf("GetGuildSettings() error = %v", err)
+	}
+	if got.RecommendMode != domain.RecommendModeSimilar || got.PageSize != 8 || got.Locale != "en" || got.DetailVisibility != domain.DetailVisibilityPublic || !got.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("GetGuildSettings() fields not restored: %+v", got)
+	}
+	if got.AutoLink == nil || *got.AutoLink {
+		t.Errorf("GetGuildSettings() AutoLink = %v, want false", got.AutoLink)
+	}
+	if !slices.Equal(got.AllowedChannels, []string{"ch1", "ch2"}) {
+		t.Errorf("GetGuildSettings() AllowedChannels = %v, want [ch1 ch2]", got.AllowedChannels)
+	}
+
+	// 上書き（未設定に戻した項目はゼロ値で復元される）
+	if err := store.SaveGuildSettings(ctx, &domain.GuildSettings{GuildID: "guild1", PageSize: 3}); err != nil {
+		t.Fatalf("SaveGuildSettings() overwrite error = %v", err)
+	}
+	got, err = store.GetGuildSettings(ctx, "guild1")
+	if err != nil {
+		t.Fatalf("GetGuildSettings() error = %v", err)
+	}
+	if got.PageSize != 3 || got.RecommendMode != "" || got.AutoLink != nil || got.Locale != "" || got.AllowedChannels != nil || got.DetailVisibility != "" {
+		t.Errorf("GetGuildSettings() after overwrite = %+v", got)
+	}
+
+	// 他のギルドには影響しない
+	if _, err := store.GetGuildSettings(ctx, "guild2"); !errors.Is(err, domain.ErrGuildSettingsNotFound) {
+		t.Errorf("GetGuildSettings(guild2) error = %v, want ErrGuildSettingsNotFound", err)
+	}
+
+	// 削除（未保存のギルドも成功する）
+	if err := store.DeleteGuildSettings(ctx, "guild1"); err != nil {
+		t.Fatalf("DeleteGuildSettings() error = %v", err)
+	}
+	if _, err := store.GetGuildSettings(ctx, "guild1"); !errors.Is(err, domain.ErrGuildSettingsNotFound) {
+		t.Errorf("GetGuildSettings() after delete error = %v, want ErrGuildSettingsNotFound", err)
+	}
+	if err := store.DeleteGuildSettings(ctx, "guild2"); err != nil {
+		t.Errorf("DeleteGuildSettings(guild2) error = %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// HistoryStore はSQLiteに保存するコマンド実行履歴リポジトリです
+// domain.HistoryRepository インターフェースを実装します
+type HistoryStore struct {
+	db *DB
+}
+
+// インターフェース実装の確認
+var _ domain.HistoryRepository = (*HistoryStore)(nil)
+
+// NewHistoryStore は新しいHistoryStoreを作成します
+func NewHistoryStore(db *DB) *HistoryStore {
+	return &HistoryStore{db: db}
+}
+
+// historyColumns は履歴の取得カラムです（scanHistory と順序を合わせる）
+const historyColumns = `id, user_id, command, entity_type, url, query, mode, label, created_at`
+
+// AddHistory は履歴を追加します
+func (s *HistoryStore) AddHistory(ctx context.Context, entry *domain.HistoryEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	res, err := s.db.db.ExecContext(ctx,
+		`INSERT INTO history (user_id, command, entity_type, url, query, mode, label, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.UserID, entry.Command, entry.EntityType, entry.URL, entry.Query, entry.Mode, entry.Label, entry.CreatedAt.UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert history: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to insert history: %w", err)
+	}
+	entry.ID = id
+
+	return nil
+}
+
+// ListHistory はユーザーの履歴を新しい順に最大 limit 件取得します
+func (s *HistoryStore) ListHistory(ctx context.Context, userID string, limit int) ([]domain.HistoryEntry, error) {
+	rows, err := s.db.db.QueryContext(ctx,
+		`SELECT `+historyColumns+` FROM history
+		 WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ?`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.HistoryEntry
+	for rows.Next() {
+		entry, err := scanHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetHistory はユーザーの履歴を1件取得します
+func (s *HistoryStore) GetHistory(ctx context.Context, userID string, id int64) (*domain.HistoryEntry, error) {
+	row := s.db.db.QueryRowContext(ctx,
+		`SELECT `+historyColumns+` FROM history WHERE user_id = ? AND id = ?`,
+		userID, id,
+	)
+
+	entry, err := scanHistory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrHistoryNotFound
+	}
+	return entry, err
+}
+
+// PruneHistory はユーザーの履歴を新しい順に keep 件だけ残して削除します
+func (s *HistoryStore) PruneHistory(ctx context.Context, userID string, keep int) error {
+	if _, err := s.db.db.ExecContext(ctx,
+		`DELETE FROM history WHERE user_id = ? AND id NOT IN (
+			SELECT id FROM history WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ?
+		)`,
+		userID, userID, keep,
+	); err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	return nil
+}
+
+// ClearHistory はユーザーの履歴をすべて削除します
+func (s *HistoryStore) ClearHistory(ctx context.Context, userID string) (int, error) {
+	res, err := s.db.db.ExecContext(ctx, `DELETE FROM history WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear history: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear history: %w", err)
+	}
+	return int(n), nil
+}
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェースです
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanHistory は1行分の履歴を読み取ります
+func scanHistory(row rowScanner) (*domain.HistoryEntry, error) {
+	var entry domain.HistoryEntry
+	var createdAt int64
+	if err := row.Scan(&entry.ID, &entry.UserID, &entry.Command, &entry.EntityType, &entry.URL,
+		&entry.Query, &entry.Mode, &entry.Label, &createdAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan history: %w", err)
+	}
+	entry.CreatedAt = time.UnixMilli(createdAt)
+	return &entry, nil
+}
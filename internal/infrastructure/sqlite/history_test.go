@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestHistoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewHistoryStore(openTestDB(t))
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []*domain.HistoryEntry{
+		{UserID: "user1", Command: "track", EntityType: "track", URL: "https://open.spotify.com/track/track1", Label: "Track 1", CreatedAt: base},
+		{UserID: "user1", Command: "search", Query: "YOASOBI", Label: "YOASOBI", CreatedAt: base.Add(time.Minute)},
+		{UserID: "user1", Command: "recommend", EntityType: "album", URL: "https://open.spotify.com/album/album1", Mode: "similar", Label: "Album 1", CreatedAt: base.Add(2 * time.Minute)},
+		{UserID: "user2", Command: "artist", EntityType: "artist", URL: "https://open.spotify.com/artist/artist1", CreatedAt: base},
+	}
+	for _, e := range entries {
+		if err := store.AddHistory(ctx, e); err != nil {
+			t.Fatalf("AddHistory() error = %v", err)
+		}
+		if e.ID == 0 {
+			t.Error("AddHistory() should set entry ID")
+		}
+	}
+
+	// 一覧（新しい順・件数制限）
+	list, err := store.ListHistory(ctx, "user1", 2)
+	if err != nil {
+		t.Fatalf("ListHistory() error = %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListHistory() count = %d, want 2", len(list))
+	}
+	if list[0].Command != "recommend" || list[1].Command != "search" {
+		t.Errorf("ListHistory() order = [%s, %s], want [recommend, search]", list[0].Command, list[1].Command)
+	}
+	if list[0].Mode != "similar" || list[1].Query != "YOASOBI" {
+		t.Errorf("ListHistory() fields not restored: %+v", list)
+	}
+
+	// 1件取得（他ユーザーの履歴は取得できない）
+	got, err := store.GetHistory(ctx, "user1", entries[0].ID)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if got.URL != entries[0].URL || !got.CreatedAt.Equal(base) {
+		t.Errorf("GetHistory() = %+v", got)
+	}
+	if _, err := store.GetHistory(ctx, "user2", entries[0].ID); !errors.Is(err, domain.ErrHistoryNotFound) {
+		t.Errorf("GetHistory() other user error = %v, want ErrHistoryNotFound", err)
+	}
+
+	// 保持件数を超えた古い履歴を削除
+	if err := store.PruneHistory(ctx, "user1", 1); err != nil {
+		t.Fatalf("PruneHistory() error = %v", err)
+	}
+	list, _ = store.ListHistory(ctx, "user1", 10)
+	if len(list) != 1 || list[0].Command != "recommend" {
+		t.Errorf("after PruneHistory() = %+v, want only recommend", list)
+	}
+
+	// 全削除
+	n, err := store.ClearHistory(ctx, "user1")
+	if err != nil || n != 1 {
+		t.Errorf("ClearHistory() = %d, %v, want 1", n, err)
+	}
+	if list, _ := store.ListHistory(ctx, "user2", 10); len(list) != 1 {
+		t.Errorf("ClearHistory() should not affect other users, got %d", len(list))
+	}
+}
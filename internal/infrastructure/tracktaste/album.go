@@ -70,6 +70,11 @@ func (a *albumResponse) toDomain() *domain.AlbumDetail {
 	}
 }
 
+// albumSearchResponse はアルバム検索結果のレスポンス形式を表します
+type albumSearchResponse struct {
+	Items []albumResponse `json:"items"`
+}
+
 // albumTracks はアルバム内のトラックリストを表します
 type albumTracks struct {
 	Items []albumTrackResponse `json:"items"`
@@ -45,6 +45,11 @@ func (a *artistResponse) toDomain() *domain.ArtistDetail {
 	}
 }
 
+// artistSearchResponse はアーティスト検索結果のレスポンス形式を表します
+type artistSearchResponse struct {
+	Items []artistResponse `json:"items"`
+}
+
 // imageResponse は画像情報を表します
 type imageResponse struct {
 	URL    string `json:"url"`
@@ -0,0 +1,215 @@
+package tracktaste
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
+)
+
+const (
+	// defaultFailureThreshold はサーキットを開くまでの連続失敗回数です
+	defaultFailureThreshold = 5
+	// defaultOpenTimeout はサーキットを開いてから試行（half-open）に移るまでの時間です
+	defaultOpenTimeout = 30 * time.Second
+)
+
+// エンドポイントの系統（サーキットブレーカーの単位）
+const (
+	FamilyFetch     = "fetch"
+	FamilySearch    = "search"
+	FamilyRecommend = "recommend"
+)
+
+// breakerFamilies は表示順を固定するための系統一覧です
+var breakerFamilies = []string{FamilyFetch, FamilySearch, FamilyRecommend}
+
+// BreakerState はサーキットブレーカーの状態です
+type BreakerState int
+
+const (
+	// BreakerClosed は通常どおりリクエストを送る状態です
+	BreakerClosed BreakerState = iota
+	// BreakerOpen はリクエストを送らずに即座に失敗させる状態です
+	BreakerOpen
+	// BreakerHalfOpen は復旧確認のため1件だけリクエストを送る状態です
+	BreakerHalfOpen
+)
+
+// String は状態名を返します
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerStatus はサーキットブレーカーの現在の状態を表します
+type BreakerStatus struct {
+	Family   string
+	State    BreakerState
+	Failures int           // 連続失敗回数
+	RetryIn  time.Duration // open の場合、half-open に移るまでの残り時間
+}
+
+// circuitBreaker はエンドポイント系統ごとのサーキットブレーカーです
+type circuitBreaker struct {
+	family           string
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // half-open で試行中のリクエストがあるか
+}
+
+// newCircuitBreaker は新しいサーキットブレーカーを作成します
+func newCircuitBreaker(family string) *circuitBreaker {
+	cb := &circuitBreaker{
+		family:           family,
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+	}
+	metrics.SetTrackTasteCircuitState(family, int(BreakerClosed))
+	return cb
+}
+
+// allow はリクエストを送ってよいかどうかを返します
+// open の期間が過ぎていれば half-open に移り、1件だけ試行を許可します
+func (cb *circuitBreaker) allow(now time.Time) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerOpen:
+		if now.Sub(cb.openedAt) < cb.openTimeout {
+			return false
+		}
+		cb.setState(BreakerHalfOpen)
+		cb.probing = true
+		return true
+	case BreakerHalfOpen:
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// isOpen はサーキットが開いているかどうかを返します（allow と異なり half-open への移行や試行枠の確保はしません）
+func (cb *circuitBreaker) isOpen() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state == BreakerOpen
+}
+
+// success はリクエストの成功を記録し、half-open の場合はサーキットを閉じます
+func (cb *circuitBreaker) success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.probing = false
+	if cb.state != BreakerClosed {
+		cb.setState(BreakerClosed)
+	}
+}
+
+// failure はリクエストの失敗を記録し、連続失敗が閾値に達するか half-open で失敗した場合はサーキットを開きます
+func (cb *circuitBreaker) failure(now time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.probing = false
+	if cb.state == BreakerHalfOpen || (cb.state == BreakerClosed && cb.failures >= cb.failureThreshold) {
+		cb.openedAt = now
+		cb.setState(BreakerOpen)
+	}
+}
+
+// release は成否を判定できなかったリクエスト（呼び出し元のキャンセルなど）の試行枠を解放します
+func (cb *circuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+}
+
+// status は現在の状態を返します
+func (cb *circuitBreaker) status(now time.Time) BreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	st := BreakerStatus{Family: cb.family, State: cb.state, Failures: cb.failures}
+	if cb.state == BreakerOpen {
+		if retryIn := cb.openTimeout - now.Sub(cb.openedAt); retryIn > 0 {
+			st.RetryIn = retryIn
+		}
+	}
+	return st
+}
+
+// setState は状態を変更してログとメトリクスに記録します（ロック取得済みで呼び出すこと）
+func (cb *circuitBreaker) setState(state BreakerState) {
+	from := cb.state
+	cb.state = state
+	metrics.SetTrackTasteCircuitState(cb.family, int(state))
+
+	switch state {
+	case BreakerOpen:
+		slog.Warn("tracktaste circuit breaker opened",
+			"family", cb.family,
+			"from", from.String(),
+			"consecutive_failures", cb.failures,
+			"open_timeout", cb.openTimeout,
+		)
+	default:
+		slog.Info("tracktaste circuit breaker state changed",
+			"family", cb.family,
+			"from", from.String(),
+			"to", state.String(),
+		)
+	}
+}
+
+// endpointFamily はAPIパスからサーキットブレーカーの系統を返します
+// ヘルスチェックなど系統に属さないパスは空文字を返します（ブレーカーを通さない）
+func endpointFamily(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/search"):
+		return FamilySearch
+	case strings.HasSuffix(path, "/recommend"), strings.HasSuffix(path, "/similar"):
+		return FamilyRecommend
+	case strings.HasSuffix(path, "/fetch"):
+		return FamilyFetch
+	default:
+		return ""
+	}
+}
+
+// errCircuitOpen はサーキットが開いているときに返すエラーです
+func errCircuitOpen() error {
+	return &domain.UpstreamError{Kind: domain.ErrUpstreamUnavailable, Code: "CIRCUIT_OPEN"}
+}
+
+// BreakerStatuses は系統ごとのサーキットブレーカーの状態を返します
+func (c *Client) BreakerStatuses() []BreakerStatus {
+	now := time.Now()
+	statuses := make([]BreakerStatus, 0, len(breakerFamilies))
+	for _, family := range breakerFamilies {
+		statuses = append(statuses, c.breakers[family].status(now))
+	}
+	return statuses
+}
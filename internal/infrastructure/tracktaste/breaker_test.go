@@ -0,0 +1,211 @@
+package tracktaste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker("test")
+	cb.failureThreshold = 3
+	cb.openTimeout = time.Minute
+
+	// 閾値未満の失敗では閉じたまま
+	cb.failure(now)
+	cb.failure(now)
+	if !cb.allow(now) || cb.state != BreakerClosed {
+		t.Fatalf("state = %v, want closed", cb.state)
+	}
+
+	// 成功で連続失敗回数がリセットされる
+	cb.success()
+	cb.failure(now)
+	cb.failure(now)
+	if cb.state != BreakerClosed {
+		t.Fatalf("state = %v, want closed after success reset", cb.state)
+	}
+
+	// 閾値に達すると開く
+	cb.failure(now)
+	if cb.state != BreakerOpen {
+		t.Fatalf("state = %v, want open", cb.state)
+	}
+	if cb.allow(now.Add(30 * time.Second)) {
+		t.Error("open breaker should reject requests")
+	}
+	if st := cb.status(now.Add(30 * time.Second)); st.RetryIn != 30*time.Second {
+		t.Errorf("RetryIn = %v, want 30s", st.RetryIn)
+	}
+
+	// タイムアウト後は1件だけ試行できる
+	later := now.Add(time.Minute)
+	if !cb.allow(later) || cb.state != BreakerHalfOpen {
+		t.Fatalf("state = %v, want half-open probe to be allowed", cb.state)
+	}
+	if cb.allow(later) {
+		t.Error("only one probe should be allowed in half-open")
+	}
+
+	// 試行が失敗すると再び開く
+	cb.failure(later)
+	if cb.state != BreakerOpen {
+		t.Fatalf("state = %v, want open after failed probe", cb.state)
+	}
+
+	// 試行が成功すると閉じる
+	evenLater := later.Add(time.Minute)
+	if !cb.allow(evenLater) {
+		t.Fatal("probe should be allowed")
+	}
+	cb.success()
+	if cb.state != BreakerClosed || cb.failures != 0 {
+		t.Errorf("state = %v, failures = %d, want closed with no failures", cb.state, cb.failures)
+	}
+}
+
+func TestCircuitBreaker_ReleaseProbe(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker("test")
+	cb.failureThreshold = 1
+	cb.failure(now)
+
+	later := now.Add(cb.openTimeout)
+	if !cb.allow(later) {
+		t.Fatal("probe should be allowed")
+	}
+	cb.release()
+	if !cb.allow(later) {
+		t.Error("released probe slot should be available again")
+	}
+}
+
+func TestEndpointFamily(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v1/track/fetch", FamilyFetch},
+		{"/v1/artist/fetch", FamilyFetch},
+		{"/v1/album/fetch", FamilyFetch},
+		{"/v1/playlist/fetch", FamilyFetch},
+		{"/v1/track/search", FamilySearch},
+		{"/v2/track/recommend", FamilyRecommend},
+		{"/v1/track/similar", FamilyRecommend},
+		{"/healthz", ""},
+	}
+
+	for _, tt := range tests {
+		if got := endpointFamily(tt.path); got != tt.want {
+			t.Errorf("endpointFamily(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var (
+		attempts atomic.Int32
+		healthy  atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Response[trackResponse]{Status: 200, Result: trackResponse{ID: "t1", Name: "Track"}})
+	}))
+	defer server.Close()
+
+	client := NewClientWithRetry(server.URL, RetryConfig{})
+	breaker := client.breakers[FamilyFetch]
+	breaker.openTimeout = 50 * time.Millisecond
+	ctx := context.Background()
+
+	for n := 0; n < defaultFailureThreshold; n++ {
+		if _, err := client.FetchTrack(ctx, "https://open.spotify.com/track/t1"); err == nil {
+			t.Fatal("FetchTrack() should fail while server is unavailable")
+		}
+	}
+
+	// 開いている間はリクエストを送らずに失敗する
+	before := attempts.Load()
+	_, err := client.FetchTrack(ctx, "https://open.spotify.com/track/t1")
+	if err == nil || err.Error() != errCircuitOpen().Error() {
+		t.Errorf("FetchTrack() error = %v, want circuit open error", err)
+	}
+	if attempts.Load() != before {
+		t.Error("request should not be sent while the circuit is open")
+	}
+
+	// 他の系統には影響しない
+	if st := client.breakers[FamilySearch].status(time.Now()); st.State != BreakerClosed {
+		t.Errorf("search breaker state = %v, want closed", st.State)
+	}
+
+	// 復旧後、half-open の試行が成功すると閉じる
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.FetchTrack(ctx, "https://open.spotify.com/track/t1"); err != nil {
+		t.Fatalf("FetchTrack() error = %v", err)
+	}
+
+	statuses := client.BreakerStatuses()
+	if len(statuses) != 3 || statuses[0].Family != FamilyFetch || statuses[0].State != BreakerClosed {
+		t.Errorf("BreakerStatuses() = %+v, want fetch closed first", statuses)
+	}
+}
+
+func TestClient_CircuitBreaker_RecordsOncePerRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		wantAttempts int32
+		wantFailures int
+	}{
+		{
+			name: "retries count as one failure",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantAttempts: 3,
+			wantFailures: 1,
+		},
+		{
+			name: "invalid response counts as failure",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("not json"))
+			},
+			wantAttempts: 1,
+			wantFailures: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			client := NewClientWithRetry(server.URL, testRetryConfig())
+			if _, err := client.FetchTrack(context.Background(), "https://open.spotify.com/track/t1"); err == nil {
+				t.Fatal("FetchTrack() should fail")
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if st := client.breakers[FamilyFetch].status(time.Now()); st.Failures != tt.wantFailures {
+				t.Errorf("Failures = %d, want %d", st.Failures, tt.wantFailures)
+			}
+		})
+	}
+}
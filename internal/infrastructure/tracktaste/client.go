@@ -3,14 +3,17 @@ package tracktaste
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/metrics"
 )
 
 // Client はtracktaste APIクライアントです
@@ -18,15 +21,29 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      RetryConfig
+	breakers   map[string]*circuitBreaker // エンドポイント系統ごとのサーキットブレーカー
 }
 
-// NewClient は新しいtracktaste APIクライアントを作成します
+// NewClient はデフォルトのリトライ設定で新しいtracktaste APIクライアントを作成します
 func NewClient(baseURL string) *Client {
+	return NewClientWithRetry(baseURL, DefaultRetryConfig())
+}
+
+// NewClientWithRetry は指定したリトライ設定で新しいtracktaste APIクライアントを作成します
+func NewClientWithRetry(baseURL string, retry RetryConfig) *Client {
+	breakers := make(map[string]*circuitBreaker, len(breakerFamilies))
+	for _, family := range breakerFamilies {
+		breakers[family] = newCircuitBreaker(family)
+	}
+
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second, // v2 recommend APIは複数の外部APIを呼び出すため長めに設定
 		},
+		retry:    retry,
+		breakers: breakers,
 	}
 }
 
@@ -121,6 +138,40 @@ func (c *Client) SearchTracks(ctx context.Context, query string) ([]domain.Track
 	return tracks, nil
 }
 
+// SearchArtists はアーティストを検索します
+func (c *Client) SearchArtists(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+	endpoint := fmt.Sprintf("%s/v1/artist/search?q=%s", c.baseURL, url.QueryEscape(query))
+
+	resp, err := doRequest[artistSearchResponse](ctx, c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	artists := make([]domain.ArtistDetail, len(resp.Items))
+	for i, item := range resp.Items {
+		artists[i] = *item.toDomain()
+	}
+
+	return artists, nil
+}
+
+// SearchAlbums はアルバムを検索します
+func (c *Client) SearchAlbums(ctx context.Context, query string) ([]domain.Album, error) {
+	endpoint := fmt.Sprintf("%s/v1/album/search?q=%s", c.baseURL, url.QueryEscape(query))
+
+	resp, err := doRequest[albumSearchResponse](ctx, c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	albums := make([]domain.Album, len(resp.Items))
+	for i, item := range resp.Items {
+		albums[i] = item.toDomainBasic()
+	}
+
+	return albums, nil
+}
+
 // FetchArtist はアーティスト情報を取得します
 func (c *Client) FetchArtist(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error) {
 	endpoint := fmt.Sprintf("%s/v1/artist/fetch?url=%s", c.baseURL, url.QueryEscape(spotifyURL))
@@ -145,24 +196,156 @@ func (c *Client) FetchAlbum(ctx context.Context, spotifyURL string) (*domain.Alb
 	return resp.toDomain(), nil
 }
 
+// FetchPlaylist はプレイリスト情報を取得します
+func (c *Client) FetchPlaylist(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+	endpoint := fmt.Sprintf("%s/v1/playlist/fetch?url=%s", c.baseURL, url.QueryEscape(spotifyURL))
+
+	resp, err := doRequest[playlistResponse](ctx, c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.toDomain(), nil
+}
+
 // doRequest はAPIリクエストを実行します（v1 API用）
+// 接続エラーや一時的なエラー（429/502/503/504）の場合は、指数バックオフでリトライします
+// エンドポイント系統のサーキットが開いている場合は、リクエストを送らずに即座に失敗します
+// サーキットブレーカーにはリトライを含めた1回のリクエストとして、最終的な結果のみを記録します
 func doRequest[T any](ctx context.Context, c *Client, endpoint string) (*T, error) {
+	breaker := c.breakerFor(endpoint)
+	if breaker != nil && !breaker.allow(time.Now()) {
+		metrics.IncTrackTasteError("CIRCUIT_OPEN")
+		slog.Debug("tracktaste circuit breaker is open, request rejected", "endpoint", endpoint, "family", breaker.family)
+		return nil, errCircuitOpen()
+	}
+
+	var (
+		res    attemptResult
+		waited time.Duration
+	)
+	for retry := 0; ; retry++ {
+		res = c.doAttempt(ctx, endpoint)
+		if res.err == nil || !res.retryable(ctx) || retry >= c.retry.MaxRetries {
+			break
+		}
+		if breaker != nil && breaker.isOpen() {
+			break // リトライ中に他のリクエストでサーキットが開いた場合は直前のエラーを返す
+		}
+
+		delay := c.retry.backoff(retry + 1)
+		if res.retryAfter > delay {
+			delay = res.retryAfter
+		}
+		if !waitForRetry(ctx, delay, c.retry.MaxWait-waited) {
+			slog.Warn("tracktaste API retry skipped, not enough time left",
+				"endpoint", endpoint,
+				"retry", retry+1,
+				"delay_ms", delay.Milliseconds(),
+			)
+			break
+		}
+		waited += delay
+
+		metrics.IncTrackTasteRetry(res.reason())
+		slog.Warn("retrying tracktaste API request",
+			"endpoint", endpoint,
+			"retry", retry+1,
+			"reason", res.reason(),
+			"delay_ms", delay.Milliseconds(),
+		)
+	}
+
+	var result Response[T]
+	if res.err == nil {
+		if err := json.Unmarshal(res.body, &result); err != nil {
+			res.err = &domain.UpstreamError{Kind: domain.ErrUpstream, Code: "INVALID_RESPONSE", Status: res.status, Err: fmt.Errorf("failed to parse response: %w", err)}
+			res.invalid = true
+		}
+	}
+	recordBreakerResult(ctx, breaker, res)
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return &result.Result, nil
+}
+
+// breakerFor はエンドポイントに対応するサーキットブレーカーを返します（対象外の場合は nil）
+func (c *Client) breakerFor(endpoint string) *circuitBreaker {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil
+	}
+	return c.breakers[endpointFamily(u.Path)]
+}
+
+// recordBreakerResult はリクエスト結果をサーキットブレーカーに記録します
+// 接続エラー・5xx・解析できないレスポンスを失敗とし、4xxはtracktasteが応答できているため成功として扱います
+func recordBreakerResult(ctx context.Context, breaker *circuitBreaker, res attemptResult) {
+	if breaker == nil {
+		return
+	}
+	switch {
+	case res.err == nil:
+		breaker.success()
+	case ctx.Err() != nil, res.status == http.StatusTooManyRequests:
+		// 呼び出し元のキャンセルや流量制限は障害とはみなさない
+		breaker.release()
+	case res.status == 0, res.status >= http.StatusInternalServerError, res.invalid:
+		breaker.failure(time.Now())
+	default:
+		breaker.success()
+	}
+}
+
+// attemptResult は1回のAPIリクエストの結果を表します
+type attemptResult struct {
+	body       []byte
+	status     int           // HTTPステータス（接続エラーの場合は0）
+	retryAfter time.Duration // Retry-After ヘッダーの待ち時間
+	transient  bool          // 一時的なエラー（接続エラー・429/502/503/504）かどうか
+	invalid    bool          // 2xxだがレスポンスを解析できなかったかどうか
+	err        error         // *domain.UpstreamError（成功時は nil）
+}
+
+// retryable はリトライ対象のエラーかどうかを返します
+// 呼び出し元のcontextがキャンセル済みの場合はリトライしません
+func (r attemptResult) retryable(ctx context.Context) bool {
+	return r.err != nil && r.transient && ctx.Err() == nil
+}
+
+// reason はリトライ理由（メトリクスのラベル値）を返します
+func (r attemptResult) reason() string {
+	if r.status == 0 {
+		return "CONNECTION_ERROR"
+	}
+	return fmt.Sprintf("HTTP_%d", r.status)
+}
+
+// doAttempt はAPIリクエストを1回実行します
+func (c *Client) doAttempt(ctx context.Context, endpoint string) attemptResult {
 	start := time.Now()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return attemptResult{err: &domain.UpstreamError{Kind: domain.ErrUpstream, Code: "INVALID_REQUEST", Err: fmt.Errorf("failed to create request: %w", err)}}
 	}
 	req.Header.Set("Accept", "application/json")
 
+	// メトリクスのラベルにはクエリ文字列を含まないパスを使う
+	endpointPath := req.URL.Path
+
 	resp, err := c.httpClient.Do(req)
+	metrics.ObserveTrackTasteRequest(endpointPath, time.Since(start))
 	if err != nil {
+		metrics.IncTrackTasteError("CONNECTION_ERROR")
 		slog.Warn("tracktaste API request failed",
 			"endpoint", endpoint,
 			"error", err,
 			"latency_ms", time.Since(start).Milliseconds(),
 		)
-		return nil, fmt.Errorf("❌ 接続エラーが発生しました。")
+		return attemptResult{transient: true, err: newConnectionError(err)}
 	}
 	defer resp.Body.Close()
 
@@ -174,65 +357,107 @@ func doRequest[T any](ctx context.Context, c *Client, endpoint string) (*T, erro
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return attemptResult{status: resp.StatusCode, err: &domain.UpstreamError{
+			Kind:   domain.ErrUpstreamUnavailable,
+			Code:   "CONNECTION_ERROR",
+			Status: resp.StatusCode,
+			Err:    fmt.Errorf("failed to read response body: %w", err),
+		}}
 	}
 
 	// エラーレスポンスの場合
 	if resp.StatusCode != http.StatusOK {
+		res := attemptResult{
+			status:     resp.StatusCode,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			transient:  isRetryableStatus(resp.StatusCode),
+		}
 		var apiErr APIError
 		if err := json.Unmarshal(body, &apiErr); err != nil {
-			return nil, handleHTTPError(resp.StatusCode)
+			metrics.IncTrackTasteError(fmt.Sprintf("HTTP_%d", resp.StatusCode))
+			res.err = newHTTPError(resp.StatusCode)
+			return res
+		}
+		code := apiErr.Code
+		if code == "" {
+			code = fmt.Sprintf("HTTP_%d", resp.StatusCode)
 		}
-		return nil, handleAPIError(&apiErr)
+		metrics.IncTrackTasteError(code)
+		res.err = newAPIError(resp.StatusCode, &apiErr)
+		return res
 	}
 
-	var result Response[T]
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	return attemptResult{body: body, status: resp.StatusCode}
+}
+
+// newConnectionError は接続エラー（タイムアウトを含む）を分類したエラーを返します
+func newConnectionError(err error) error {
+	kind := domain.ErrUpstreamUnavailable
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = domain.ErrUpstreamTimeout
 	}
+	return &domain.UpstreamError{Kind: kind, Code: "CONNECTION_ERROR", Err: err}
+}
 
-	return &result.Result, nil
+// newHTTPError はエラーレスポンスの本文を解析できない場合に、HTTPステータスコードで分類したエラーを返します
+func newHTTPError(statusCode int) error {
+	kind := classifyStatus(statusCode)
+	switch kind {
+	case domain.ErrUpstreamTimeout, domain.ErrRateLimited:
+		slog.Warn("tracktaste API error", "status", statusCode, "kind", kind)
+	default:
+		slog.Error("tracktaste API error", "status", statusCode, "kind", kind)
+	}
+	return &domain.UpstreamError{Kind: kind, Code: fmt.Sprintf("HTTP_%d", statusCode), Status: statusCode}
+}
+
+// newAPIError はAPIエラーコードで分類したエラーを返します
+func newAPIError(statusCode int, apiErr *APIError) error {
+	kind := classifyAPIError(statusCode, apiErr.Code)
+	switch kind {
+	case domain.ErrInvalidInput, domain.ErrInvalidURL, domain.ErrInvalidResource:
+		// 入力の誤りは想定内のためログは出さない
+	case domain.ErrUpstreamTimeout, domain.ErrRateLimited:
+		slog.Warn("tracktaste API error", "code", apiErr.Code, "status", statusCode, "kind", kind)
+	default:
+		slog.Error("tracktaste API error", "code", apiErr.Code, "message", apiErr.Message, "status", statusCode, "kind", kind)
+	}
+	code := apiErr.Code
+	if code == "" {
+		code = fmt.Sprintf("HTTP_%d", statusCode)
+	}
+	return &domain.UpstreamError{Kind: kind, Code: code, Status: statusCode, Err: apiErr}
 }
 
-// handleHTTPError はHTTPステータスコードに応じたエラーを返します
-func handleHTTPError(statusCode int) error {
-	switch statusCode {
-	case http.StatusServiceUnavailable:
-		slog.Error("tracktaste API service unavailable", "status", statusCode)
-		return fmt.Errorf("❌ サーバーエラーが発生しました。しばらくしてから再試行してください。")
-	case http.StatusGatewayTimeout:
-		slog.Warn("tracktaste API timeout", "status", statusCode)
-		return fmt.Errorf("❌ リクエストがタイムアウトしました。しばらくしてから再試行してください。")
-	case http.StatusTooManyRequests:
-		slog.Warn("tracktaste API rate limited", "status", statusCode)
-		return fmt.Errorf("⏳ リクエスト制限中です。しばらくしてから再試行してください。")
+// classifyStatus はHTTPステータスコードからエラーの分類を返します
+func classifyStatus(statusCode int) error {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return domain.ErrRateLimited
+	case statusCode == http.StatusGatewayTimeout:
+		return domain.ErrUpstreamTimeout
+	case statusCode >= http.StatusInternalServerError:
+		return domain.ErrUpstreamUnavailable
 	default:
-		slog.Error("tracktaste API error", "status", statusCode)
-		return fmt.Errorf("❌ サーバーエラーが発生しました。しばらくしてから再試行してください。")
+		return domain.ErrUpstream
 	}
 }
 
-// handleAPIError はAPIエラーコードに応じたエラーを返します
-func handleAPIError(apiErr *APIError) error {
-	switch apiErr.Code {
-	case "EMPTY_PARAM", "EMPTY_URL":
-		return fmt.Errorf("❌ URL を入力してください。")
-	case "EMPTY_QUERY":
-		return fmt.Errorf("❌ 検索キーワードを入力してください。")
-	case "INVALID_PARAM":
-		return fmt.Errorf("❌ 入力形式が不正です。")
+// classifyAPIError はAPIエラーコードからエラーの分類を返します（未知のコードはHTTPステータスコードで分類する）
+func classifyAPIError(statusCode int, code string) error {
+	switch code {
+	case "EMPTY_PARAM", "EMPTY_URL", "EMPTY_QUERY", "INVALID_PARAM":
+		return domain.ErrInvalidInput
 	case "NOT_SPOTIFY_URL", "INVALID_URL":
-		return fmt.Errorf("❌ Spotify の URL を入力してください。")
+		return domain.ErrInvalidURL
 	case "INVALID_RESOURCE_TYPE", "DIFFERENT_SPOTIFY_URL":
-		return fmt.Errorf("❌ 正しい種類の URL を入力してください。")
+		return domain.ErrInvalidResource
 	case "SOMETHING_SPOTIFY_ERROR", "SOMETHING_KKBOX_ERROR":
-		slog.Error("tracktaste API backend error", "code", apiErr.Code, "message", apiErr.Message)
-		return fmt.Errorf("❌ サーバーエラーが発生しました。しばらくしてから再試行してください。")
+		return domain.ErrUpstreamUnavailable
 	case "REQUEST_TIMEOUT":
-		slog.Warn("tracktaste API timeout", "code", apiErr.Code)
-		return fmt.Errorf("❌ リクエストがタイムアウトしました。しばらくしてから再試行してください。")
+		return domain.ErrUpstreamTimeout
 	default:
-		slog.Error("tracktaste API unknown error", "code", apiErr.Code, "message", apiErr.Message)
-		return fmt.Errorf("❌ サーバーエラーが発生しました。しばらくしてから再試行してください。")
+		return classifyStatus(statusCode)
 	}
 }
@@ -0,0 +1,75 @@
+package tracktaste
+
+import (
+	"context"
+	"strings"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// maxUPCCandidates は UPC を照合するために詳細を取得する候補アルバムの最大数です
+const maxUPCCandidates = 3
+
+// インターフェース実装の確認
+var _ domain.ExternalIDRepository = (*Client)(nil)
+
+// FindTrackByISRC は ISRC が一致するトラックを返します（見つからない場合は nil）
+// Spotify の検索の isrc: フィルターで検索し、結果の ISRC を照合します
+func (c *Client) FindTrackByISRC(ctx context.Context, isrc string) (*domain.Track, error) {
+	isrc = strings.TrimSpace(isrc)
+	if isrc == "" {
+		return nil, nil
+	}
+
+	tracks, err := c.SearchTracks(ctx, "isrc:"+isrc)
+	if err != nil {
+		return nil, err
+	}
+	for _, track := range tracks {
+		if track.ISRC != nil && strings.EqualFold(*track.ISRC, isrc) {
+			return &track, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindAlbumByUPC は UPC が一致するアルバムを返します（見つからない場合は nil）
+// Spotify のトラック検索は UPC で絞り込めないため、query の検索結果のアルバムを先頭から最大 maxUPCCandidates 件取得して照合します
+func (c *Client) FindAlbumByUPC(ctx context.Context, upc, query string) (*domain.AlbumDetail, error) {
+	upc = normalizeUPC(upc)
+	if upc == "" || strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+
+	tracks, err := c.SearchTracks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, track := range tracks {
+		albumURL := track.Album.URL
+		if albumURL == "" || seen[albumURL] {
+			continue
+		}
+		if len(seen) >= maxUPCCandidates {
+			break
+		}
+		seen[albumURL] = true
+
+		album, err := c.FetchAlbum(ctx, albumURL)
+		if err != nil {
+			return nil, err
+		}
+		if normalizeUPC(album.UPC) == upc {
+			return album, nil
+		}
+	}
+	return nil, nil
+}
+
+// normalizeUPC は UPC の前後の空白と先頭の0を取り除きます
+// サービスによって UPC（12桁）と EAN（13桁、先頭が0）の表記が異なるためです
+func normalizeUPC(upc string) string {
+	return strings.TrimLeft(strings.TrimSpace(upc), "0")
+}
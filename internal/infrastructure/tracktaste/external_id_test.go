@@ -0,0 +1,109 @@
+package tracktaste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestClient_FindTrackByISRC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "isrc:USUM71703861" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		resp := Response[searchResponse]{
+			Status: 200,
+			Result: searchResponse{Items: []searchTrackResponse{
+				{ID: "other", Name: "Cover", ISRC: strPtr("JPXX01700001")},
+				{ID: "t1", Name: "Track 1", URL: "https://open.spotify.com/track/t1", ISRC: strPtr("usum71703861")},
+			}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	track, err := client.FindTrackByISRC(context.Background(), " USUM71703861 ")
+	if err != nil {
+		t.Fatalf("FindTrackByISRC() error = %v", err)
+	}
+	if track == nil || track.ID != "t1" {
+		t.Fatalf("FindTrackByISRC() = %+v, want track t1", track)
+	}
+
+	track, err = client.FindTrackByISRC(context.Background(), "")
+	if err != nil || track != nil {
+		t.Errorf("FindTrackByISRC(\"\") = (%+v, %v), want (nil, nil)", track, err)
+	}
+}
+
+func TestClient_FindAlbumByUPC(t *testing.T) {
+	albumUPCs := map[string]string{
+		"https://open.spotify.com/album/a1": "0000000000001",
+		"https://open.spotify.com/album/a2": "602445790104",
+		"https://open.spotify.com/album/a3": "111111111111",
+		"https://open.spotify.com/album/a4": "222222222222",
+	}
+
+	var albumFetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/track/search":
+			items := []searchTrackResponse{
+				{ID: "t1", Album: albumResponse{URL: "https://open.spotify.com/album/a1"}},
+				{ID: "t2", Album: albumResponse{URL: "https://open.spotify.com/album/a1"}},
+				{ID: "t3", Album: albumResponse{URL: "https://open.spotify.com/album/a2"}},
+				{ID: "t4", Album: albumResponse{URL: "https://open.spotify.com/album/a3"}},
+				{ID: "t5", Album: albumResponse{URL: "https://open.spotify.com/album/a4"}},
+			}
+			_ = json.NewEncoder(w).Encode(Response[searchResponse]{Status: 200, Result: searchResponse{Items: items}})
+		case "/v1/album/fetch":
+			albumFetches++
+			albumURL := r.URL.Query().Get("url")
+			_ = json.NewEncoder(w).Encode(Response[albumResponse]{Status: 200, Result: albumResponse{URL: albumURL, UPC: albumUPCs[albumURL]}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	tests := []struct {
+		name        string
+		upc         string
+		wantURL     string
+		wantFetches int
+	}{
+		{name: "EAN matches UPC", upc: "0602445790104", wantURL: "https://open.spotify.com/album/a2", wantFetches: 2},
+		{name: "only first candidates are checked", upc: "222222222222", wantFetches: 3},
+		{name: "empty upc", upc: "", wantFetches: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumFetches = 0
+			album, err := client.FindAlbumByUPC(context.Background(), tt.upc, "album artist")
+			if err != nil {
+				t.Fatalf("FindAlbumByUPC() error = %v", err)
+			}
+			gotURL := ""
+			if album != nil {
+				gotURL = album.URL
+			}
+			if gotURL != tt.wantURL {
+				t.Errorf("FindAlbumByUPC() URL = %q, want %q", gotURL, tt.wantURL)
+			}
+			if albumFetches != tt.wantFetches {
+				t.Errorf("album fetches = %d, want %d", albumFetches, tt.wantFetches)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package tracktaste
+
+import "github.com/t1nyb0x/jamberry/internal/domain"
+
+// playlistResponse はプレイリスト情報を表します
+type playlistResponse struct {
+	URL         string          `json:"url"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Owner       playlistOwner   `json:"owner"`
+	Followers   *int            `json:"followers,omitempty"`
+	Images      []imageResponse `json:"images"`
+	Tracks      *playlistTracks `json:"tracks,omitempty"`
+}
+
+// playlistOwner はプレイリスト作成者の情報を表します
+type playlistOwner struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	URL         string `json:"url"`
+}
+
+// playlistTracks はプレイリスト内のトラックリストを表します
+type playlistTracks struct {
+	Total int                   `json:"total"`
+	Items []searchTrackResponse `json:"items"`
+}
+
+func (p *playlistResponse) toDomain() *domain.Playlist {
+	images := make([]domain.Image, len(p.Images))
+	for i, img := range p.Images {
+		images[i] = img.toDomain()
+	}
+
+	owner := p.Owner.DisplayName
+	if owner == "" {
+		owner = p.Owner.ID
+	}
+
+	playlist := &domain.Playlist{
+		ID:          p.ID,
+		Name:        p.Name,
+		URL:         p.URL,
+		Description: p.Description,
+		Owner:       owner,
+		Followers:   p.Followers,
+		Images:      images,
+	}
+
+	if p.Tracks != nil {
+		playlist.Tracks = make([]domain.Track, len(p.Tracks.Items))
+		for i, t := range p.Tracks.Items {
+			playlist.Tracks[i] = t.toDomain()
+		}
+		playlist.TotalTracks = p.Tracks.Total
+	}
+
+	// total が返らない場合は取得できた曲数で補完
+	if playlist.TotalTracks < len(playlist.Tracks) {
+		playlist.TotalTracks = len(playlist.Tracks)
+	}
+
+	return playlist
+}
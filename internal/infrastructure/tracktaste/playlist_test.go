@@ -0,0 +1,119 @@
+package tracktaste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_FetchPlaylist(t *testing.T) {
+	followers := 100
+
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+		wantName       string
+		wantOwner      string
+		wantTracks     int
+		wantTotal      int
+	}{
+		{
+			name: "successful fetch",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/playlist/fetch" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				if got := r.URL.Query().Get("url"); got != "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M" {
+					t.Errorf("unexpected url param: %s", got)
+				}
+				resp := Response[playlistResponse]{
+					Status: 200,
+					Result: playlistResponse{
+						ID:        "37i9dQZF1DXcBWIGoYBM5M",
+						Name:      "Test Playlist",
+						Owner:     playlistOwner{ID: "owner1", DisplayName: "Owner Name"},
+						Followers: &followers,
+						Tracks: &playlistTracks{
+							Total: 120,
+							Items: []searchTrackResponse{
+								{ID: "t1", Name: "Track 1"},
+								{ID: "t2", Name: "Track 2"},
+							},
+						},
+					},
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(resp)
+			},
+			wantErr:    false,
+			wantName:   "Test Playlist",
+			wantOwner:  "Owner Name",
+			wantTracks: 2,
+			wantTotal:  120,
+		},
+		{
+			name: "owner without display name and missing total",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				resp := Response[playlistResponse]{
+					Status: 200,
+					Result: playlistResponse{
+						ID:    "37i9dQZF1DXcBWIGoYBM5M",
+						Name:  "Test Playlist",
+						Owner: playlistOwner{ID: "owner1"},
+						Tracks: &playlistTracks{
+							Items: []searchTrackResponse{{ID: "t1", Name: "Track 1"}},
+						},
+					},
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(resp)
+			},
+			wantErr:    false,
+			wantName:   "Test Playlist",
+			wantOwner:  "owner1",
+			wantTracks: 1,
+			wantTotal:  1,
+		},
+		{
+			name: "not found",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(APIError{Status: 400, Message: "invalid", Code: "INVALID_RESOURCE_TYPE"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			playlist, err := client.FetchPlaylist(context.Background(), "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M")
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchPlaylist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if playlist.Name != tt.wantName {
+					t.Errorf("Name = %v, want %v", playlist.Name, tt.wantName)
+				}
+				if playlist.Owner != tt.wantOwner {
+					t.Errorf("Owner = %v, want %v", playlist.Owner, tt.wantOwner)
+				}
+				if len(playlist.Tracks) != tt.wantTracks {
+					t.Errorf("Tracks count = %v, want %v", len(playlist.Tracks), tt.wantTracks)
+				}
+				if playlist.TotalTracks != tt.wantTotal {
+					t.Errorf("TotalTracks = %v, want %v", playlist.TotalTracks, tt.wantTotal)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package tracktaste
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries はリトライ回数（初回リクエストを含まない）のデフォルト値です
+	DefaultMaxRetries = 2
+	// defaultBaseDelay はリトライ間隔の初期値です
+	defaultBaseDelay = 300 * time.Millisecond
+	// defaultMaxDelay はリトライ間隔（Retry-After を除く）の上限です
+	defaultMaxDelay = 3 * time.Second
+	// defaultMaxWait はリトライ待ちに使う時間の合計の上限です
+	// 呼び出し元のcontextに期限がない場合でも、Discordの応答期限内に収まるようにします
+	defaultMaxWait = 30 * time.Second
+)
+
+// RetryConfig はtracktaste APIリクエストのリトライ設定です
+// リトライは冪等なGETリクエストで、接続エラー・429・502・503・504の場合のみ行います
+type RetryConfig struct {
+	MaxRetries int           // 初回リクエストを含まないリトライ回数（0の場合はリトライしない）
+	BaseDelay  time.Duration // 1回目のリトライ間隔（2回目以降は倍々で増加）
+	MaxDelay   time.Duration // リトライ間隔の上限
+	MaxWait    time.Duration // リトライ待ちの合計時間の上限
+}
+
+// DefaultRetryConfig はデフォルトのリトライ設定を返します
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  defaultBaseDelay,
+		MaxDelay:   defaultMaxDelay,
+		MaxWait:    defaultMaxWait,
+	}
+}
+
+// backoff は retry 回目（1始まり）のリトライ前に待つ時間を返します
+// 指数バックオフの値の半分を固定、残り半分をランダムにする（equal jitter）
+func (c RetryConfig) backoff(retry int) time.Duration {
+	delay := c.BaseDelay
+	for n := 1; n < retry && delay < c.MaxDelay; n++ {
+		delay *= 2
+	}
+	if delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// isRetryableStatus はリトライ対象のHTTPステータスかどうかを返します
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter は Retry-After ヘッダー（秒数またはHTTP日付）を待ち時間に変換します
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// waitForRetry はリトライまで待機します
+// 待機するとcontextの期限やリトライ待ちの上限を超える場合は待たずに false を返します
+func waitForRetry(ctx context.Context, delay, remaining time.Duration) bool {
+	if delay > remaining {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
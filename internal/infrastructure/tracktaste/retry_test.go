@@ -0,0 +1,273 @@
+package tracktaste
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// testRetryConfig はテスト用に待ち時間を短くしたリトライ設定を返します
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: 2,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+		MaxWait:    time.Second,
+	}
+}
+
+func TestDoRequest_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int // 各リクエストで返すステータス（最後の値を以降も返す）
+		retryAfter   string
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{
+			name:         "success without retry",
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retry on 503 then succeed",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 2,
+		},
+		{
+			name:         "retry on 502 and 504",
+			statuses:     []int{http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retry on 429 honoring Retry-After",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "0",
+			wantAttempts: 2,
+		},
+		{
+			name:         "give up after max retries",
+			statuses:     []int{http.StatusServiceUnavailable},
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "no retry on 400",
+			statuses:     []int{http.StatusBadRequest},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "no retry on 500",
+			statuses:     []int{http.StatusInternalServerError},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				if status != http.StatusOK {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(status)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(Response[HealthResult]{Status: 200, Result: HealthResult{Status: "ok"}})
+			}))
+			defer server.Close()
+
+			client := NewClientWithRetry(server.URL, testRetryConfig())
+			_, err := client.FetchHealth(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchHealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoRequest_RetryConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := NewClientWithRetry(url, testRetryConfig())
+	_, err := client.FetchHealth(context.Background())
+	var upstreamErr *domain.UpstreamError
+	if !errors.As(err, &upstreamErr) || upstreamErr.Code != "CONNECTION_ERROR" {
+		t.Fatalf("FetchHealth() error = %v, want connection error", err)
+	}
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Error("connection error should be ErrUpstreamUnavailable")
+	}
+}
+
+func TestNewConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"refused", errors.New("connection refused"), domain.ErrUpstreamUnavailable},
+		{"deadline", context.DeadlineExceeded, domain.ErrUpstreamTimeout},
+		{"client timeout", &url.Error{Op: "Get", URL: "http://example.com", Err: timeoutError{}}, domain.ErrUpstreamTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newConnectionError(tt.err); !errors.Is(err, tt.want) {
+				t.Errorf("newConnectionError() = %v, want kind %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// timeoutError はタイムアウトを表す net.Error です
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDoRequest_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		apiError *APIError // nil の場合は本文なし
+		wantKind error
+		wantCode string
+	}{
+		{"500", http.StatusInternalServerError, nil, domain.ErrUpstreamUnavailable, "HTTP_500"},
+		{"503", http.StatusServiceUnavailable, nil, domain.ErrUpstreamUnavailable, "HTTP_503"},
+		{"504", http.StatusGatewayTimeout, nil, domain.ErrUpstreamTimeout, "HTTP_504"},
+		{"429", http.StatusTooManyRequests, nil, domain.ErrRateLimited, "HTTP_429"},
+		{"404", http.StatusNotFound, nil, domain.ErrUpstream, "HTTP_404"},
+		{"empty query", http.StatusBadRequest, &APIError{Code: "EMPTY_QUERY"}, domain.ErrInvalidInput, "EMPTY_QUERY"},
+		{"not spotify url", http.StatusBadRequest, &APIError{Code: "NOT_SPOTIFY_URL"}, domain.ErrInvalidURL, "NOT_SPOTIFY_URL"},
+		{"invalid resource type", http.StatusBadRequest, &APIError{Code: "INVALID_RESOURCE_TYPE"}, domain.ErrInvalidResource, "INVALID_RESOURCE_TYPE"},
+		{"spotify error", http.StatusBadGateway, &APIError{Code: "SOMETHING_SPOTIFY_ERROR"}, domain.ErrUpstreamUnavailable, "SOMETHING_SPOTIFY_ERROR"},
+		{"request timeout", http.StatusGatewayTimeout, &APIError{Code: "REQUEST_TIMEOUT"}, domain.ErrUpstreamTimeout, "REQUEST_TIMEOUT"},
+		{"unknown code", http.StatusBadRequest, &APIError{Code: "SOMETHING_NEW"}, domain.ErrUpstream, "SOMETHING_NEW"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				if tt.apiError != nil {
+					_ = json.NewEncoder(w).Encode(tt.apiError)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClientWithRetry(server.URL, RetryConfig{})
+			_, err := client.FetchHealth(context.Background())
+			if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("FetchHealth() error = %v, want kind %v", err, tt.wantKind)
+			}
+			var upstreamErr *domain.UpstreamError
+			if !errors.As(err, &upstreamErr) {
+				t.Fatalf("FetchHealth() error = %T, want *domain.UpstreamError", err)
+			}
+			if upstreamErr.Code != tt.wantCode || upstreamErr.Status != tt.status {
+				t.Errorf("Code = %s, Status = %d, want %s, %d", upstreamErr.Code, upstreamErr.Status, tt.wantCode, tt.status)
+			}
+			if tt.apiError != nil {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+					t.Errorf("error should wrap the APIError, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestDoRequest_RetryRespectsDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	client := NewClientWithRetry(server.URL, testRetryConfig())
+	start := time.Now()
+	if _, err := client.FetchHealth(ctx); err == nil {
+		t.Fatal("FetchHealth() should return error")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("should give up without waiting past the deadline, took %v", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	cfg := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		retry   int
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for n := 0; n < 20; n++ {
+			got := cfg.backoff(tt.retry)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("backoff(%d) = %v, want [%v, %v]", tt.retry, got, tt.wantMin, tt.wantMax)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "3", 3 * time.Second},
+		{"negative", "-1", 0},
+		{"http date", now.Add(5 * time.Second).Format(http.TimeFormat), 5 * time.Second},
+		{"past date", now.Add(-5 * time.Second).Format(http.TimeFormat), 0},
+		{"invalid", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
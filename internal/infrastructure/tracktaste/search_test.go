@@ -0,0 +1,59 @@
+package tracktaste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SearchArtistsAndAlbums(t *testing.T) {
+	popularity := 80
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "米津玄師" {
+			t.Errorf("unexpected q param: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/artist/search":
+			_ = json.NewEncoder(w).Encode(Response[artistSearchResponse]{
+				Status: 200,
+				Result: artistSearchResponse{Items: []artistResponse{
+					{ID: "1snhtMLZ5MY4H4VyRLCkqh", Name: "米津玄師", Followers: "5,000,000", Popularity: &popularity},
+				}},
+			})
+		case "/v1/album/search":
+			_ = json.NewEncoder(w).Encode(Response[albumSearchResponse]{
+				Status: 200,
+				Result: albumSearchResponse{Items: []albumResponse{
+					{ID: "a1", Name: "STRAY SHEEP", ReleaseDate: "2020-08-05", Artists: []artistBasic{{Name: "米津玄師"}}},
+					{ID: "a2", Name: "Lemon", ReleaseDate: "2018-03-14", Artists: []artistBasic{{Name: "米津玄師"}}},
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(APIError{Status: 404, Message: "not found", Code: "NOT_FOUND"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	artists, err := client.SearchArtists(context.Background(), "米津玄師")
+	if err != nil {
+		t.Fatalf("SearchArtists() error = %v", err)
+	}
+	if len(artists) != 1 || artists[0].Name != "米津玄師" || artists[0].Followers != "5,000,000" {
+		t.Errorf("SearchArtists() = %+v", artists)
+	}
+
+	albums, err := client.SearchAlbums(context.Background(), "米津玄師")
+	if err != nil {
+		t.Fatalf("SearchAlbums() error = %v", err)
+	}
+	if len(albums) != 2 || albums[0].Name != "STRAY SHEEP" || albums[1].Artists[0].Name != "米津玄師" {
+		t.Errorf("SearchAlbums() = %+v", albums)
+	}
+}
@@ -0,0 +1,140 @@
+package linkresolver
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+// Platform は Spotify 以外の音楽配信サービスを表します
+type Platform string
+
+const (
+	PlatformAppleMusic   Platform = "apple_music"
+	PlatformYouTubeMusic Platform = "youtube_music"
+	PlatformDeezer       Platform = "deezer"
+)
+
+// Link は他サービスのトラック・アルバムのリンクを表します
+type Link struct {
+	Platform   Platform
+	EntityType spotify.EntityType // EntityTrack または EntityAlbum
+	ID         string
+	Country    string // Apple Music のストアの国コード（jp など）
+}
+
+// key はキャッシュのキーを返します
+func (l Link) key() string {
+	return string(l.Platform) + ":" + string(l.EntityType) + ":" + l.ID
+}
+
+var (
+	// numericIDRegex は Apple Music・Deezer の数値IDにマッチします
+	numericIDRegex = regexp.MustCompile(`^[0-9]+$`)
+	// youtubeVideoIDRegex は YouTube の動画IDにマッチします
+	youtubeVideoIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+	// youtubeAlbumIDRegex は YouTube Music のアルバムのプレイリストID（OLAK5uy_ で始まる）にマッチします
+	youtubeAlbumIDRegex = regexp.MustCompile(`^OLAK5uy_[a-zA-Z0-9_-]+$`)
+	// countryRegex は Apple Music のストアの国コードにマッチします
+	countryRegex = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+// Parse は Apple Music・YouTube Music・Deezer のトラック・アルバムのURLを解析します
+// 対応していないURLの場合は false を返します
+func Parse(rawURL string) (Link, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return Link{}, false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch strings.ToLower(u.Hostname()) {
+	case "music.apple.com":
+		return parseAppleMusic(parts, u.Query())
+	case "music.youtube.com":
+		return parseYouTubeMusic(parts, u.Query())
+	case "www.deezer.com", "deezer.com":
+		return parseDeezer(parts)
+	default:
+		return Link{}, false
+	}
+}
+
+// IsSupported は入力が他サービスのトラック・アルバムのURLかどうかを返します
+func IsSupported(input string) bool {
+	_, ok := Parse(input)
+	return ok
+}
+
+// IsResolvable は入力が LinkResolver で Spotify のURLに解決するリンク（短縮URL・他サービスのリンク）かどうかを返します
+func IsResolvable(input string) bool {
+	return spotify.IsShortLink(input) || IsSupported(input)
+}
+
+// parseAppleMusic は Apple Music のURLのパスを解析します
+// /{country}/album/{slug}/{id}?i={trackID} はトラック、?i= がない場合はアルバムとして扱います
+// /{country}/song/{slug}/{id} はトラックとして扱います（slug は省略される場合があります）
+func parseAppleMusic(parts []string, query url.Values) (Link, bool) {
+	if len(parts) < 3 || !countryRegex.MatchString(parts[0]) {
+		return Link{}, false
+	}
+	country := parts[0]
+	id := parts[len(parts)-1]
+	if !numericIDRegex.MatchString(id) {
+		return Link{}, false
+	}
+
+	switch parts[1] {
+	case "album":
+		if trackID := query.Get("i"); numericIDRegex.MatchString(trackID) {
+			return Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityTrack, ID: trackID, Country: country}, true
+		}
+		return Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityAlbum, ID: id, Country: country}, true
+	case "song":
+		return Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityTrack, ID: id, Country: country}, true
+	default:
+		return Link{}, false
+	}
+}
+
+// parseYouTubeMusic は YouTube Music のURLを解析します
+// /watch?v={id} はトラック、/playlist?list=OLAK5uy_... はアルバムとして扱います（通常のプレイリストは対象外）
+func parseYouTubeMusic(parts []string, query url.Values) (Link, bool) {
+	if len(parts) != 1 {
+		return Link{}, false
+	}
+
+	switch parts[0] {
+	case "watch":
+		if id := query.Get("v"); youtubeVideoIDRegex.MatchString(id) {
+			return Link{Platform: PlatformYouTubeMusic, EntityType: spotify.EntityTrack, ID: id}, true
+		}
+	case "playlist":
+		if id := query.Get("list"); youtubeAlbumIDRegex.MatchString(id) {
+			return Link{Platform: PlatformYouTubeMusic, EntityType: spotify.EntityAlbum, ID: id}, true
+		}
+	}
+	return Link{}, false
+}
+
+// parseDeezer は Deezer のURLのパスを解析します
+// /{lang}/track/{id} と /{lang}/album/{id} に対応します（言語のパスは省略される場合があります）
+func parseDeezer(parts []string) (Link, bool) {
+	if len(parts) == 3 {
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || !numericIDRegex.MatchString(parts[1]) {
+		return Link{}, false
+	}
+
+	switch parts[0] {
+	case "track":
+		return Link{Platform: PlatformDeezer, EntityType: spotify.EntityTrack, ID: parts[1]}, true
+	case "album":
+		return Link{Platform: PlatformDeezer, EntityType: spotify.EntityAlbum, ID: parts[1]}, true
+	default:
+		return Link{}, false
+	}
+}
@@ -0,0 +1,93 @@
+package linkresolver
+
+import (
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   Link
+		wantOK bool
+	}{
+		{
+			name:   "apple music track in album",
+			input:  "https://music.apple.com/jp/album/lemon/1361329931?i=1361329935",
+			want:   Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityTrack, ID: "1361329935", Country: "jp"},
+			wantOK: true,
+		},
+		{
+			name:   "apple music album",
+			input:  " https://music.apple.com/us/album/lemon/1361329931 ",
+			want:   Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityAlbum, ID: "1361329931", Country: "us"},
+			wantOK: true,
+		},
+		{
+			name:   "apple music song",
+			input:  "https://music.apple.com/jp/song/1361329935",
+			want:   Link{Platform: PlatformAppleMusic, EntityType: spotify.EntityTrack, ID: "1361329935", Country: "jp"},
+			wantOK: true,
+		},
+		{
+			name:   "youtube music track",
+			input:  "https://music.youtube.com/watch?v=SX_ViT4Ra7k&si=abc",
+			want:   Link{Platform: PlatformYouTubeMusic, EntityType: spotify.EntityTrack, ID: "SX_ViT4Ra7k"},
+			wantOK: true,
+		},
+		{
+			name:   "youtube music album",
+			input:  "https://music.youtube.com/playlist?list=OLAK5uy_kZ4Q1Kp1wq2jt7xJ6AAAAAAAAAAAAAAAA",
+			want:   Link{Platform: PlatformYouTubeMusic, EntityType: spotify.EntityAlbum, ID: "OLAK5uy_kZ4Q1Kp1wq2jt7xJ6AAAAAAAAAAAAAAAA"},
+			wantOK: true,
+		},
+		{
+			name:   "deezer track with language",
+			input:  "https://www.deezer.com/ja/track/3135556",
+			want:   Link{Platform: PlatformDeezer, EntityType: spotify.EntityTrack, ID: "3135556"},
+			wantOK: true,
+		},
+		{
+			name:   "deezer album",
+			input:  "https://deezer.com/album/302127",
+			want:   Link{Platform: PlatformDeezer, EntityType: spotify.EntityAlbum, ID: "302127"},
+			wantOK: true,
+		},
+		{name: "apple music artist", input: "https://music.apple.com/jp/artist/kenshi-yonezu/1133030236"},
+		{name: "apple music playlist", input: "https://music.apple.com/jp/playlist/top-100/pl.043a2c9876114d95a4659988497567be"},
+		{name: "youtube music user playlist", input: "https://music.youtube.com/playlist?list=PLxxxxxxxxxxxxxxxx"},
+		{name: "youtube video", input: "https://www.youtube.com/watch?v=SX_ViT4Ra7k"},
+		{name: "deezer artist", input: "https://www.deezer.com/ja/artist/27"},
+		{name: "spotify url", input: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"},
+		{name: "not a url", input: "music.apple.com/jp/album/lemon/1361329931"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Parse(%q) = (%+v, %v), want (%+v, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsResolvable(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"https://spotify.link/AbCdEf123", true},
+		{"https://www.deezer.com/track/3135556", true},
+		{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", false},
+		{"4iV5W9uYEdYUVa79Axb7Rh", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsResolvable(tt.input); got != tt.want {
+			t.Errorf("IsResolvable(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
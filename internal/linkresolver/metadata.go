@@ -0,0 +1,164 @@
+package linkresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/lookup"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+// 各サービスの公開API（認証不要）のエンドポイント
+const (
+	deezerAPIBaseURL = "https://api.deezer.com"
+	iTunesLookupURL  = "https://itunes.apple.com/lookup"
+	youTubeOEmbedURL = "https://www.youtube.com/oembed"
+)
+
+// metadata は他サービスのトラック・アルバムの情報です
+// Spotify の検索に使うため、取得できた項目のみ設定します
+type metadata struct {
+	Title  string // トラック名またはアルバム名
+	Artist string
+	Album  string // トラックの収録アルバム名
+	ISRC   string // トラックの ISRC（Deezer のみ）
+	UPC    string // アルバムの UPC（Deezer のみ）
+}
+
+// fetchMetadata はリンクのサービスの公開APIからトラック・アルバムの情報を取得します
+func (r *Resolver) fetchMetadata(ctx context.Context, link Link) (*metadata, error) {
+	switch link.Platform {
+	case PlatformDeezer:
+		return r.fetchDeezer(ctx, link)
+	case PlatformAppleMusic:
+		return r.fetchAppleMusic(ctx, link)
+	case PlatformYouTubeMusic:
+		return r.fetchYouTubeMusic(ctx, link)
+	default:
+		return nil, fmt.Errorf("unsupported platform: %s", link.Platform)
+	}
+}
+
+// deezerResponse は Deezer API のトラック・アルバムのレスポンスです
+type deezerResponse struct {
+	Title  string `json:"title"`
+	ISRC   string `json:"isrc"`
+	UPC    string `json:"upc"`
+	Artist struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Album struct {
+		Title string `json:"title"`
+	} `json:"album"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// fetchDeezer は Deezer API からトラックの ISRC・アルバムの UPC を含む情報を取得します
+func (r *Resolver) fetchDeezer(ctx context.Context, link Link) (*metadata, error) {
+	endpoint := fmt.Sprintf("%s/%s/%s", deezerAPIBaseURL, link.EntityType, link.ID)
+
+	var resp deezerResponse
+	if err := r.getJSON(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+	// Deezer API は存在しないIDにもステータス200でエラーを返す
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%w: deezer %s: %s", domain.ErrLinkNotMatched, resp.Error.Type, resp.Error.Message)
+	}
+
+	meta := &metadata{Title: resp.Title, Artist: resp.Artist.Name}
+	if link.EntityType == spotify.EntityTrack {
+		meta.Album = resp.Album.Title
+		meta.ISRC = resp.ISRC
+	} else {
+		meta.UPC = resp.UPC
+	}
+	return meta, nil
+}
+
+// iTunesLookupResponse は iTunes Search API の lookup のレスポンスです
+type iTunesLookupResponse struct {
+	ResultCount int `json:"resultCount"`
+	Results     []struct {
+		WrapperType    string `json:"wrapperType"`
+		TrackName      string `json:"trackName"`
+		CollectionName string `json:"collectionName"`
+		ArtistName     string `json:"artistName"`
+	} `json:"results"`
+}
+
+// fetchAppleMusic は iTunes Search API からトラック名・アルバム名・アーティスト名を取得します
+// iTunes Search API は ISRC / UPC を返さないため、Spotify の検索は名前で行います
+func (r *Resolver) fetchAppleMusic(ctx context.Context, link Link) (*metadata, error) {
+	query := url.Values{"id": {link.ID}}
+	if link.Country != "" {
+		query.Set("country", link.Country)
+	}
+
+	var resp iTunesLookupResponse
+	if err := r.getJSON(ctx, iTunesLookupURL+"?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResultCount == 0 || len(resp.Results) == 0 {
+		return nil, fmt.Errorf("%w: apple music %s %s", domain.ErrLinkNotMatched, link.EntityType, link.ID)
+	}
+
+	result := resp.Results[0]
+	if link.EntityType == spotify.EntityTrack {
+		return &metadata{Title: result.TrackName, Artist: result.ArtistName, Album: result.CollectionName}, nil
+	}
+	return &metadata{Title: result.CollectionName, Artist: result.ArtistName}, nil
+}
+
+// youTubeOEmbedResponse は YouTube の oEmbed のレスポンスです
+type youTubeOEmbedResponse struct {
+	Title      string `json:"title"`
+	AuthorName string `json:"author_name"`
+}
+
+// fetchYouTubeMusic は YouTube の oEmbed からタイトルとチャンネル名を取得します
+// YouTube Music の自動生成チャンネル名（「アーティスト名 - Topic」）はアーティスト名として扱います
+func (r *Resolver) fetchYouTubeMusic(ctx context.Context, link Link) (*metadata, error) {
+	target := "https://www.youtube.com/watch?v=" + link.ID
+	if link.EntityType == spotify.EntityAlbum {
+		target = "https://www.youtube.com/playlist?list=" + link.ID
+	}
+	query := url.Values{"url": {target}, "format": {"json"}}
+
+	var resp youTubeOEmbedResponse
+	if err := r.getJSON(ctx, youTubeOEmbedURL+"?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	artist := strings.TrimSuffix(resp.AuthorName, " - Topic")
+	title := resp.Title
+	if link.EntityType == spotify.EntityAlbum {
+		// アルバムのプレイリストのタイトルは「Album - アルバム名」
+		title = strings.TrimPrefix(title, "Album - ")
+	}
+	return &metadata{Title: title, Artist: artist}, nil
+}
+
+// getJSON は GET リクエストのJSONレスポンスを v にデコードします
+// 404 などのクライアントエラーは対象が存在しないものとして domain.ErrLinkNotMatched を返します
+func (r *Resolver) getJSON(ctx context.Context, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	err = lookup.GetJSON(r.client, req, v)
+	var statusErr *lookup.StatusError
+	if errors.As(err, &statusErr) && statusErr.Status >= 400 && statusErr.Status < 500 {
+		return fmt.Errorf("%w: %v", domain.ErrLinkNotMatched, err)
+	}
+	return err
+}
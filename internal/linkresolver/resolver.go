@@ -0,0 +1,226 @@
+package linkresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/lookup"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+	"github.com/t1nyb0x/jamberry/internal/ttlcache"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTimeout は他サービスのリンク1件の解決（情報の取得と Spotify の検索）のタイムアウトのデフォルト値です
+	DefaultTimeout = 10 * time.Second
+	// DefaultCacheTTL は解決結果をキャッシュする期間のデフォルト値です
+	DefaultCacheTTL = 24 * time.Hour
+	// DefaultCacheMaxEntries はキャッシュに保持するリンクの最大数のデフォルト値です
+	DefaultCacheMaxEntries = 1000
+)
+
+// ErrUnsupportedLink は解決の対象のリンク（短縮URL・他サービスのリンク）ではないことを表します
+var ErrUnsupportedLink = errors.New("unsupported link")
+
+// HTTPClient は他サービスの公開APIの呼び出しに使うHTTPクライアントです
+// テストでは httptest のサーバーに接続するクライアントに差し替えます
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient は他サービスの公開APIの呼び出し用のHTTPクライアントを作成します
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+// Catalog は他サービスのリンクに対応する Spotify のトラック・アルバムを検索するリポジトリです
+type Catalog interface {
+	domain.ExternalIDRepository
+
+	// SearchTracks はトラックを検索します（ISRC / UPC がない場合の名前での検索に使います）
+	SearchTracks(ctx context.Context, query string) ([]domain.Track, error)
+}
+
+// Config は Resolver の設定です
+// 0以下の値はデフォルト値として扱います
+type Config struct {
+	Timeout         time.Duration
+	CacheTTL        time.Duration
+	CacheMaxEntries int
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完した設定を返します
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = DefaultCacheTTL
+	}
+	if c.CacheMaxEntries <= 0 {
+		c.CacheMaxEntries = DefaultCacheMaxEntries
+	}
+	return c
+}
+
+// Resolver は Apple Music・YouTube Music・Deezer のリンクを対応する Spotify のURLに解決します
+// domain.LinkResolver インターフェースを実装します
+// Spotify の短縮URLは shortLinks に委譲し、他サービスのリンクは ISRC / UPC（取得できない場合は名前）で Spotify を検索します
+type Resolver struct {
+	shortLinks domain.LinkResolver
+	client     HTTPClient
+	catalog    Catalog
+	cfg        Config
+	now        func() time.Time
+	group      singleflight.Group
+	cache      *ttlcache.Cache[string]
+}
+
+// NewResolver は新しい Resolver を作成します
+// shortLinks が nil の場合、短縮URLは解決しません
+func NewResolver(shortLinks domain.LinkResolver, client HTTPClient, catalog Catalog, cfg Config) *Resolver {
+	cfg = cfg.withDefaults()
+	return &Resolver{
+		shortLinks: shortLinks,
+		client:     client,
+		catalog:    catalog,
+		cfg:        cfg,
+		now:        time.Now,
+		cache:      ttlcache.New[string](cfg.CacheTTL, cfg.CacheMaxEntries),
+	}
+}
+
+// インターフェース実装の確認
+var _ domain.LinkResolver = (*Resolver)(nil)
+
+// ResolveLink はリンクに対応する Spotify のURLを返します
+// 対応する Spotify のトラック・アルバムが見つからない場合は domain.ErrLinkNotMatched を返します
+func (r *Resolver) ResolveLink(ctx context.Context, rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if spotify.IsShortLink(rawURL) && r.shortLinks != nil {
+		return r.shortLinks.ResolveLink(ctx, rawURL)
+	}
+
+	link, ok := Parse(rawURL)
+	if !ok {
+		return "", ErrUnsupportedLink
+	}
+
+	key := link.key()
+	if resolved, ok := r.cache.Get(key, r.now()); ok {
+		return resolved, nil
+	}
+
+	v, err, _ := r.group.Do(key, func() (any, error) {
+		// 呼び出し元のキャンセルで同じリンクを待つ他の呼び出しまで失敗しないよう、専用のタイムアウトで解決する
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.Timeout)
+		defer cancel()
+
+		start := time.Now()
+		resolved, err := r.resolve(ctx, link)
+		if err != nil {
+			slog.Warn("failed to resolve link", "platform", link.Platform, "type", link.EntityType, "id", link.ID, "duration", time.Since(start), "error", err)
+			return "", err
+		}
+		slog.Debug("link resolved", "platform", link.Platform, "type", link.EntityType, "id", link.ID, "resolved", resolved, "duration", time.Since(start))
+		r.cache.Set(key, resolved, r.now())
+		return resolved, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// resolve は他サービスからリンクの情報を取得し、対応する Spotify のURLを検索します
+func (r *Resolver) resolve(ctx context.Context, link Link) (string, error) {
+	meta, err := r.fetchMetadata(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	if meta.Title == "" {
+		return "", fmt.Errorf("%w: no title for %s %s", domain.ErrLinkNotMatched, link.Platform, link.ID)
+	}
+
+	if link.EntityType == spotify.EntityAlbum {
+		return r.matchAlbum(ctx, meta)
+	}
+	return r.matchTrack(ctx, meta)
+}
+
+// matchTrack は ISRC、次に名前で Spotify のトラックを検索します
+func (r *Resolver) matchTrack(ctx context.Context, meta *metadata) (string, error) {
+	if meta.ISRC != "" {
+		track, err := r.catalog.FindTrackByISRC(ctx, meta.ISRC)
+		if err != nil {
+			return "", err
+		}
+		if track != nil && track.URL != "" {
+			return track.URL, nil
+		}
+	}
+
+	tracks, err := r.catalog.SearchTracks(ctx, searchQuery(meta.Title, meta.Artist))
+	if err != nil {
+		return "", err
+	}
+	for _, track := range tracks {
+		if meta.ISRC != "" && track.ISRC != nil && strings.EqualFold(*track.ISRC, meta.ISRC) {
+			return track.URL, nil
+		}
+	}
+	// 名前とアーティスト名が完全に一致するトラック、なければ両方が部分一致するトラックを採用する
+	i := lookup.Best(len(tracks), func(i int) lookup.Level {
+		return lookup.Match(meta.Title, meta.Artist, tracks[i].Name, tracks[i].Artists)
+	})
+	if i < 0 {
+		return "", fmt.Errorf("%w: track %q by %q", domain.ErrLinkNotMatched, meta.Title, meta.Artist)
+	}
+	return tracks[i].URL, nil
+}
+
+// matchAlbum は UPC、次に名前で Spotify のアルバムを検索します
+func (r *Resolver) matchAlbum(ctx context.Context, meta *metadata) (string, error) {
+	query := searchQuery(meta.Title, meta.Artist)
+	if meta.UPC != "" {
+		album, err := r.catalog.FindAlbumByUPC(ctx, meta.UPC, query)
+		if err != nil {
+			return "", err
+		}
+		if album != nil && album.URL != "" {
+			return album.URL, nil
+		}
+	}
+
+	tracks, err := r.catalog.SearchTracks(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	// 名前とアーティスト名が完全に一致するアルバム、なければ両方が部分一致するアルバムを採用する
+	i := lookup.Best(len(tracks), func(i int) lookup.Level {
+		album := tracks[i].Album
+		if album.URL == "" {
+			return lookup.None
+		}
+		artists := album.Artists
+		if len(artists) == 0 {
+			artists = tracks[i].Artists
+		}
+		return lookup.Match(meta.Title, meta.Artist, album.Name, artists)
+	})
+	if i < 0 {
+		return "", fmt.Errorf("%w: album %q by %q", domain.ErrLinkNotMatched, meta.Title, meta.Artist)
+	}
+	return tracks[i].Album.URL, nil
+}
+
+// searchQuery は名前での検索に使うクエリを返します
+func searchQuery(title, artist string) string {
+	return strings.TrimSpace(title + " " + artist)
+}
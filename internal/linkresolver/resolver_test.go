@@ -0,0 +1,202 @@
+package linkresolver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// rewriteTransport はリクエストのホストを保ったまま、接続先を httptest のサーバーに差し替えます
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// fakeCatalog はテスト用の Catalog です
+type fakeCatalog struct {
+	byISRC  map[string]*domain.Track
+	byUPC   map[string]*domain.AlbumDetail
+	results map[string][]domain.Track
+	queries []string
+}
+
+func (f *fakeCatalog) FindTrackByISRC(ctx context.Context, isrc string) (*domain.Track, error) {
+	return f.byISRC[isrc], nil
+}
+
+func (f *fakeCatalog) FindAlbumByUPC(ctx context.Context, upc, query string) (*domain.AlbumDetail, error) {
+	return f.byUPC[upc], nil
+}
+
+func (f *fakeCatalog) SearchTracks(ctx context.Context, query string) ([]domain.Track, error) {
+	f.queries = append(f.queries, query)
+	return f.results[query], nil
+}
+
+// fakeShortLinks はテスト用の短縮URLの LinkResolver です
+type fakeShortLinks struct{}
+
+func (fakeShortLinks) ResolveLink(ctx context.Context, shortURL string) (string, error) {
+	return "https://open.spotify.com/track/short", nil
+}
+
+// newTestResolver は各サービスの公開APIを模擬するサーバーに接続する Resolver を作成します
+func newTestResolver(t *testing.T, catalog Catalog) (*Resolver, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Host + r.URL.Path {
+		case "api.deezer.com/track/3135556":
+			_, _ = w.Write([]byte(`{"title":"Harder, Better, Faster, Stronger","isrc":"GBDUW0000059","artist":{"name":"Daft Punk"},"album":{"title":"Discovery"}}`))
+		case "api.deezer.com/album/302127":
+			_, _ = w.Write([]byte(`{"title":"Discovery","upc":"0724384960650","artist":{"name":"Daft Punk"}}`))
+		case "api.deezer.com/track/1":
+			_, _ = w.Write([]byte(`{"error":{"type":"DataException","message":"no data","code":800}}`))
+		case "itunes.apple.com/lookup":
+			if r.URL.Query().Get("id") == "1361329935" {
+				_, _ = w.Write([]byte(`{"resultCount":1,"results":[{"wrapperType":"track","trackName":"Lemon","collectionName":"Lemon","artistName":"米津玄師"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"resultCount":0,"results":[]}`))
+		case "www.youtube.com/oembed":
+			switch r.URL.Query().Get("url") {
+			case "https://www.youtube.com/watch?v=SX_ViT4Ra7k":
+				_, _ = w.Write([]byte(`{"title":"Lemon","author_name":"Kenshi Yonezu - Topic"}`))
+			case "https://www.youtube.com/watch?v=NoArtist000":
+				_, _ = w.Write([]byte(`{"title":"Lemon","author_name":""}`))
+			case "https://www.youtube.com/playlist?list=OLAK5uy_discovery":
+				_, _ = w.Write([]byte(`{"title":"Album - Discovery","author_name":"Daft Punk - Topic"}`))
+			default:
+				http.NotFound(w, r)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: rewriteTransport{target: target}}
+	return NewResolver(fakeShortLinks{}, client, catalog, Config{}), &requests
+}
+
+func newTestCatalog() *fakeCatalog {
+	discovery := domain.Album{Name: "Discovery", URL: "https://open.spotify.com/album/discovery", Artists: []domain.Artist{{Name: "Daft Punk"}}}
+	return &fakeCatalog{
+		byISRC: map[string]*domain.Track{
+			"GBDUW0000059": {Name: "Harder, Better, Faster, Stronger", URL: "https://open.spotify.com/track/hbfs"},
+		},
+		byUPC: map[string]*domain.AlbumDetail{
+			"0724384960650": {Name: "Discovery", URL: "https://open.spotify.com/album/discovery"},
+		},
+		results: map[string][]domain.Track{
+			"Lemon 米津玄師": {
+				{Name: "Lemon Tree", URL: "https://open.spotify.com/track/other", Artists: []domain.Artist{{Name: "Fools Garden"}}},
+				{Name: "Lemon (Instrumental)", URL: "https://open.spotify.com/track/instrumental", Artists: []domain.Artist{{Name: "米津玄師"}}},
+				{Name: "Lemon", URL: "https://open.spotify.com/track/lemon", Artists: []domain.Artist{{Name: "米津玄師"}}},
+			},
+			"Lemon": {
+				{Name: "Lemon", URL: "https://open.spotify.com/track/lemon", Artists: []domain.Artist{{Name: "米津玄師"}}},
+			},
+			"Lemon Kenshi Yonezu": {
+				{Name: "Lemon", URL: "https://open.spotify.com/track/lemon", Artists: []domain.Artist{{Name: "Kenshi Yonezu"}}},
+			},
+			"Discovery Daft Punk": {
+				{Name: "One More Time", Album: discovery, Artists: discovery.Artists},
+			},
+		},
+	}
+}
+
+func TestResolver_ResolveLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "deezer track by ISRC", input: "https://www.deezer.com/ja/track/3135556", want: "https://open.spotify.com/track/hbfs"},
+		{name: "deezer album by UPC", input: "https://www.deezer.com/album/302127", want: "https://open.spotify.com/album/discovery"},
+		{name: "apple music track by name", input: "https://music.apple.com/jp/album/lemon/1361329931?i=1361329935", want: "https://open.spotify.com/track/lemon"},
+		{name: "youtube music track by name", input: "https://music.youtube.com/watch?v=SX_ViT4Ra7k", want: "https://open.spotify.com/track/lemon"},
+		{name: "youtube music album by name", input: "https://music.youtube.com/playlist?list=OLAK5uy_discovery", want: "https://open.spotify.com/album/discovery"},
+		{name: "spotify short link", input: "https://spotify.link/AbCdEf123", want: "https://open.spotify.com/track/short"},
+		{name: "deezer not found", input: "https://www.deezer.com/track/1", wantErr: domain.ErrLinkNotMatched},
+		{name: "apple music not found", input: "https://music.apple.com/jp/song/999", wantErr: domain.ErrLinkNotMatched},
+		{name: "youtube unavailable", input: "https://music.youtube.com/watch?v=AAAAAAAAAAA", wantErr: domain.ErrLinkNotMatched},
+		{name: "youtube without artist", input: "https://music.youtube.com/watch?v=NoArtist000", wantErr: domain.ErrLinkNotMatched},
+		{name: "unsupported", input: "https://example.com/track/1", wantErr: ErrUnsupportedLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, _ := newTestResolver(t, newTestCatalog())
+			got, err := resolver.ResolveLink(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ResolveLink() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveLink() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_NoMatch(t *testing.T) {
+	catalog := newTestCatalog()
+	catalog.byISRC = nil
+	resolver, _ := newTestResolver(t, catalog)
+
+	// ISRC で見つからない場合は名前で検索し、名前の一致しない結果は採用しない
+	_, err := resolver.ResolveLink(context.Background(), "https://www.deezer.com/track/3135556")
+	if !errors.Is(err, domain.ErrLinkNotMatched) {
+		t.Fatalf("ResolveLink() error = %v, want %v", err, domain.ErrLinkNotMatched)
+	}
+	if len(catalog.queries) != 1 || catalog.queries[0] != "Harder, Better, Faster, Stronger Daft Punk" {
+		t.Errorf("search queries = %q, want the title and artist", catalog.queries)
+	}
+}
+
+func TestResolver_Cache(t *testing.T) {
+	resolver, requests := newTestResolver(t, newTestCatalog())
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := resolver.ResolveLink(ctx, "https://www.deezer.com/track/3135556"); err != nil {
+			t.Fatalf("ResolveLink() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1 (cached)", got)
+	}
+
+	// 失敗はキャッシュしない
+	for range 2 {
+		_, _ = resolver.ResolveLink(ctx, "https://www.deezer.com/track/1")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 (failures not cached)", got)
+	}
+}
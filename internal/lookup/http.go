@@ -0,0 +1,44 @@
+package lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxResponseBytes は読み込むレスポンスボディの最大バイト数です
+const MaxResponseBytes = 1 << 20
+
+// HTTPClient は他サービスの公開APIの呼び出しに使うHTTPクライアントです
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// StatusError はステータス200以外の応答を表します
+type StatusError struct {
+	Status int
+	Host   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s", e.Status, e.Host)
+}
+
+// GetJSON はリクエストを送信し、JSONレスポンスを v にデコードします
+// ステータス200以外の応答の場合は *StatusError を返します
+func GetJSON(client HTTPClient, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{Status: resp.StatusCode, Host: req.URL.Host}
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxResponseBytes)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", req.URL.Host, err)
+	}
+	return nil
+}
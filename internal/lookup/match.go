@@ -0,0 +1,78 @@
+package lookup
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// Level は他サービスと Spotify のトラック・アルバムの名前の一致の度合いです
+type Level int
+
+const (
+	// None は一致しないことを表します
+	None Level = iota
+	// Partial は一方が他方を含むことを表します（「(Remastered)」「(Official Video)」などの付加がある場合）
+	Partial
+	// Exact は表記揺れ（記号・空白・大文字小文字）を除いて同じことを表します
+	Exact
+)
+
+// Match は名前・アーティスト名と、照合先のトラック・アルバムの名前・アーティストの一致の度合いを返します
+// 名前とアーティスト名の両方が完全に一致する場合は Exact、両方が少なくとも部分一致する場合は Partial を返します
+// アーティスト名が分からない場合は取り違えを避けるため None を返します
+func Match(name, artist, otherName string, otherArtists []domain.Artist) Level {
+	nameLevel := CompareNames(name, otherName)
+	if nameLevel == None {
+		return None
+	}
+
+	artistLevel := None
+	for _, a := range otherArtists {
+		artistLevel = max(artistLevel, CompareNames(artist, a.Name))
+	}
+	return min(nameLevel, artistLevel)
+}
+
+// Best は n 件の候補のうち、完全に一致する候補、なければ部分一致する最初の候補のインデックスを返します
+// level は i 番目の候補の一致の度合いを返します。一致する候補がない場合は -1 を返します
+func Best(n int, level func(i int) Level) int {
+	partial := -1
+	for i := range n {
+		switch level(i) {
+		case Exact:
+			return i
+		case Partial:
+			if partial < 0 {
+				partial = i
+			}
+		}
+	}
+	return partial
+}
+
+// CompareNames は表記揺れを除いた2つの名前の一致の度合いを返します
+func CompareNames(a, b string) Level {
+	a, b = NormalizeName(a), NormalizeName(b)
+	switch {
+	case a == "" || b == "":
+		return None
+	case a == b:
+		return Exact
+	case strings.Contains(a, b) || strings.Contains(b, a):
+		return Partial
+	}
+	return None
+}
+
+// NormalizeName は名前を小文字にし、文字と数字以外を取り除きます
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package lookup
+
+import (
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestMatch(t *testing.T) {
+	artists := []domain.Artist{{Name: "Daft Punk"}}
+
+	tests := []struct {
+		name    string
+		artist  string
+		artists []domain.Artist
+		want    Level
+	}{
+		{name: "Harder, Better, Faster, Stronger", artist: "Daft Punk", artists: artists, want: Exact},
+		{name: "harder better faster stronger", artist: "daft punk", artists: artists, want: Exact},
+		{name: "Harder, Better, Faster, Stronger (Remastered)", artist: "Daft Punk", artists: artists, want: Partial},
+		{name: "Harder, Better, Faster, Stronger", artist: "Daft Punk - Topic", artists: artists, want: Partial},
+		{name: "Harder, Better, Faster, Stronger", artist: "Cover Band", artists: artists, want: None},
+		{name: "One More Time", artist: "Daft Punk", artists: artists, want: None},
+		{name: "Harder, Better, Faster, Stronger", artist: "", artists: artists, want: None},
+		{name: "Harder, Better, Faster, Stronger", artist: "Daft Punk", want: None},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.name, tt.artist, "Harder, Better, Faster, Stronger", tt.artists); got != tt.want {
+			t.Errorf("Match(%q, %q, %v) = %v, want %v", tt.name, tt.artist, tt.artists, got, tt.want)
+		}
+	}
+}
+
+func TestBest(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []Level
+		want   int
+	}{
+		{name: "exact preferred over earlier partial", levels: []Level{None, Partial, Exact}, want: 2},
+		{name: "first partial", levels: []Level{None, Partial, Partial}, want: 1},
+		{name: "no match", levels: []Level{None, None}, want: -1},
+		{name: "empty", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Best(len(tt.levels), func(i int) Level { return tt.levels[i] }); got != tt.want {
+				t.Errorf("Best() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Level
+	}{
+		{"Harder, Better, Faster, Stronger", "harder better faster stronger", Exact},
+		{"Lemon", "Lemon (Official Video)", Partial},
+		{"Lemon", "Flamingo", None},
+		{"", "Lemon", None},
+	}
+
+	for _, tt := range tests {
+		if got := CompareNames(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareNames(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "jamberry"
+
+// キャッシュ層のラベル値
+const (
+	CacheLayerL1 = "l1"
+	CacheLayerL2 = "l2"
+)
+
+// エンティティキャッシュの結果のラベル値
+const (
+	EntityCacheHit    = "hit"
+	EntityCacheMiss   = "miss"
+	EntityCacheShared = "shared" // 同時に発生した同一キーのミスを1回の取得にまとめた
+	EntityCacheStale  = "stale"  // tracktaste 障害時に古いデータを返した
+)
+
+var (
+	// registry はjamberry専用のメトリクスレジストリです（グローバルレジストリとの衝突を避ける）
+	registry = prometheus.NewRegistry()
+
+	commandsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "commands_total",
+		Help:      "Number of slash commands received, by command and subcommand.",
+	}, []string{"command", "subcommand"})
+
+	tracktasteRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tracktaste_request_duration_seconds",
+		Help:      "Latency of tracktaste API requests, by endpoint path.",
+		// v2 recommend は数秒かかるため上限を長めに取る
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30},
+	}, []string{"endpoint"})
+
+	tracktasteErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tracktaste_errors_total",
+		Help:      "Number of failed tracktaste API requests, by error code.",
+	}, []string{"code"})
+
+	tracktasteRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tracktaste_retries_total",
+		Help:      "Number of retried tracktaste API requests, by reason.",
+	}, []string{"reason"})
+
+	tracktasteCircuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tracktaste_circuit_state",
+		Help:      "State of the tracktaste circuit breaker by endpoint family (0=closed, 1=open, 2=half-open).",
+	}, []string{"family"})
+
+	cacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of pagination cache lookups, by layer and result (hit/miss).",
+	}, []string{"layer", "result"})
+
+	cacheEvictionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_l1_evictions_total",
+		Help:      "Number of entries removed from the in-memory L1 cache, by reason (max_entries/max_bytes/expired).",
+	}, []string{"reason"})
+
+	cacheL1Entries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_l1_entries",
+		Help:      "Current number of entries in the in-memory L1 cache.",
+	})
+
+	cacheL1Bytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_l1_bytes",
+		Help:      "Approximate size in bytes of the in-memory L1 cache.",
+	})
+
+	entityCacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "entity_cache_requests_total",
+		Help:      "Number of tracktaste entity cache lookups, by entity type and result (hit/miss/shared/stale).",
+	}, []string{"entity", "result"})
+
+	rateLimitRejectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ratelimit_rejections_total",
+		Help:      "Number of requests rejected by the rate limiter, by exhausted scope (user/guild).",
+	}, []string{"scope"})
+)
+
+func init() {
+	registry.MustRegister(
+		commandsTotal,
+		tracktasteRequestDuration,
+		tracktasteErrorsTotal,
+		tracktasteRetriesTotal,
+		tracktasteCircuitState,
+		cacheRequestsTotal,
+		cacheEvictionsTotal,
+		cacheL1Entries,
+		cacheL1Bytes,
+		entityCacheRequestsTotal,
+		rateLimitRejectionsTotal,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler はPrometheusテキスト形式でメトリクスを返すHTTPハンドラーを返します
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// IncCommand はコマンドの受信数をカウントします
+func IncCommand(command, subcommand string) {
+	commandsTotal.WithLabelValues(command, subcommand).Inc()
+}
+
+// ObserveTrackTasteRequest はtracktaste APIのレイテンシを記録します
+// endpoint にはクエリ文字列を含まないパスを渡してください（ラベルの種類数を抑えるため）
+func ObserveTrackTasteRequest(endpoint string, d time.Duration) {
+	tracktasteRequestDuration.WithLabelValues(endpoint).Observe(d.Seconds())
+}
+
+// IncTrackTasteError はtracktaste APIのエラー数をエラーコードごとにカウントします
+func IncTrackTasteError(code string) {
+	tracktasteErrorsTotal.WithLabelValues(code).Inc()
+}
+
+// IncTrackTasteRetry はtracktaste APIのリトライ数を理由（CONNECTION_ERROR / HTTP_<status>）ごとにカウントします
+func IncTrackTasteRetry(reason string) {
+	tracktasteRetriesTotal.WithLabelValues(reason).Inc()
+}
+
+// SetTrackTasteCircuitState はtracktasteのサーキットブレーカーの状態を記録します
+func SetTrackTasteCircuitState(family string, state int) {
+	tracktasteCircuitState.WithLabelValues(family).Set(float64(state))
+}
+
+// ObserveCache はキャッシュ参照のヒット/ミスを記録します
+func ObserveCache(layer string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestsTotal.WithLabelValues(layer, result).Inc()
+}
+
+// AddCacheEvictions はL1キャッシュから取り除かれたエントリ数を理由ごとにカウントします
+func AddCacheEvictions(reason string, n int) {
+	cacheEvictionsTotal.WithLabelValues(reason).Add(float64(n))
+}
+
+// SetL1Usage はL1キャッシュの現在のエントリ数とデータ量（概算）を記録します
+func SetL1Usage(entries int, bytes int64) {
+	cacheL1Entries.Set(float64(entries))
+	cacheL1Bytes.Set(float64(bytes))
+}
+
+// ObserveEntityCache はエンティティキャッシュの参照結果（hit/miss/shared）をエンティティ種別ごとにカウントします
+func ObserveEntityCache(entity, result string) {
+	entityCacheRequestsTotal.WithLabelValues(entity, result).Inc()
+}
+
+// IncRateLimitRejection はレートリミットによる拒否数を上限に達した枠（user/guild）ごとにカウントします
+func IncRateLimitRejection(scope string) {
+	rateLimitRejectionsTotal.WithLabelValues(scope).Inc()
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCounters(t *testing.T) {
+	tests := []struct {
+		name   string
+		record func()
+		value  func() float64
+	}{
+		{
+			name:   "command",
+			record: func() { IncCommand("jam", "track") },
+			value:  func() float64 { return testutil.ToFloat64(commandsTotal.WithLabelValues("jam", "track")) },
+		},
+		{
+			name:   "tracktaste error",
+			record: func() { IncTrackTasteError("INVALID_URL") },
+			value:  func() float64 { return testutil.ToFloat64(tracktasteErrorsTotal.WithLabelValues("INVALID_URL")) },
+		},
+		{
+			name:   "tracktaste retry",
+			record: func() { IncTrackTasteRetry("HTTP_503") },
+			value:  func() float64 { return testutil.ToFloat64(tracktasteRetriesTotal.WithLabelValues("HTTP_503")) },
+		},
+		{
+			name:   "cache hit",
+			record: func() { ObserveCache(CacheLayerL1, true) },
+			value:  func() float64 { return testutil.ToFloat64(cacheRequestsTotal.WithLabelValues(CacheLayerL1, "hit")) },
+		},
+		{
+			name:   "cache miss",
+			record: func() { ObserveCache(CacheLayerL2, false) },
+			value:  func() float64 { return testutil.ToFloat64(cacheRequestsTotal.WithLabelValues(CacheLayerL2, "miss")) },
+		},
+		{
+			name:   "cache eviction",
+			record: func() { AddCacheEvictions("max_entries", 1) },
+			value:  func() float64 { return testutil.ToFloat64(cacheEvictionsTotal.WithLabelValues("max_entries")) },
+		},
+		{
+			name:   "entity cache",
+			record: func() { ObserveEntityCache("track", "shared") },
+			value:  func() float64 { return testutil.ToFloat64(entityCacheRequestsTotal.WithLabelValues("track", "shared")) },
+		},
+		{
+			name:   "rate limit rejection",
+			record: func() { IncRateLimitRejection("user") },
+			value:  func() float64 { return testutil.ToFloat64(rateLimitRejectionsTotal.WithLabelValues("user")) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.value()
+			tt.record()
+			if got := tt.value(); got != before+1 {
+				t.Errorf("counter = %v, want %v", got, before+1)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	IncCommand("help", "")
+	ObserveTrackTasteRequest("/v1/track/fetch", 120*time.Millisecond)
+
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET /metrics error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, want := range []string{
+		`jamberry_commands_total{command="help",subcommand=""}`,
+		`jamberry_tracktaste_request_duration_seconds_bucket{endpoint="/v1/track/fetch",le="0.25"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+const (
+	// MaxAutocompleteChoices はオートコンプリートで返せる候補の最大数です（Discord の制限）
+	MaxAutocompleteChoices = 25
+	// maxChoiceLength は候補の表示名と値の最大文字数です（Discord の制限）
+	maxChoiceLength = 100
+)
+
+// BuildTrackChoices はトラックの検索結果からオートコンプリートの候補を構築します
+// 表示名は「トラック名 — アーティスト名」、値はトラックのURLです
+func BuildTrackChoices(tracks []domain.Track) []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, min(len(tracks), MaxAutocompleteChoices))
+	for _, track := range tracks {
+		if len(choices) >= MaxAutocompleteChoices {
+			break
+		}
+		// URLが上限を超える場合は選択しても解決できないため候補にしない
+		if track.URL == "" || len(track.URL) > maxChoiceLength {
+			continue
+		}
+
+		name := track.Name
+		if artists := JoinArtistNames(track.Artists); artists != "" {
+			name = fmt.Sprintf("%s — %s", track.Name, artists)
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateRunes(name, maxChoiceLength),
+			Value: track.URL,
+		})
+	}
+	return choices
+}
+
+// truncateRunes は文字列を指定した文字数に収まるよう末尾を「…」で切り詰めます
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+func TestBuildTrackChoices(t *testing.T) {
+	tracks := []domain.Track{
+		{Name: "Song", URL: "https://open.spotify.com/track/1", Artists: []domain.Artist{{Name: "A"}, {Name: "B"}}},
+		{Name: "No Artist", URL: "https://open.spotify.com/track/2"},
+		{Name: "No URL"},
+		{Name: strings.Repeat("長", 120), URL: "https://open.spotify.com/track/3", Artists: []domain.Artist{{Name: "C"}}},
+	}
+
+	choices := BuildTrackChoices(tracks)
+	if len(choices) != 3 {
+		t.Fatalf("len(choices) = %d, want 3", len(choices))
+	}
+	if choices[0].Name != "Song — A, B" || choices[0].Value != "https://open.spotify.com/track/1" {
+		t.Errorf("choices[0] = %q => %v", choices[0].Name, choices[0].Value)
+	}
+	if choices[1].Name != "No Artist" {
+		t.Errorf("choices[1].Name = %q, want No Artist", choices[1].Name)
+	}
+	if n := utf8.RuneCountInString(choices[2].Name); n != maxChoiceLength {
+		t.Errorf("truncated name length = %d, want %d", n, maxChoiceLength)
+	}
+	if !strings.HasSuffix(choices[2].Name, "…") {
+		t.Errorf("truncated name = %q, want suffix …", choices[2].Name)
+	}
+}
+
+func TestBuildTrackChoices_Limit(t *testing.T) {
+	tracks := make([]domain.Track, MaxAutocompleteChoices+5)
+	for i := range tracks {
+		tracks[i] = domain.Track{Name: fmt.Sprintf("Song %d", i), URL: fmt.Sprintf("https://open.spotify.com/track/%d", i)}
+	}
+
+	if got := len(BuildTrackChoices(tracks)); got != MaxAutocompleteChoices {
+		t.Errorf("len(choices) = %d, want %d", got, MaxAutocompleteChoices)
+	}
+	if got := BuildTrackChoices(nil); len(got) != 0 {
+		t.Errorf("BuildTrackChoices(nil) = %v, want empty", got)
+	}
+}
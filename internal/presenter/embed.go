@@ -3,13 +3,67 @@ package presenter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
+// StaleFooterText は tracktaste 障害時に古いキャッシュデータを表示していることを示すフッターです
+// Embed のタイムスタンプ（取得日時）と並べて表示されます
+const StaleFooterText = "⚠️ TrackTaste に接続できないため、次の日時に取得したキャッシュデータを表示しています"
+
+// maxFieldValueLength は Embed フィールドの値の最大文字数です（Discord の制限）
+const maxFieldValueLength = 1024
+
+// applyStaleFooter は古いキャッシュデータの場合にフッターと取得日時を設定します
+func applyStaleFooter(loc i18n.Locale, embed *discordgo.MessageEmbed, cachedAt time.Time) {
+	if cachedAt.IsZero() {
+		return
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: i18n.T(loc, StaleFooterText)}
+	embed.Timestamp = cachedAt.Format(time.RFC3339)
+}
+
+// formatLinks はリンク欄の値を組み立てます
+// 他のサービスのリンクは2行目に、一致を確認していない検索結果へのリンクは3行目に分けてまとめ、
+// フィールドの文字数上限を超える分は省略します
+func formatLinks(loc i18n.Locale, spotifyURL string, links []domain.ServiceLink) string {
+	value := i18n.Sprintf(loc, "[🔗 Spotify で開く](%s)", spotifyURL)
+
+	var others, searches []string
+	length := len(value)
+	for _, link := range links {
+		line, prefix := &others, "\n🎧 "
+		label := link.Service
+		if link.Search {
+			line, prefix = &searches, "\n🔍 "
+			label = i18n.Sprintf(loc, "%s で検索", link.Service)
+		}
+		item := fmt.Sprintf("[%s](%s)", label, link.URL)
+		sep := len(" ・ ")
+		if len(*line) == 0 {
+			sep = len(prefix)
+		}
+		if length+sep+len(item) > maxFieldValueLength {
+			break
+		}
+		length += sep + len(item)
+		*line = append(*line, item)
+	}
+	if len(others) > 0 {
+		value += "\n🎧 " + strings.Join(others, " ・ ")
+	}
+	if len(searches) > 0 {
+		value += "\n🔍 " + strings.Join(searches, " ・ ")
+	}
+	return value
+}
+
 // BuildTrackEmbed はトラック情報のEmbedを構築します
-func BuildTrackEmbed(track *domain.Track) *discordgo.MessageEmbed {
+// links は他のサービスで開くリンクで、リンク欄に Spotify のリンクと並べて表示します
+func BuildTrackEmbed(loc i18n.Locale, track *domain.Track, links []domain.ServiceLink) *discordgo.MessageEmbed {
 	title := "🎵 " + track.Name
 	if track.Explicit {
 		title += " 🔞"
@@ -21,23 +75,23 @@ func BuildTrackEmbed(track *domain.Track) *discordgo.MessageEmbed {
 		Color:       SpotifyGreen,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "アルバム",
+				Name:   i18n.T(loc, "アルバム"),
 				Value:  track.Album.Name,
 				Inline: true,
 			},
 			{
-				Name:   "再生時間",
+				Name:   i18n.T(loc, "再生時間"),
 				Value:  FormatDuration(track.DurationMs),
 				Inline: true,
 			},
 			{
-				Name:   "リリース日",
+				Name:   i18n.T(loc, "リリース日"),
 				Value:  track.Album.ReleaseDate,
 				Inline: true,
 			},
 			{
-				Name:   "リンク",
-				Value:  fmt.Sprintf("[🔗 Spotify で開く](%s)", track.URL),
+				Name:   i18n.T(loc, "リンク"),
+				Value:  formatLinks(loc, track.URL, links),
 				Inline: false,
 			},
 		},
@@ -46,7 +100,7 @@ func BuildTrackEmbed(track *domain.Track) *discordgo.MessageEmbed {
 	// 人気度（欠損時は省略）
 	if track.Popularity != nil {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "人気度",
+			Name:   i18n.T(loc, "人気度"),
 			Value:  fmt.Sprintf("%d", *track.Popularity),
 			Inline: true,
 		})
@@ -59,30 +113,32 @@ func BuildTrackEmbed(track *domain.Track) *discordgo.MessageEmbed {
 		}
 	}
 
+	applyStaleFooter(loc, embed, track.CachedAt)
+
 	return embed
 }
 
 // BuildArtistEmbed はアーティスト情報のEmbedを構築します
-func BuildArtistEmbed(artist *domain.ArtistDetail) *discordgo.MessageEmbed {
+func BuildArtistEmbed(loc i18n.Locale, artist *domain.ArtistDetail) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title: "🎤 " + artist.Name,
 		Color: SpotifyGreen,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "フォロワー",
+				Name:   i18n.T(loc, "フォロワー"),
 				Value:  artist.Followers,
 				Inline: true,
 			},
 			{
-				Name:   "リンク",
-				Value:  fmt.Sprintf("[🔗 Spotify で開く](%s)", artist.URL),
+				Name:   i18n.T(loc, "リンク"),
+				Value:  i18n.Sprintf(loc, "[🔗 Spotify で開く](%s)", artist.URL),
 				Inline: false,
 			},
 		},
 	}
 
 	// ジャンル（最大3件、欠損時は「なし」）
-	genreValue := "なし"
+	genreValue := i18n.T(loc, "なし")
 	if len(artist.Genres) > 0 {
 		genres := artist.Genres
 		if len(genres) > 3 {
@@ -92,7 +148,7 @@ func BuildArtistEmbed(artist *domain.ArtistDetail) *discordgo.MessageEmbed {
 	}
 	embed.Fields = append([]*discordgo.MessageEmbedField{
 		{
-			Name:   "ジャンル",
+			Name:   i18n.T(loc, "ジャンル"),
 			Value:  genreValue,
 			Inline: true,
 		},
@@ -101,7 +157,7 @@ func BuildArtistEmbed(artist *domain.ArtistDetail) *discordgo.MessageEmbed {
 	// 人気度（欠損時は省略）
 	if artist.Popularity != nil {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "人気度",
+			Name:   i18n.T(loc, "人気度"),
 			Value:  fmt.Sprintf("%d", *artist.Popularity),
 			Inline: true,
 		})
@@ -114,29 +170,32 @@ func BuildArtistEmbed(artist *domain.ArtistDetail) *discordgo.MessageEmbed {
 		}
 	}
 
+	applyStaleFooter(loc, embed, artist.CachedAt)
+
 	return embed
 }
 
 // BuildAlbumEmbed はアルバム情報のEmbedを構築します
-func BuildAlbumEmbed(album *domain.AlbumDetail) *discordgo.MessageEmbed {
+// links は他のサービスで開くリンクで、リンク欄に Spotify のリンクと並べて表示します
+func BuildAlbumEmbed(loc i18n.Locale, album *domain.AlbumDetail, links []domain.ServiceLink) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title:       "💿 " + album.Name,
 		Description: JoinArtistNames(album.Artists),
 		Color:       SpotifyGreen,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "リリース日",
+				Name:   i18n.T(loc, "リリース日"),
 				Value:  album.ReleaseDate,
 				Inline: true,
 			},
 			{
-				Name:   "トラック数",
-				Value:  fmt.Sprintf("%d 曲", len(album.Tracks)),
+				Name:   i18n.T(loc, "トラック数"),
+				Value:  i18n.Sprintf(loc, "%d 曲", len(album.Tracks)),
 				Inline: true,
 			},
 			{
-				Name:   "リンク",
-				Value:  fmt.Sprintf("[🔗 Spotify で開く](%s)", album.URL),
+				Name:   i18n.T(loc, "リンク"),
+				Value:  formatLinks(loc, album.URL, links),
 				Inline: false,
 			},
 		},
@@ -145,7 +204,7 @@ func BuildAlbumEmbed(album *domain.AlbumDetail) *discordgo.MessageEmbed {
 	// 人気度（欠損時は省略）
 	if album.Popularity != nil {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "人気度",
+			Name:   i18n.T(loc, "人気度"),
 			Value:  fmt.Sprintf("%d", *album.Popularity),
 			Inline: true,
 		})
@@ -162,7 +221,7 @@ func BuildAlbumEmbed(album *domain.AlbumDetail) *discordgo.MessageEmbed {
 			trackList = append(trackList, fmt.Sprintf("%d. %s", i+1, t.Name))
 		}
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:  "収録曲",
+			Name:  i18n.T(loc, "収録曲"),
 			Value: strings.Join(trackList, "\n"),
 		})
 	}
@@ -174,5 +233,7 @@ func BuildAlbumEmbed(album *domain.AlbumDetail) *discordgo.MessageEmbed {
 		}
 	}
 
+	applyStaleFooter(loc, embed, album.CachedAt)
+
 	return embed
 }
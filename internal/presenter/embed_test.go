@@ -3,8 +3,12 @@ package presenter
 import (
 	"strings"
 	"testing"
+	"time"
+	"unicode"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
 // extractURLFromMarkdown extracts URL from markdown link format: [text](URL)
@@ -143,7 +147,7 @@ func TestBuildTrackEmbed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			embed := BuildTrackEmbed(tt.track)
+			embed := BuildTrackEmbed(i18n.Japanese, tt.track, nil)
 			result := &EmbedResult{
 				Title:         embed.Title,
 				Description:   embed.Description,
@@ -256,7 +260,7 @@ func TestBuildArtistEmbed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			embed := BuildArtistEmbed(tt.artist)
+			embed := BuildArtistEmbed(i18n.Japanese, tt.artist)
 			result := &EmbedResult{
 				Title:         embed.Title,
 				HasPopularity: false,
@@ -407,7 +411,7 @@ func TestBuildAlbumEmbed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			embed := BuildAlbumEmbed(tt.album)
+			embed := BuildAlbumEmbed(i18n.Japanese, tt.album, nil)
 			result := &EmbedResult{
 				Title:         embed.Title,
 				Description:   embed.Description,
@@ -432,6 +436,51 @@ func TestBuildAlbumEmbed(t *testing.T) {
 	}
 }
 
+func TestBuildEmbed_StaleFooter(t *testing.T) {
+	cachedAt := time.Date(2025, 1, 15, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		build func(cachedAt time.Time) *discordgo.MessageEmbed
+	}{
+		{
+			name: "track",
+			build: func(cachedAt time.Time) *discordgo.MessageEmbed {
+				return BuildTrackEmbed(i18n.Japanese, &domain.Track{Name: "Test Track", CachedAt: cachedAt}, nil)
+			},
+		},
+		{
+			name: "artist",
+			build: func(cachedAt time.Time) *discordgo.MessageEmbed {
+				return BuildArtistEmbed(i18n.Japanese, &domain.ArtistDetail{Name: "Test Artist", CachedAt: cachedAt})
+			},
+		},
+		{
+			name: "album",
+			build: func(cachedAt time.Time) *discordgo.MessageEmbed {
+				return BuildAlbumEmbed(i18n.Japanese, &domain.AlbumDetail{Name: "Test Album", CachedAt: cachedAt}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fresh := tt.build(time.Time{})
+			if fresh.Footer != nil || fresh.Timestamp != "" {
+				t.Errorf("fresh data should not have stale footer, got footer=%v timestamp=%q", fresh.Footer, fresh.Timestamp)
+			}
+
+			stale := tt.build(cachedAt)
+			if stale.Footer == nil || stale.Footer.Text != StaleFooterText {
+				t.Errorf("Footer = %v, want %q", stale.Footer, StaleFooterText)
+			}
+			if want := "2025-01-15T12:34:56Z"; stale.Timestamp != want {
+				t.Errorf("Timestamp = %q, want %q", stale.Timestamp, want)
+			}
+		})
+	}
+}
+
 // EmbedResult はテスト用のEmbed結果を表します
 type EmbedResult struct {
 	Title         string
@@ -445,3 +494,127 @@ type EmbedResult struct {
 	HasTracks     bool
 	TrackCount    int
 }
+
+// hasJapanese は文字列に日本語（漢字・ひらがな・カタカナ）が含まれるかを返します
+func hasJapanese(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			return true
+		}
+	}
+	return false
+}
+
+// assertNoJapanese は Embed の表示テキストに翻訳漏れ（日本語）がないことを検証します
+func assertNoJapanese(t *testing.T, embed *discordgo.MessageEmbed) {
+	t.Helper()
+	texts := []string{embed.Title, embed.Description}
+	for _, field := range embed.Fields {
+		texts = append(texts, field.Name, field.Value)
+	}
+	if embed.Footer != nil {
+		texts = append(texts, embed.Footer.Text)
+	}
+	for _, text := range texts {
+		if hasJapanese(text) {
+			t.Errorf("embed text should be translated, got %q", text)
+		}
+	}
+}
+
+func TestBuildEmbed_English(t *testing.T) {
+	popularity := 80
+	cachedAt := time.Date(2025, 1, 15, 12, 34, 56, 0, time.UTC)
+
+	track := BuildTrackEmbed(i18n.English, &domain.Track{
+		Name:       "Test Track",
+		Artists:    []domain.Artist{{Name: "Test Artist"}},
+		Album:      domain.Album{Name: "Test Album", ReleaseDate: "2025-01-01"},
+		DurationMs: 180000,
+		Popularity: &popularity,
+		URL:        "https://open.spotify.com/track/1",
+		CachedAt:   cachedAt,
+	}, nil)
+	assertNoJapanese(t, track)
+	if track.Fields[0].Name != "Album" {
+		t.Errorf("Fields[0].Name = %q, want Album", track.Fields[0].Name)
+	}
+	if !strings.Contains(track.Fields[3].Value, "Open in Spotify") {
+		t.Errorf("link field = %q, want English link text", track.Fields[3].Value)
+	}
+
+	artist := BuildArtistEmbed(i18n.English, &domain.ArtistDetail{
+		Name:       "Test Artist",
+		Followers:  "1,000",
+		Popularity: &popularity,
+		CachedAt:   cachedAt,
+	})
+	assertNoJapanese(t, artist)
+	if artist.Fields[0].Name != "Genres" || artist.Fields[0].Value != "None" {
+		t.Errorf("genre field = %q: %q, want Genres: None", artist.Fields[0].Name, artist.Fields[0].Value)
+	}
+
+	album := BuildAlbumEmbed(i18n.English, &domain.AlbumDetail{
+		Name:        "Test Album",
+		ReleaseDate: "2025-01-01",
+		Tracks:      []domain.AlbumTrack{{Name: "Track 1"}, {Name: "Track 2"}},
+		Popularity:  &popularity,
+		CachedAt:    cachedAt,
+	}, nil)
+	assertNoJapanese(t, album)
+	if album.Fields[1].Value != "2 tracks" {
+		t.Errorf("track count = %q, want 2 tracks", album.Fields[1].Value)
+	}
+}
+
+func TestBuildEmbed_ServiceLinks(t *testing.T) {
+	links := []domain.ServiceLink{
+		{Service: "Apple Music", URL: "https://music.apple.com/jp/album/1"},
+		{Service: "Deezer", URL: "https://www.deezer.com/album/2"},
+	}
+
+	tests := []struct {
+		name  string
+		links []domain.ServiceLink
+		want  string
+	}{
+		{
+			name: "spotify only",
+			want: "[🔗 Spotify で開く](https://open.spotify.com/album/1)",
+		},
+		{
+			name:  "with other services",
+			links: links,
+			want:  "[🔗 Spotify で開く](https://open.spotify.com/album/1)\n🎧 [Apple Music](https://music.apple.com/jp/album/1) ・ [Deezer](https://www.deezer.com/album/2)",
+		},
+		{
+			name:  "search links on their own line",
+			links: []domain.ServiceLink{links[0], {Service: "YouTube Music", URL: "https://music.youtube.com/search?q=x", Search: true}, links[1]},
+			want: "[🔗 Spotify で開く](https://open.spotify.com/album/1)\n🎧 [Apple Music](https://music.apple.com/jp/album/1) ・ [Deezer](https://www.deezer.com/album/2)" +
+				"\n🔍 [YouTube Music で検索](https://music.youtube.com/search?q=x)",
+		},
+		{
+			name:  "too long links are omitted",
+			links: []domain.ServiceLink{links[0], {Service: "KKBOX", URL: "https://www.kkbox.com/" + strings.Repeat("x", 1024)}},
+			want:  "[🔗 Spotify で開く](https://open.spotify.com/album/1)\n🎧 [Apple Music](https://music.apple.com/jp/album/1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track := BuildTrackEmbed(i18n.Japanese, &domain.Track{URL: "https://open.spotify.com/album/1"}, tt.links)
+			album := BuildAlbumEmbed(i18n.Japanese, &domain.AlbumDetail{URL: "https://open.spotify.com/album/1"}, tt.links)
+			for _, embed := range []*discordgo.MessageEmbed{track, album} {
+				var got string
+				for _, field := range embed.Fields {
+					if field.Name == "リンク" {
+						got = field.Value
+					}
+				}
+				if got != tt.want {
+					t.Errorf("link field = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -6,24 +6,48 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
-// getModeLabel はレコメンドモードの日本語ラベルを返します
-func getModeLabel(mode domain.RecommendMode) string {
+// maxButtonsPerRow はDiscordが1つのActionsRowに許可するボタンの最大数です
+const maxButtonsPerRow = 5
+
+// getModeLabel はレコメンドモードの表示ラベルを返します
+func getModeLabel(loc i18n.Locale, mode domain.RecommendMode) string {
 	switch mode {
 	case domain.RecommendModeSimilar:
-		return "雰囲気重視"
+		return i18n.T(loc, "雰囲気重視")
 	case domain.RecommendModeRelated:
-		return "関連性重視"
+		return i18n.T(loc, "関連性重視")
 	case domain.RecommendModeBalanced:
-		return "バランス"
+		return i18n.T(loc, "バランス")
 	default:
-		return "バランス"
+		return i18n.T(loc, "バランス")
+	}
+}
+
+// getSearchTypeLabel は検索対象の種別の表示ラベルを返します
+func getSearchTypeLabel(loc i18n.Locale, searchType domain.SearchType) string {
+	switch searchType {
+	case domain.SearchTypeArtist:
+		return i18n.T(loc, "アーティスト")
+	case domain.SearchTypeAlbum:
+		return i18n.T(loc, "アルバム")
+	case domain.SearchTypeAll:
+		return i18n.T(loc, "すべて")
+	default:
+		return i18n.T(loc, "トラック")
 	}
 }
 
 // BuildRecommendEmbed はレコメンド結果のEmbedを構築します
-func BuildRecommendEmbed(originalTrackName string, items []domain.SimilarTrack, page, pageSize, total int, mode domain.RecommendMode) *discordgo.MessageEmbed {
+func BuildRecommendEmbed(loc i18n.Locale, originalTrackName string, items []domain.SimilarTrack, page, pageSize, total int, mode domain.RecommendMode) *discordgo.MessageEmbed {
+	return BuildRecommendEmbedWithSeeds(loc, originalTrackName, nil, items, page, pageSize, total, mode)
+}
+
+// BuildRecommendEmbedWithSeeds は複数シードに基づくレコメンド結果のEmbedを構築します
+// seedNames が空の場合は単一トラックのレコメンドとして表示します
+func BuildRecommendEmbedWithSeeds(loc i18n.Locale, sourceName string, seedNames []string, items []domain.SimilarTrack, page, pageSize, total int, mode domain.RecommendMode) *discordgo.MessageEmbed {
 	start := page * pageSize
 	end := start + pageSize
 	if end > len(items) {
@@ -31,8 +55,14 @@ func BuildRecommendEmbed(originalTrackName string, items []domain.SimilarTrack,
 	}
 	displayItems := items[start:end]
 
-	modeLabel := getModeLabel(mode)
-	description := fmt.Sprintf("「%s」に基づくレコメンド\n**モード**: %s (%d-%d / %d 件)", originalTrackName, modeLabel, start+1, end, total)
+	modeLabel := getModeLabel(loc, mode)
+	var description string
+	if len(seedNames) > 0 {
+		description = i18n.Sprintf(loc, "「%s」の %d 曲に基づくレコメンド\n🌱 %s\n**モード**: %s (%d-%d / %d 件)",
+			sourceName, len(seedNames), strings.Join(seedNames, " / "), modeLabel, start+1, end, total)
+	} else {
+		description = i18n.Sprintf(loc, "「%s」に基づくレコメンド\n**モード**: %s (%d-%d / %d 件)", sourceName, modeLabel, start+1, end, total)
+	}
 
 	var trackListParts []string
 	for i, track := range displayItems {
@@ -89,14 +119,14 @@ func BuildRecommendEmbed(originalTrackName string, items []domain.SimilarTrack,
 	}
 
 	return &discordgo.MessageEmbed{
-		Title:       "🎶 おすすめトラック",
+		Title:       i18n.T(loc, "🎶 おすすめトラック"),
 		Description: description + "\n\n" + strings.Join(trackListParts, "\n\n"),
 		Color:       SpotifyGreen,
 	}
 }
 
 // BuildSearchEmbed は検索結果のEmbedを構築します
-func BuildSearchEmbed(query string, items []domain.Track, page, pageSize, total int) *discordgo.MessageEmbed {
+func BuildSearchEmbed(loc i18n.Locale, query string, items []domain.Track, page, pageSize, total int) *discordgo.MessageEmbed {
 	start := page * pageSize
 	end := start + pageSize
 	if end > len(items) {
@@ -104,7 +134,7 @@ func BuildSearchEmbed(query string, items []domain.Track, page, pageSize, total
 	}
 	displayItems := items[start:end]
 
-	description := fmt.Sprintf("「%s」の検索結果 (%d-%d / %d 件)", query, start+1, end, total)
+	description := i18n.Sprintf(loc, "「%s」の検索結果 (%d-%d / %d 件)", query, start+1, end, total)
 
 	var trackListParts []string
 	for i, track := range displayItems {
@@ -116,31 +146,416 @@ func BuildSearchEmbed(query string, items []domain.Track, page, pageSize, total
 	}
 
 	return &discordgo.MessageEmbed{
-		Title:       "🔍 検索結果",
+		Title:       i18n.T(loc, "🔍 検索結果"),
 		Description: description + "\n\n" + strings.Join(trackListParts, "\n\n"),
 		Color:       SpotifyGreen,
 	}
 }
 
+// BuildArtistSearchEmbed はアーティストの検索結果のEmbedを構築します
+func BuildArtistSearchEmbed(loc i18n.Locale, query string, items []domain.ArtistDetail, page, pageSize, total int) *discordgo.MessageEmbed {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	displayItems := items[start:end]
+
+	description := i18n.Sprintf(loc, "「%s」の検索結果 (%d-%d / %d 件)", query, start+1, end, total)
+
+	var artistListParts []string
+	for i, artist := range displayItems {
+		artistListParts = append(artistListParts, fmt.Sprintf("**%d. %s**\n%s", start+i+1, artist.Name, artistSearchDetail(loc, &artist)))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "🔍 検索結果"),
+		Description: description + "\n\n" + strings.Join(artistListParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+}
+
+// BuildAlbumSearchEmbed はアルバムの検索結果のEmbedを構築します
+func BuildAlbumSearchEmbed(loc i18n.Locale, query string, items []domain.Album, page, pageSize, total int) *discordgo.MessageEmbed {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	displayItems := items[start:end]
+
+	description := i18n.Sprintf(loc, "「%s」の検索結果 (%d-%d / %d 件)", query, start+1, end, total)
+
+	var albumListParts []string
+	for i, album := range displayItems {
+		albumListParts = append(albumListParts, fmt.Sprintf(
+			"**%d. %s** 🎤 %s\n📅 %s | 🔗 [Spotify](%s)",
+			start+i+1, album.Name, JoinArtistNames(album.Artists), album.ReleaseDate, album.URL,
+		))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "🔍 検索結果"),
+		Description: description + "\n\n" + strings.Join(albumListParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+}
+
+// BuildAllSearchEmbed はトラック・アーティスト・アルバムをまとめた検索結果のEmbedを構築します
+// 各項目の先頭に種別のアイコンを表示します
+func BuildAllSearchEmbed(loc i18n.Locale, query string, items []domain.SearchItem, page, pageSize, total int) *discordgo.MessageEmbed {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	displayItems := items[start:end]
+
+	description := i18n.Sprintf(loc, "「%s」の検索結果 (%d-%d / %d 件)", query, start+1, end, total)
+
+	var listParts []string
+	for i, item := range displayItems {
+		icon := favoriteTypeIcon(string(item.Type))
+		switch {
+		case item.Track != nil:
+			listParts = append(listParts, fmt.Sprintf(
+				"**%d. %s %s** 🎤 %s\n📀 %s | 🔗 [Spotify](%s)",
+				start+i+1, icon, item.Track.Name, JoinArtistNames(item.Track.Artists), item.Track.Album.Name, item.Track.URL,
+			))
+		case item.Artist != nil:
+			listParts = append(listParts, fmt.Sprintf("**%d. %s %s**\n%s", start+i+1, icon, item.Artist.Name, artistSearchDetail(loc, item.Artist)))
+		case item.Album != nil:
+			listParts = append(listParts, fmt.Sprintf(
+				"**%d. %s %s** 🎤 %s\n📅 %s | 🔗 [Spotify](%s)",
+				start+i+1, icon, item.Album.Name, JoinArtistNames(item.Album.Artists), item.Album.ReleaseDate, item.Album.URL,
+			))
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "🔍 検索結果"),
+		Description: description + "\n\n" + strings.Join(listParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+}
+
+// artistSearchDetail はアーティストの検索結果の2行目（フォロワー数・リンク）を組み立てます
+// フォロワー数が欠損している場合は省略します
+func artistSearchDetail(loc i18n.Locale, artist *domain.ArtistDetail) string {
+	link := fmt.Sprintf("🔗 [Spotify](%s)", artist.URL)
+	if artist.Followers == "" {
+		return link
+	}
+	return i18n.Sprintf(loc, "👥 フォロワー %s", artist.Followers) + " | " + link
+}
+
+// BuildPlaylistEmbed はプレイリストのEmbedを構築します（収録曲はページ単位で表示）
+func BuildPlaylistEmbed(loc i18n.Locale, playlist *domain.Playlist, page, pageSize int) *discordgo.MessageEmbed {
+	total := len(playlist.Tracks)
+	start := page * pageSize
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	displayItems := playlist.Tracks[start:end]
+
+	description := i18n.Sprintf(loc, "👤 %s\n収録曲 (%d-%d / %d 曲)", playlist.Owner, start+1, end, total)
+	if playlist.TotalTracks > total {
+		description += i18n.Sprintf(loc, "\n※ 全 %d 曲のうち先頭 %d 曲を表示しています", playlist.TotalTracks, total)
+	}
+
+	var trackListParts []string
+	for i, track := range displayItems {
+		trackListParts = append(trackListParts, fmt.Sprintf(
+			"**%d. %s** 🎤 %s\n⏱ %s | 🔗 [Spotify](%s)",
+			start+i+1, track.Name, JoinArtistNames(track.Artists), FormatDuration(track.DurationMs), track.URL,
+		))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📃 " + playlist.Name,
+		URL:         playlist.URL,
+		Description: description + "\n\n" + strings.Join(trackListParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+
+	// フォロワー数（欠損時は省略）
+	if playlist.Followers != nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(loc, "フォロワー"),
+			Value:  FormatNumber(*playlist.Followers),
+			Inline: true,
+		})
+	}
+
+	// プレイリスト画像
+	if imgURL := GetLargestImage(playlist.Images); imgURL != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
+			URL: imgURL,
+		}
+	}
+
+	return embed
+}
+
+// favoriteTypeIcon はお気に入り種別ごとのアイコンを返します
+func favoriteTypeIcon(entityType string) string {
+	switch entityType {
+	case "artist":
+		return "🎤"
+	case "album":
+		return "💿"
+	default:
+		return "🎵"
+	}
+}
+
+// BuildFavoritesEmbed はお気に入り一覧のEmbedを構築します
+func BuildFavoritesEmbed(loc i18n.Locale, items []domain.Favorite, page, pageSize, total int) *discordgo.MessageEmbed {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	displayItems := items[start:end]
+
+	description := i18n.Sprintf(loc, "お気に入り (%d-%d / %d 件)", start+1, end, total)
+
+	var listParts []string
+	for i, fav := range displayItems {
+		line := fmt.Sprintf("**%d. %s %s**", start+i+1, favoriteTypeIcon(fav.EntityType), fav.Name)
+		if fav.Subtitle != "" {
+			line += " — " + fav.Subtitle
+		}
+		listParts = append(listParts, fmt.Sprintf("%s\n🔗 [Spotify](%s)", line, fav.URL))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "⭐ お気に入り"),
+		Description: description + "\n\n" + strings.Join(listParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+}
+
+// BuildFavoriteButton はお気に入り登録ボタンを構築します
+func BuildFavoriteButton(entityType, spotifyID string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "⭐ Save",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("fav_add:%s:%s", entityType, spotifyID),
+				},
+			},
+		},
+	}
+}
+
+// historyCommandIcon は履歴のコマンドごとのアイコンを返します
+func historyCommandIcon(command string) string {
+	switch command {
+	case "artist":
+		return "👤"
+	case "album":
+		return "💿"
+	case "recommend":
+		return "✨"
+	case "search":
+		return "🔍"
+	default:
+		return "🎵"
+	}
+}
+
+// BuildHistoryEmbed はコマンド実行履歴のEmbedを構築します
+func BuildHistoryEmbed(loc i18n.Locale, items []domain.HistoryEntry, page, pageSize, total int) *discordgo.MessageEmbed {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	displayItems := items[start:end]
+
+	description := i18n.Sprintf(loc, "実行履歴 (%d-%d / %d 件)\n番号ボタンで同じコマンドを再実行できます", start+1, end, total)
+
+	var listParts []string
+	for i, entry := range displayItems {
+		label := entry.Label
+		if label == "" {
+			label = entry.URL
+		}
+		line := fmt.Sprintf("**%d. %s /jam %s** %s", start+i+1, historyCommandIcon(entry.Command), entry.Command, label)
+		if entry.Mode != "" {
+			line += i18n.Sprintf(loc, "（%s）", getModeLabel(loc, domain.RecommendMode(entry.Mode)))
+		}
+		if entry.Command == "search" && entry.EntityType != "" && entry.EntityType != string(domain.SearchTypeTrack) {
+			line += i18n.Sprintf(loc, "（%s）", getSearchTypeLabel(loc, domain.SearchType(entry.EntityType)))
+		}
+		detail := fmt.Sprintf("🕘 <t:%d:R>", entry.CreatedAt.Unix())
+		if entry.URL != "" {
+			detail += fmt.Sprintf(" | 🔗 [Spotify](%s)", entry.URL)
+		}
+		listParts = append(listParts, line+"\n"+detail)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "🕘 履歴"),
+		Description: description + "\n\n" + strings.Join(listParts, "\n\n"),
+		Color:       SpotifyGreen,
+	}
+}
+
+// BuildHistoryRerunButtons は表示中の履歴を再実行する番号ボタンを構築します
+func BuildHistoryRerunButtons(items []domain.HistoryEntry, page, pageSize int) []discordgo.MessageComponent {
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	if start >= end {
+		return nil
+	}
+
+	var buttons []discordgo.MessageComponent
+	for i, entry := range items[start:end] {
+		buttons = append(buttons, discordgo.Button{
+			Label:    fmt.Sprintf("🔁 %d", start+i+1),
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("history_run:%d", entry.ID),
+		})
+	}
+
+	// 1行に置けるボタンは最大5個のため、表示件数が多い場合は複数行に分ける
+	var rows []discordgo.MessageComponent
+	for len(buttons) > 0 {
+		n := min(len(buttons), maxButtonsPerRow)
+		rows = append(rows, discordgo.ActionsRow{Components: buttons[:n]})
+		buttons = buttons[n:]
+	}
+	return rows
+}
+
+// BuildTrackSelectMenu は表示中の検索結果から詳細を表示する曲を選ぶセレクトメニューを構築します
+func BuildTrackSelectMenu(loc i18n.Locale, tracks []domain.Track, page, pageSize int) []discordgo.MessageComponent {
+	start, end := pageBounds(len(tracks), page, pageSize)
+
+	var options []discordgo.SelectMenuOption
+	for i, track := range tracks[start:end] {
+		options = appendTrackOption(options, start+i+1, track.ID, track.Name, track.Artists)
+	}
+	return buildTrackSelectMenu(loc, page, options)
+}
+
+// BuildRecommendSelectMenu は表示中のレコメンドから詳細を表示する曲を選ぶセレクトメニューを構築します
+func BuildRecommendSelectMenu(loc i18n.Locale, items []domain.SimilarTrack, page, pageSize int) []discordgo.MessageComponent {
+	start, end := pageBounds(len(items), page, pageSize)
+
+	var options []discordgo.SelectMenuOption
+	for i, track := range items[start:end] {
+		// レコメンドはトラックのアーティストが欠損している場合があるため、アルバムのアーティストで補う
+		artists := track.Artists
+		if len(artists) == 0 {
+			artists = track.Album.Artists
+		}
+		options = appendTrackOption(options, start+i+1, track.ID, track.Name, artists)
+	}
+	return buildTrackSelectMenu(loc, page, options)
+}
+
+// BuildSearchItemSelectMenu は種別をまたいだ検索結果のうち、表示中のトラックから詳細を表示する曲を選ぶセレクトメニューを構築します
+func BuildSearchItemSelectMenu(loc i18n.Locale, items []domain.SearchItem, page, pageSize int) []discordgo.MessageComponent {
+	start, end := pageBounds(len(items), page, pageSize)
+
+	var options []discordgo.SelectMenuOption
+	for i, item := range items[start:end] {
+		if item.Track != nil {
+			options = appendTrackOption(options, start+i+1, item.Track.ID, item.Track.Name, item.Track.Artists)
+		}
+	}
+	return buildTrackSelectMenu(loc, page, options)
+}
+
+// appendTrackOption はセレクトメニューに曲の選択肢を追加します
+// 表示名は一覧と同じ番号付きの曲名、値はトラックの Spotify ID です（IDが欠損している曲は選択できないため追加しません）
+func appendTrackOption(options []discordgo.SelectMenuOption, number int, id, name string, artists []domain.Artist) []discordgo.SelectMenuOption {
+	if id == "" {
+		return options
+	}
+	return append(options, discordgo.SelectMenuOption{
+		Label:       truncateRunes(fmt.Sprintf("%d. %s", number, name), maxChoiceLength),
+		Value:       id,
+		Description: truncateRunes(JoinArtistNames(artists), maxChoiceLength),
+	})
+}
+
+// buildTrackSelectMenu は曲を選ぶセレクトメニューの行を構築します（選択肢がない場合は nil を返します）
+// custom_id は track_select:<page> 形式で、選択した曲のIDはインタラクションの values で受け取ります
+func buildTrackSelectMenu(loc i18n.Locale, page int, options []discordgo.SelectMenuOption) []discordgo.MessageComponent {
+	if len(options) == 0 {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.StringSelectMenu,
+					CustomID:    fmt.Sprintf("track_select:%d", page),
+					Placeholder: i18n.T(loc, "🔎 曲を選んで詳細を表示"),
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+// BuildTrackDetailButtons は一覧から選択した曲の詳細に付けるボタンを構築します
+// お気に入り登録と、その曲を起点にしたレコメンドのボタンです
+func BuildTrackDetailButtons(loc i18n.Locale, trackID string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "⭐ Save",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("fav_add:track:%s", trackID),
+				},
+				discordgo.Button{
+					Label:    i18n.T(loc, "✨ この曲でおすすめ"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: fmt.Sprintf("recommend_from:%s", trackID),
+				},
+			},
+		},
+	}
+}
+
+// pageBounds は指定したページに表示する範囲 [start, end) を返します（範囲外のページは空の範囲になります）
+func pageBounds(total, page, pageSize int) (int, int) {
+	start := min(max(page*pageSize, 0), total)
+	end := min(start+pageSize, total)
+	return start, end
+}
+
 // BuildPaginationButtons はページングボタンを構築します
-func BuildPaginationButtons(messageID string, page, totalPages int) []discordgo.MessageComponent {
+func BuildPaginationButtons(loc i18n.Locale, messageID string, page, totalPages int) []discordgo.MessageComponent {
 	return []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
-					Label:    "◀ 前へ",
+					Label:    i18n.T(loc, "◀ 前へ"),
 					Style:    discordgo.SecondaryButton,
 					CustomID: fmt.Sprintf("page_prev:%s:%d", messageID, page),
 					Disabled: page == 0,
 				},
 				discordgo.Button{
-					Label:    "次へ ▶",
+					Label:    i18n.T(loc, "次へ ▶"),
 					Style:    discordgo.SecondaryButton,
 					CustomID: fmt.Sprintf("page_next:%s:%d", messageID, page),
 					Disabled: page >= totalPages-1,
 				},
 				discordgo.Button{
-					Label:    "👁 自分も見る",
+					Label:    i18n.T(loc, "👁 自分も見る"),
 					Style:    discordgo.PrimaryButton,
 					CustomID: fmt.Sprintf("view_own:%s", messageID),
 				},
@@ -148,3 +563,25 @@ func BuildPaginationButtons(messageID string, page, totalPages int) []discordgo.
 		},
 	}
 }
+
+// BuildEphemeralPaginationButtons はEphemeralメッセージ用のページングボタンを構築します
+func BuildEphemeralPaginationButtons(loc i18n.Locale, messageID string, page, totalPages int) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(loc, "◀ 前へ"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("ephemeral_prev:%s:%d", messageID, page),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    i18n.T(loc, "次へ ▶"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("ephemeral_next:%s:%d", messageID, page),
+					Disabled: page >= totalPages-1,
+				},
+			},
+		},
+	}
+}
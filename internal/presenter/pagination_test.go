@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
 )
 
 func TestBuildRecommendEmbed(t *testing.T) {
@@ -77,7 +79,7 @@ func TestBuildRecommendEmbed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			embed := BuildRecommendEmbed(tt.originalTrackName, tt.items, tt.page, tt.pageSize, tt.total, domain.RecommendModeBalanced)
+			embed := BuildRecommendEmbed(i18n.Japanese, tt.originalTrackName, tt.items, tt.page, tt.pageSize, tt.total, domain.RecommendModeBalanced)
 
 			if embed.Title != tt.wantTitle {
 				t.Errorf("Title = %s, want %s", embed.Title, tt.wantTitle)
@@ -174,7 +176,7 @@ func TestBuildSearchEmbed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			embed := BuildSearchEmbed(tt.query, tt.items, tt.page, tt.pageSize, tt.total)
+			embed := BuildSearchEmbed(i18n.Japanese, tt.query, tt.items, tt.page, tt.pageSize, tt.total)
 
 			if embed.Title != tt.wantTitle {
 				t.Errorf("Title = %s, want %s", embed.Title, tt.wantTitle)
@@ -247,7 +249,7 @@ func TestBuildPaginationButtons(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			components := BuildPaginationButtons(tt.messageID, tt.page, tt.totalPages)
+			components := BuildPaginationButtons(i18n.Japanese, tt.messageID, tt.page, tt.totalPages)
 
 			if len(components) != 1 {
 				t.Fatalf("Expected 1 component row, got %d", len(components))
@@ -350,3 +352,503 @@ func createTestTracks(count int) []domain.Track {
 	}
 	return tracks
 }
+
+func TestBuildPlaylistEmbed(t *testing.T) {
+	followers := 12345
+	playlist := &domain.Playlist{
+		ID:          "37i9dQZF1DXcBWIGoYBM5M",
+		Name:        "Test Playlist",
+		URL:         "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+		Owner:       "Test Owner",
+		Followers:   &followers,
+		Images:      []domain.Image{{URL: "http://example.com/playlist.jpg", Width: 300, Height: 300}},
+		Tracks:      createTestTracks(12),
+		TotalTracks: 12,
+	}
+
+	tests := []struct {
+		name         string
+		page         int
+		wantStartNum int
+		wantEndNum   int
+	}{
+		{"first page", 0, 1, 5},
+		{"second page", 1, 6, 10},
+		{"last page with less items", 2, 11, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embed := BuildPlaylistEmbed(i18n.Japanese, playlist, tt.page, 5)
+
+			if embed.Title != "📃 Test Playlist" {
+				t.Errorf("Title = %s, want 📃 Test Playlist", embed.Title)
+			}
+			if embed.URL != playlist.URL {
+				t.Errorf("URL = %s, want %s", embed.URL, playlist.URL)
+			}
+			if !strings.Contains(embed.Description, "Test Owner") {
+				t.Error("Description should contain owner name")
+			}
+			for i := tt.wantStartNum; i <= tt.wantEndNum; i++ {
+				if !strings.Contains(embed.Description, fmt.Sprintf("**%d.", i)) {
+					t.Errorf("Description should contain item number %d", i)
+				}
+			}
+			if strings.Contains(embed.Description, fmt.Sprintf("**%d.", tt.wantEndNum+1)) {
+				t.Errorf("Description should not contain item number %d", tt.wantEndNum+1)
+			}
+			if embed.Thumbnail == nil || embed.Thumbnail.URL != "http://example.com/playlist.jpg" {
+				t.Error("Thumbnail should be set to playlist image")
+			}
+			if len(embed.Fields) != 1 || embed.Fields[0].Value != "12,345" {
+				t.Errorf("Followers field not set correctly: %+v", embed.Fields)
+			}
+		})
+	}
+}
+
+func TestBuildPlaylistEmbed_Truncated(t *testing.T) {
+	playlist := &domain.Playlist{
+		Name:        "Long Playlist",
+		Owner:       "Owner",
+		Tracks:      createTestTracks(3),
+		TotalTracks: 250,
+	}
+
+	embed := BuildPlaylistEmbed(i18n.Japanese, playlist, 0, 5)
+
+	if !strings.Contains(embed.Description, "全 250 曲のうち先頭 3 曲") {
+		t.Errorf("Description should mention truncation, got: %s", embed.Description)
+	}
+	if len(embed.Fields) != 0 {
+		t.Errorf("Followers field should be omitted when missing")
+	}
+	if embed.Thumbnail != nil {
+		t.Error("Thumbnail should not be set when images are missing")
+	}
+}
+
+func TestBuildRecommendEmbedWithSeeds(t *testing.T) {
+	items := createTestSimilarTracks(7)
+
+	embed := BuildRecommendEmbedWithSeeds(i18n.Japanese, "Test Album", []string{"Seed A", "Seed B"}, items, 0, 5, 7, domain.RecommendModeBalanced)
+
+	if !strings.Contains(embed.Description, "「Test Album」の 2 曲に基づくレコメンド") {
+		t.Errorf("Description should contain source header, got: %s", embed.Description)
+	}
+	if !strings.Contains(embed.Description, "🌱 Seed A / Seed B") {
+		t.Errorf("Description should list seed tracks, got: %s", embed.Description)
+	}
+
+	// シードが無い場合は単一トラックのレコメンドと同じ表示になる
+	single := BuildRecommendEmbedWithSeeds(i18n.Japanese, "Test Song", nil, items, 0, 5, 7, domain.RecommendModeBalanced)
+	legacy := BuildRecommendEmbed(i18n.Japanese, "Test Song", items, 0, 5, 7, domain.RecommendModeBalanced)
+	if single.Description != legacy.Description {
+		t.Error("BuildRecommendEmbedWithSeeds without seeds should match BuildRecommendEmbed")
+	}
+	if strings.Contains(single.Description, "🌱") {
+		t.Error("Description should not contain seed line without seeds")
+	}
+}
+
+func TestBuildFavoritesEmbed(t *testing.T) {
+	items := []domain.Favorite{
+		{EntityType: "track", Name: "Lemon", Subtitle: "米津玄師", URL: "https://open.spotify.com/track/1"},
+		{EntityType: "artist", Name: "YOASOBI", URL: "https://open.spotify.com/artist/2"},
+		{EntityType: "album", Name: "STRAY SHEEP", Subtitle: "米津玄師", URL: "https://open.spotify.com/album/3"},
+	}
+
+	embed := BuildFavoritesEmbed(i18n.Japanese, items, 0, 2, len(items))
+
+	if embed.Title != "⭐ お気に入り" {
+		t.Errorf("Title = %s, want ⭐ お気に入り", embed.Title)
+	}
+	for _, want := range []string{"(1-2 / 3 件)", "**1. 🎵 Lemon** — 米津玄師", "**2. 🎤 YOASOBI**", "https://open.spotify.com/artist/2"} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("Description should contain %q, got %s", want, embed.Description)
+		}
+	}
+	if strings.Contains(embed.Description, "STRAY SHEEP") {
+		t.Error("Description should not contain items of the next page")
+	}
+
+	embed = BuildFavoritesEmbed(i18n.Japanese, items, 1, 2, len(items))
+	if !strings.Contains(embed.Description, "**3. 💿 STRAY SHEEP**") {
+		t.Errorf("second page should contain album entry, got %s", embed.Description)
+	}
+}
+
+func TestBuildFavoriteButton(t *testing.T) {
+	components := BuildFavoriteButton("album", "0sNOF9WDwhWunNAHPD3Baj")
+
+	row, ok := components[0].(discordgo.ActionsRow)
+	if !ok {
+		t.Fatal("first component should be ActionsRow")
+	}
+	btn, ok := row.Components[0].(discordgo.Button)
+	if !ok {
+		t.Fatal("component should be Button")
+	}
+	if btn.CustomID != "fav_add:album:0sNOF9WDwhWunNAHPD3Baj" {
+		t.Errorf("CustomID = %s, want fav_add:album:0sNOF9WDwhWunNAHPD3Baj", btn.CustomID)
+	}
+}
+
+func TestBuildHistoryEmbed(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []domain.HistoryEntry{
+		{ID: 3, Command: "recommend", URL: "https://open.spotify.com/album/1", Mode: "similar", Label: "STRAY SHEEP", CreatedAt: createdAt},
+		{ID: 2, Command: "search", Query: "YOASOBI", Label: "YOASOBI", CreatedAt: createdAt},
+		{ID: 1, Command: "track", URL: "https://open.spotify.com/track/2", CreatedAt: createdAt},
+	}
+
+	embed := BuildHistoryEmbed(i18n.Japanese, items, 0, 5, len(items))
+
+	if embed.Title != "🕘 履歴" {
+		t.Errorf("Title = %s, want 🕘 履歴", embed.Title)
+	}
+	for _, want := range []string{
+		"(1-3 / 3 件)",
+		"**1. ✨ /jam recommend** STRAY SHEEP（雰囲気重視）",
+		"**2. 🔍 /jam search** YOASOBI",
+		"**3. 🎵 /jam track** https://open.spotify.com/track/2", // ラベルが空の場合はURL
+		fmt.Sprintf("<t:%d:R>", createdAt.Unix()),
+	} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("Description should contain %q, got %s", want, embed.Description)
+		}
+	}
+}
+
+func TestBuildHistoryEmbed_SearchType(t *testing.T) {
+	items := []domain.HistoryEntry{
+		{ID: 2, Command: "search", EntityType: "album", Query: "YOASOBI", Label: "YOASOBI"},
+		{ID: 1, Command: "search", EntityType: "track", Query: "Lemon", Label: "Lemon"},
+	}
+
+	embed := BuildHistoryEmbed(i18n.Japanese, items, 0, 5, len(items))
+
+	if !strings.Contains(embed.Description, "**1. 🔍 /jam search** YOASOBI（アルバム）") {
+		t.Errorf("Description should contain the search type, got %s", embed.Description)
+	}
+	if strings.Contains(embed.Description, "Lemon（") {
+		t.Errorf("track search should not show the search type, got %s", embed.Description)
+	}
+}
+
+func TestBuildHistoryRerunButtons(t *testing.T) {
+	items := make([]domain.HistoryEntry, 7)
+	for i := range items {
+		items[i] = domain.HistoryEntry{ID: int64(100 + i)}
+	}
+
+	tests := []struct {
+		name        string
+		page        int
+		wantLabels  []string
+		wantFirstID string
+	}{
+		{name: "first page", page: 0, wantLabels: []string{"🔁 1", "🔁 2", "🔁 3", "🔁 4", "🔁 5"}, wantFirstID: "history_run:100"},
+		{name: "last page", page: 1, wantLabels: []string{"🔁 6", "🔁 7"}, wantFirstID: "history_run:105"},
+		{name: "out of range", page: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := BuildHistoryRerunButtons(items, tt.page, 5)
+			if len(tt.wantLabels) == 0 {
+				if components != nil {
+					t.Errorf("expected no components, got %d", len(components))
+				}
+				return
+			}
+
+			row := components[0].(discordgo.ActionsRow)
+			if len(row.Components) != len(tt.wantLabels) {
+				t.Fatalf("button count = %d, want %d", len(row.Components), len(tt.wantLabels))
+			}
+			for i, c := range row.Components {
+				if btn := c.(discordgo.Button); btn.Label != tt.wantLabels[i] {
+					t.Errorf("button[%d].Label = %s, want %s", i, btn.Label, tt.wantLabels[i])
+				}
+			}
+			if btn := row.Components[0].(discordgo.Button); btn.CustomID != tt.wantFirstID {
+				t.Errorf("CustomID = %s, want %s", btn.CustomID, tt.wantFirstID)
+			}
+		})
+	}
+}
+
+func TestBuildEphemeralPaginationButtons(t *testing.T) {
+	components := BuildEphemeralPaginationButtons(i18n.Japanese, "msg123", 1, 3)
+
+	row := components[0].(discordgo.ActionsRow)
+	prev := row.Components[0].(discordgo.Button)
+	next := row.Components[1].(discordgo.Button)
+
+	if prev.CustomID != "ephemeral_prev:msg123:1" || prev.Disabled {
+		t.Errorf("prev button = %+v", prev)
+	}
+	if next.CustomID != "ephemeral_next:msg123:1" || next.Disabled {
+		t.Errorf("next button = %+v", next)
+	}
+
+	components = BuildEphemeralPaginationButtons(i18n.Japanese, "msg123", 2, 3)
+	row = components[0].(discordgo.ActionsRow)
+	if !row.Components[1].(discordgo.Button).Disabled {
+		t.Error("next button should be disabled on the last page")
+	}
+}
+
+func TestBuildListEmbeds_English(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	followers := 1200
+
+	tests := []struct {
+		name  string
+		embed *discordgo.MessageEmbed
+		want  []string
+	}{
+		{
+			name:  "recommend with seeds",
+			embed: BuildRecommendEmbedWithSeeds(i18n.English, "Test Album", []string{"Seed A", "Seed B"}, createTestSimilarTracks(7), 0, 5, 7, domain.RecommendModeSimilar),
+			want:  []string{"Recommendations based on 2 tracks from \"Test Album\"", "**Mode**: Vibe (1-5 / 7)"},
+		},
+		{
+			name:  "recommend",
+			embed: BuildRecommendEmbed(i18n.English, "Test Song", createTestSimilarTracks(7), 1, 5, 7, domain.RecommendModeBalanced),
+			want:  []string{"Recommendations based on \"Test Song\"", "**Mode**: Balanced (6-7 / 7)"},
+		},
+		{
+			name:  "search",
+			embed: BuildSearchEmbed(i18n.English, "test", createTestTracks(3), 0, 5, 3),
+			want:  []string{"Search results for \"test\" (1-3 / 3)"},
+		},
+		{
+			name: "playlist",
+			embed: BuildPlaylistEmbed(i18n.English, &domain.Playlist{
+				Name:        "Long Playlist",
+				Owner:       "Owner",
+				Tracks:      createTestTracks(3),
+				TotalTracks: 250,
+				Followers:   &followers,
+			}, 0, 5),
+			want: []string{"Tracks (1-3 / 3)", "Showing the first 3 of 250 tracks"},
+		},
+		{
+			name:  "favorites",
+			embed: BuildFavoritesEmbed(i18n.English, []domain.Favorite{{EntityType: "track", Name: "Lemon", URL: "https://open.spotify.com/track/1"}}, 0, 5, 1),
+			want:  []string{"Favorites (1-1 / 1)"},
+		},
+		{
+			name: "history",
+			embed: BuildHistoryEmbed(i18n.English, []domain.HistoryEntry{
+				{ID: 1, Command: "recommend", URL: "https://open.spotify.com/album/1", Mode: "related", Label: "Album", CreatedAt: createdAt},
+			}, 0, 5, 1),
+			want: []string{"History (1-1 / 1)", "**1. ✨ /jam recommend** Album (Relevance)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNoJapanese(t, tt.embed)
+			for _, want := range tt.want {
+				if !strings.Contains(tt.embed.Description, want) {
+					t.Errorf("Description should contain %q, got %s", want, tt.embed.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPaginationButtons_English(t *testing.T) {
+	for _, components := range [][]discordgo.MessageComponent{
+		BuildPaginationButtons(i18n.English, "msg123", 0, 3),
+		BuildEphemeralPaginationButtons(i18n.English, "msg123", 0, 3),
+	} {
+		for _, c := range components[0].(discordgo.ActionsRow).Components {
+			if label := c.(discordgo.Button).Label; hasJapanese(label) {
+				t.Errorf("button label should be translated, got %q", label)
+			}
+		}
+	}
+}
+
+func TestBuildHistoryRerunButtons_MultipleRows(t *testing.T) {
+	items := make([]domain.HistoryEntry, 8)
+	for i := range items {
+		items[i] = domain.HistoryEntry{ID: int64(100 + i)}
+	}
+
+	// 1行に置けるボタンは5個までのため、8件は5個と3個の2行に分かれる
+	components := BuildHistoryRerunButtons(items, 0, 8)
+	if len(components) != 2 {
+		t.Fatalf("row count = %d, want 2", len(components))
+	}
+	if n := len(components[0].(discordgo.ActionsRow).Components); n != 5 {
+		t.Errorf("first row button count = %d, want 5", n)
+	}
+	second := components[1].(discordgo.ActionsRow).Components
+	if len(second) != 3 {
+		t.Fatalf("second row button count = %d, want 3", len(second))
+	}
+	if btn := second[0].(discordgo.Button); btn.Label != "🔁 6" {
+		t.Errorf("second row first label = %s, want 🔁 6", btn.Label)
+	}
+}
+
+func TestBuildSearchEmbed_Types(t *testing.T) {
+	artist := domain.ArtistDetail{Name: "米津玄師", URL: "https://open.spotify.com/artist/1", Followers: "5,000,000"}
+	album := domain.Album{Name: "STRAY SHEEP", URL: "https://open.spotify.com/album/2", ReleaseDate: "2020-08-05", Artists: []domain.Artist{{Name: "米津玄師"}}}
+	track := domain.Track{Name: "Lemon", URL: "https://open.spotify.com/track/3", Artists: []domain.Artist{{Name: "米津玄師"}}, Album: domain.Album{Name: "Lemon"}}
+
+	tests := []struct {
+		name  string
+		embed *discordgo.MessageEmbed
+		want  []string
+	}{
+		{
+			name: "artist",
+			embed: BuildArtistSearchEmbed(i18n.Japanese, "米津玄師", []domain.ArtistDetail{
+				artist,
+				{Name: "No Followers", URL: "https://open.spotify.com/artist/4"},
+			}, 0, 5, 2),
+			want: []string{
+				"「米津玄師」の検索結果 (1-2 / 2 件)",
+				"**1. 米津玄師**\n👥 フォロワー 5,000,000 | 🔗 [Spotify](https://open.spotify.com/artist/1)",
+				"**2. No Followers**\n🔗 [Spotify](https://open.spotify.com/artist/4)",
+			},
+		},
+		{
+			name:  "album",
+			embed: BuildAlbumSearchEmbed(i18n.Japanese, "米津玄師", []domain.Album{album}, 0, 5, 1),
+			want: []string{
+				"**1. STRAY SHEEP** 🎤 米津玄師\n📅 2020-08-05 | 🔗 [Spotify](https://open.spotify.com/album/2)",
+			},
+		},
+		{
+			name: "all",
+			embed: BuildAllSearchEmbed(i18n.Japanese, "米津玄師", []domain.SearchItem{
+				{Type: domain.SearchTypeTrack, Track: &track},
+				{Type: domain.SearchTypeArtist, Artist: &artist},
+				{Type: domain.SearchTypeAlbum, Album: &album},
+			}, 0, 5, 3),
+			want: []string{
+				"**1. 🎵 Lemon** 🎤 米津玄師\n📀 Lemon | 🔗 [Spotify](https://open.spotify.com/track/3)",
+				"**2. 🎤 米津玄師**\n👥 フォロワー 5,000,000",
+				"**3. 💿 STRAY SHEEP** 🎤 米津玄師\n📅 2020-08-05",
+			},
+		},
+		{
+			name: "all second page",
+			embed: BuildAllSearchEmbed(i18n.Japanese, "米津玄師", []domain.SearchItem{
+				{Type: domain.SearchTypeTrack, Track: &track},
+				{Type: domain.SearchTypeAlbum, Album: &album},
+			}, 1, 1, 2),
+			want: []string{"(2-2 / 2 件)", "**2. 💿 STRAY SHEEP**"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.embed.Title != "🔍 検索結果" {
+				t.Errorf("Title = %q, want 🔍 検索結果", tt.embed.Title)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(tt.embed.Description, want) {
+					t.Errorf("Description = %q, want to contain %q", tt.embed.Description, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildTrackSelectMenu(t *testing.T) {
+	artists := []domain.Artist{{Name: "米津玄師"}}
+	tracks := []domain.Track{
+		{ID: "t1", Name: "Lemon", Artists: artists},
+		{ID: "", Name: "No ID"},
+		{ID: "t3", Name: strings.Repeat("あ", 120), Artists: artists},
+	}
+
+	tests := []struct {
+		name       string
+		components []discordgo.MessageComponent
+		wantID     string
+		wantLabels []string
+	}{
+		{
+			name:       "tracks",
+			components: BuildTrackSelectMenu(i18n.Japanese, tracks, 0, 5),
+			wantID:     "track_select:0",
+			wantLabels: []string{"1. Lemon", "3. " + strings.Repeat("あ", 96) + "…"},
+		},
+		{
+			name: "recommend falls back to album artists",
+			components: BuildRecommendSelectMenu(i18n.Japanese, []domain.SimilarTrack{
+				{ID: "s1", Name: "Lemon"},
+				{ID: "s2", Name: "Flamingo", Album: domain.Album{Artists: artists}},
+			}, 1, 1),
+			wantID:     "track_select:1",
+			wantLabels: []string{"2. Flamingo"},
+		},
+		{
+			name: "all search lists tracks only",
+			components: BuildSearchItemSelectMenu(i18n.Japanese, []domain.SearchItem{
+				{Type: domain.SearchTypeArtist, Artist: &domain.ArtistDetail{ID: "a1", Name: "米津玄師"}},
+				{Type: domain.SearchTypeTrack, Track: &tracks[0]},
+			}, 0, 5),
+			wantID:     "track_select:0",
+			wantLabels: []string{"2. Lemon"},
+		},
+		{
+			name:       "out of range",
+			components: BuildTrackSelectMenu(i18n.Japanese, tracks, 3, 5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.wantLabels) == 0 {
+				if tt.components != nil {
+					t.Errorf("expected no components, got %d", len(tt.components))
+				}
+				return
+			}
+
+			menu := tt.components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+			if menu.CustomID != tt.wantID {
+				t.Errorf("CustomID = %q, want %q", menu.CustomID, tt.wantID)
+			}
+			if len(menu.Options) != len(tt.wantLabels) {
+				t.Fatalf("option count = %d, want %d", len(menu.Options), len(tt.wantLabels))
+			}
+			for i, want := range tt.wantLabels {
+				if menu.Options[i].Label != want {
+					t.Errorf("Options[%d].Label = %q, want %q", i, menu.Options[i].Label, want)
+				}
+				if menu.Options[i].Description != "米津玄師" {
+					t.Errorf("Options[%d].Description = %q, want 米津玄師", i, menu.Options[i].Description)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildTrackDetailButtons(t *testing.T) {
+	row := BuildTrackDetailButtons(i18n.English, "t1")[0].(discordgo.ActionsRow)
+	if len(row.Components) != 2 {
+		t.Fatalf("button count = %d, want 2", len(row.Components))
+	}
+
+	wantIDs := []string{"fav_add:track:t1", "recommend_from:t1"}
+	for i, want := range wantIDs {
+		if got := row.Components[i].(discordgo.Button).CustomID; got != want {
+			t.Errorf("Components[%d].CustomID = %q, want %q", i, got, want)
+		}
+	}
+	if got := row.Components[1].(discordgo.Button).Label; got != "✨ Recommend from this" {
+		t.Errorf("Label = %q, want ✨ Recommend from this", got)
+	}
+}
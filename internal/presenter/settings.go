@@ -0,0 +1,99 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+// GuildSettingsDefaults はギルドで未設定の項目に適用される全体の設定です
+type GuildSettingsDefaults struct {
+	PageSize int
+	AutoLink bool // AUTO_LINK_EXPAND（false の場合はギルドの設定に関わらずリンク自動展開は動作しません）
+}
+
+// BuildGuildSettingsEmbed はギルドの設定のEmbedを構築します
+// 未設定の項目は全体の設定の値に「（デフォルト）」を付けて表示します
+func BuildGuildSettingsEmbed(loc i18n.Locale, settings *domain.GuildSettings, defaults GuildSettingsDefaults) *discordgo.MessageEmbed {
+	withDefault := func(value string, isDefault bool) string {
+		if isDefault {
+			return i18n.Sprintf(loc, "%s（デフォルト）", value)
+		}
+		return value
+	}
+
+	mode := settings.RecommendMode
+	if mode == "" {
+		mode = domain.RecommendModeBalanced
+	}
+
+	pageSize := settings.PageSize
+	if pageSize <= 0 {
+		pageSize = defaults.PageSize
+	}
+
+	autoLink := defaults.AutoLink
+	if settings.AutoLink != nil {
+		autoLink = *settings.AutoLink
+	}
+	autoLinkValue := withDefault(enabledLabel(loc, autoLink), settings.AutoLink == nil)
+	if !defaults.AutoLink {
+		autoLinkValue = i18n.T(loc, "無効（Bot 全体で無効化されています）")
+	}
+
+	channels := i18n.T(loc, "すべてのチャンネル")
+	if len(settings.AllowedChannels) > 0 {
+		mentions := make([]string, len(settings.AllowedChannels))
+		for i, id := range settings.AllowedChannels {
+			mentions[i] = fmt.Sprintf("<#%s>", id)
+		}
+		channels = strings.Join(mentions, " ")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: i18n.T(loc, "⚙️ サーバー設定"),
+		Color: SpotifyGreen,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(loc, "レコメンドモード"), Value: withDefault(getModeLabel(loc, mode), settings.RecommendMode == ""), Inline: true},
+			{Name: i18n.T(loc, "1ページの表示件数"), Value: withDefault(strconv.Itoa(pageSize), settings.PageSize <= 0), Inline: true},
+			{Name: i18n.T(loc, "リンク自動展開"), Value: autoLinkValue, Inline: true},
+			{Name: i18n.T(loc, "言語"), Value: localeLabel(loc, settings.Locale), Inline: true},
+			{Name: i18n.T(loc, "選択した項目の詳細"), Value: withDefault(detailVisibilityLabel(loc, settings.DetailVisibility), settings.DetailVisibility == ""), Inline: true},
+			{Name: i18n.T(loc, "利用できるチャンネル"), Value: channels},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: i18n.T(loc, "/jam config の各サブコマンドで変更できます（サーバー管理権限が必要です）")},
+	}
+}
+
+// enabledLabel は有効/無効の表示名を返します
+func enabledLabel(loc i18n.Locale, enabled bool) string {
+	if enabled {
+		return i18n.T(loc, "有効")
+	}
+	return i18n.T(loc, "無効")
+}
+
+// detailVisibilityLabel は一覧から選択した項目の詳細の表示範囲の表示名を返します
+func detailVisibilityLabel(loc i18n.Locale, visibility domain.DetailVisibility) string {
+	if visibility == domain.DetailVisibilityPublic {
+		return i18n.T(loc, "チャンネル全体")
+	}
+	return i18n.T(loc, "自分のみ")
+}
+
+// localeLabel はギルドの言語設定の表示名を返します
+// 言語名はどの言語で表示しても読めるよう、その言語自身の表記を使います
+func localeLabel(loc i18n.Locale, locale string) string {
+	switch i18n.Locale(locale) {
+	case i18n.Japanese:
+		return "日本語"
+	case i18n.English:
+		return "English"
+	default:
+		return i18n.T(loc, "自動（Discord のサーバーの言語）")
+	}
+}
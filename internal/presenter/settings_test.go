@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+func TestBuildGuildSettingsEmbed(t *testing.T) {
+	disabled := false
+	defaults := GuildSettingsDefaults{PageSize: 5, AutoLink: true}
+
+	tests := []struct {
+		name     string
+		settings *domain.GuildSettings
+		defaults GuildSettingsDefaults
+		want     []string // レコメンドモード・表示件数・リンク自動展開・言語・詳細の表示範囲・チャンネルの順
+	}{
+		{
+			name:     "unset shows defaults",
+			settings: &domain.GuildSettings{GuildID: "g1"},
+			defaults: defaults,
+			want:     []string{"バランス（デフォルト）", "5（デフォルト）", "有効（デフォルト）", "自動（Discord のサーバーの言語）", "自分のみ（デフォルト）", "すべてのチャンネル"},
+		},
+		{
+			name: "configured values",
+			settings: &domain.GuildSettings{
+				GuildID:          "g1",
+				RecommendMode:    domain.RecommendModeSimilar,
+				PageSize:         8,
+				AutoLink:         &disabled,
+				Locale:           "en",
+				AllowedChannels:  []string{"111", "222"},
+				DetailVisibility: domain.DetailVisibilityPublic,
+			},
+			defaults: defaults,
+			want:     []string{"雰囲気重視", "8", "無効", "English", "チャンネル全体", "<#111> <#222>"},
+		},
+		{
+			name:     "auto link disabled globally",
+			settings: &domain.GuildSettings{GuildID: "g1"},
+			defaults: GuildSettingsDefaults{PageSize: 5, AutoLink: false},
+			want:     []string{"バランス（デフォルト）", "5（デフォルト）", "無効（Bot 全体で無効化されています）", "自動（Discord のサーバーの言語）", "自分のみ（デフォルト）", "すべてのチャンネル"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embed := BuildGuildSettingsEmbed(i18n.Japanese, tt.settings, tt.defaults)
+			if len(embed.Fields) != len(tt.want) {
+				t.Fatalf("field count = %d, want %d", len(embed.Fields), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if embed.Fields[i].Value != want {
+					t.Errorf("Fields[%d].Value = %q, want %q", i, embed.Fields[i].Value, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildGuildSettingsEmbed_English(t *testing.T) {
+	disabled := false
+	settings := &domain.GuildSettings{GuildID: "g1", AutoLink: &disabled, Locale: "ja"}
+
+	embed := BuildGuildSettingsEmbed(i18n.English, settings, GuildSettingsDefaults{PageSize: 5, AutoLink: true})
+	if embed.Fields[0].Value != "Balanced (default)" {
+		t.Errorf("mode = %q, want Balanced (default)", embed.Fields[0].Value)
+	}
+	// 言語名はその言語自身の表記のまま表示する
+	if embed.Fields[3].Value != "日本語" {
+		t.Errorf("language = %q, want 日本語", embed.Fields[3].Value)
+	}
+	embed.Fields = append(embed.Fields[:3], embed.Fields[4:]...)
+	assertNoJapanese(t, embed)
+}
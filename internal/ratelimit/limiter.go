@@ -8,78 +8,147 @@ import (
 const (
 	// Window はレートリミットのウィンドウサイズです
 	Window = 10 * time.Second
-	// MaxRequests はウィンドウ内の最大リクエスト数です
+	// MaxRequests はウィンドウ内の最大リクエスト数（ユーザーごとのコスト上限）です
 	MaxRequests = 5
+	// GuildMaxRequests はウィンドウ内のギルドごとのコスト上限のデフォルト値です
+	GuildMaxRequests = 30
 )
 
-// Limiter はユーザーごとのレートリミッターです
+// Limiter はユーザーごと・ギルドごとのレートリミッターです
 type Limiter struct {
-	users sync.Map
+	users  sync.Map
+	guilds sync.Map
+	quota  Quota
 }
 
-// userEntry はユーザーごとのレートリミット情報を保持します
+// userEntry はユーザー（またはギルド）ごとのレートリミット情報を保持します
+// コストNのリクエストは同じ時刻のタイムスタンプN個として記録します
 type userEntry struct {
 	mu         sync.Mutex
 	timestamps []time.Time
 }
 
-// NewLimiter は新しいレートリミッターを作成します
+// NewLimiter はデフォルトの上限で新しいレートリミッターを作成します
 func NewLimiter() *Limiter {
-	return &Limiter{}
+	return NewLimiterWithQuota(DefaultQuota())
 }
 
-// Allow は指定されたユーザーのリクエストを許可するかどうかを判定します
+// NewLimiterWithQuota は指定した上限で新しいレートリミッターを作成します
+func NewLimiterWithQuota(quota Quota) *Limiter {
+	return &Limiter{quota: quota.withDefaults()}
+}
+
+// Quota は上限設定を返します
+func (l *Limiter) Quota() Quota {
+	return l.quota
+}
+
+// Allow は指定されたユーザーのコスト1のリクエストを許可するかどうかを判定します
 func (l *Limiter) Allow(userID string) bool {
+	return l.Take(Request{UserID: userID, Cost: 1}).Allowed
+}
+
+// Take はリクエストのコストをユーザーとギルドの両方の枠から消費できるか判定し、許可した場合は消費します
+func (l *Limiter) Take(req Request) Decision {
 	now := time.Now()
 	windowStart := now.Add(-Window)
+	withGuild := l.quota.hasGuildLimit(req)
+	cost := l.quota.clampCost(req.Cost, withGuild)
 
 	// ユーザーエントリを取得または作成
-	value, _ := l.users.LoadOrStore(userID, &userEntry{})
-	entry := value.(*userEntry)
+	value, _ := l.users.LoadOrStore(req.UserID, &userEntry{})
+	user := value.(*userEntry)
+
+	// ユーザー → ギルドの順でロックする（ロック順を固定してデッドロックを避ける）
+	user.mu.Lock()
+	defer user.mu.Unlock()
+
+	user.prune(windowStart)
+	decision := Decision{
+		Cost:           cost,
+		UserRemaining:  l.quota.UserMax - len(user.timestamps),
+		GuildRemaining: -1,
+	}
+
+	var guild *userEntry
+	if withGuild {
+		value, _ := l.guilds.LoadOrStore(req.GuildID, &userEntry{})
+		guild = value.(*userEntry)
+		guild.mu.Lock()
+		defer guild.mu.Unlock()
+
+		guild.prune(windowStart)
+		decision.GuildRemaining = l.quota.GuildMax - len(guild.timestamps)
+	}
+
+	// 制限を超えていないかチェック
+	if retry := user.retryAfter(now, cost, l.quota.UserMax); retry > 0 {
+		return decision.reject(ScopeUser, retry)
+	}
+	if guild != nil {
+		if retry := guild.retryAfter(now, cost, l.quota.GuildMax); retry > 0 {
+			return decision.reject(ScopeGuild, retry)
+		}
+	}
 
-	entry.mu.Lock()
-	defer entry.mu.Unlock()
+	// タイムスタンプを追加
+	user.add(now, cost)
+	decision.UserRemaining -= cost
+	if guild != nil {
+		guild.add(now, cost)
+		decision.GuildRemaining -= cost
+	}
+	decision.Allowed = true
+	return decision
+}
 
-	// ウィンドウ外のタイムスタンプを削除
+// prune はウィンドウ外のタイムスタンプを削除します
+func (e *userEntry) prune(windowStart time.Time) {
 	var validTimestamps []time.Time
-	for _, ts := range entry.timestamps {
+	for _, ts := range e.timestamps {
 		if ts.After(windowStart) {
 			validTimestamps = append(validTimestamps, ts)
 		}
 	}
+	e.timestamps = validTimestamps
+}
 
-	// 制限を超えていないかチェック
-	if len(validTimestamps) >= MaxRequests {
-		entry.timestamps = validTimestamps
-		return false
+// retryAfter は cost を消費できるようになるまでの待ち時間を返します（すぐに消費できる場合は0）
+func (e *userEntry) retryAfter(now time.Time, cost, limit int) time.Duration {
+	over := len(e.timestamps) + cost - limit
+	if over <= 0 {
+		return 0
 	}
+	// 古い順に over 個がウィンドウ外に出れば消費できる
+	return e.timestamps[over-1].Add(Window).Sub(now)
+}
 
-	// タイムスタンプを追加
-	entry.timestamps = append(validTimestamps, now)
-	return true
+// add はコスト分のタイムスタンプを追加します
+func (e *userEntry) add(now time.Time, cost int) {
+	for n := 0; n < cost; n++ {
+		e.timestamps = append(e.timestamps, now)
+	}
 }
 
 // Cleanup は古いエントリをクリーンアップします
 func (l *Limiter) Cleanup() {
-	now := time.Now()
-	windowStart := now.Add(-Window)
+	windowStart := time.Now().Add(-Window)
+	cleanupEntries(&l.users, windowStart)
+	cleanupEntries(&l.guilds, windowStart)
+}
 
-	l.users.Range(func(key, value interface{}) bool {
+// cleanupEntries はウィンドウ内のタイムスタンプがなくなったエントリを削除します
+func cleanupEntries(entries *sync.Map, windowStart time.Time) {
+	entries.Range(func(key, value interface{}) bool {
 		entry := value.(*userEntry)
 		entry.mu.Lock()
 
-		var validTimestamps []time.Time
-		for _, ts := range entry.timestamps {
-			if ts.After(windowStart) {
-				validTimestamps = append(validTimestamps, ts)
-			}
-		}
+		entry.prune(windowStart)
 
-		if len(validTimestamps) == 0 {
+		if len(entry.timestamps) == 0 {
 			entry.mu.Unlock()
-			l.users.Delete(key)
+			entries.Delete(key)
 		} else {
-			entry.timestamps = validTimestamps
 			entry.mu.Unlock()
 		}
 
@@ -210,3 +210,147 @@ func TestLimiter_EmptyUserID(t *testing.T) {
 		t.Error("6th request with empty user ID should be denied")
 	}
 }
+
+func TestLimiter_Take(t *testing.T) {
+	runTakeTests(t, func(t *testing.T, quota Quota) RateLimiter {
+		return NewLimiterWithQuota(quota)
+	})
+}
+
+// runTakeTests はインメモリ実装とRedis実装で共通の Take の振る舞いを検証します
+func runTakeTests(t *testing.T, newLimiter func(t *testing.T, quota Quota) RateLimiter) {
+	type step struct {
+		req           Request
+		wantAllowed   bool
+		wantScope     Scope
+		wantRemaining int
+	}
+	tests := []struct {
+		name  string
+		quota Quota
+		steps []step
+	}{
+		{
+			name:  "cost is consumed from user quota",
+			quota: Quota{UserMax: 5},
+			steps: []step{
+				{Request{UserID: "u1", Cost: 3}, true, "", 2},
+				{Request{UserID: "u1", Cost: 3}, false, ScopeUser, 2},
+				{Request{UserID: "u1", Cost: 2}, true, "", 0},
+			},
+		},
+		{
+			name:  "zero cost is treated as one",
+			quota: Quota{UserMax: 2},
+			steps: []step{
+				{Request{UserID: "u1"}, true, "", 1},
+				{Request{UserID: "u1", Cost: -1}, true, "", 0},
+				{Request{UserID: "u1"}, false, ScopeUser, 0},
+			},
+		},
+		{
+			name:  "cost over the limit is clamped",
+			quota: Quota{UserMax: 5},
+			steps: []step{
+				{Request{UserID: "u1", Cost: 10}, true, "", 0},
+			},
+		},
+		{
+			name:  "guild quota is shared between users",
+			quota: Quota{UserMax: 5, GuildMax: 6},
+			steps: []step{
+				{Request{UserID: "u1", GuildID: "g1", Cost: 3}, true, "", 2},
+				{Request{UserID: "u2", GuildID: "g1", Cost: 3}, true, "", 0},
+				{Request{UserID: "u3", GuildID: "g1", Cost: 1}, false, ScopeGuild, 0},
+				{Request{UserID: "u3", GuildID: "g2", Cost: 1}, true, "", 4},
+			},
+		},
+		{
+			name:  "rejected request does not consume guild quota",
+			quota: Quota{UserMax: 2, GuildMax: 10},
+			steps: []step{
+				{Request{UserID: "u1", GuildID: "g1", Cost: 2}, true, "", 0},
+				{Request{UserID: "u1", GuildID: "g1", Cost: 2}, false, ScopeUser, 0},
+				{Request{UserID: "u2", GuildID: "g1", Cost: 2}, true, "", 0},
+			},
+		},
+		{
+			name:  "no guild quota in DMs",
+			quota: Quota{UserMax: 5, GuildMax: 1},
+			steps: []step{
+				{Request{UserID: "u1", Cost: 3}, true, "", 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newLimiter(t, tt.quota)
+			for idx, st := range tt.steps {
+				got := limiter.Take(st.req)
+				if got.Allowed != st.wantAllowed {
+					t.Fatalf("step %d: Allowed = %v, want %v", idx, got.Allowed, st.wantAllowed)
+				}
+				if got.Scope != st.wantScope {
+					t.Errorf("step %d: Scope = %q, want %q", idx, got.Scope, st.wantScope)
+				}
+				if got.Remaining() != st.wantRemaining {
+					t.Errorf("step %d: Remaining() = %d, want %d", idx, got.Remaining(), st.wantRemaining)
+				}
+				if !got.Allowed && (got.RetryAfter <= 0 || got.RetryAfter > Window) {
+					t.Errorf("step %d: RetryAfter = %v, want (0, %v]", idx, got.RetryAfter, Window)
+				}
+			}
+		})
+	}
+}
+
+func TestUserEntry_RetryAfter(t *testing.T) {
+	now := time.Now()
+	entry := &userEntry{timestamps: []time.Time{
+		now.Add(-8 * time.Second),
+		now.Add(-5 * time.Second),
+		now.Add(-2 * time.Second),
+	}}
+
+	tests := []struct {
+		name  string
+		cost  int
+		limit int
+		want  time.Duration
+	}{
+		{"within limit", 2, 5, 0},
+		{"oldest must expire", 3, 5, 2 * time.Second},
+		{"two oldest must expire", 4, 5, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entry.retryAfter(now, tt.cost, tt.limit); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCosts(t *testing.T) {
+	costs := DefaultCosts().Merge(map[string]int{"search": 1, "artist": 2})
+
+	tests := []struct {
+		command string
+		want    int
+	}{
+		{"recommend", 3},
+		{"search", 1},
+		{"artist", 2},
+		{"track", 1},
+	}
+	for _, tt := range tests {
+		if got := costs.Of(tt.command); got != tt.want {
+			t.Errorf("Of(%q) = %d, want %d", tt.command, got, tt.want)
+		}
+	}
+	if DefaultCosts().Of("search") != 2 {
+		t.Error("Merge() should not modify the original costs")
+	}
+}
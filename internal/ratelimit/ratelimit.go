@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/metrics"
+)
+
+// RateLimiter はユーザーごと・ギルドごとのリクエスト可否を判定するレートリミッターです
+// インメモリ実装（Limiter）とRedis実装（RedisLimiter）があります
+type RateLimiter interface {
+	// Allow は指定されたユーザーのコスト1のリクエストを許可するかどうかを判定します
+	Allow(userID string) bool
+
+	// Take はリクエストのコストをユーザーとギルドの両方の枠から消費できるか判定し、許可した場合は消費します
+	Take(req Request) Decision
+}
+
+// インターフェース実装の確認
+var (
+	_ RateLimiter = (*Limiter)(nil)
+	_ RateLimiter = (*RedisLimiter)(nil)
+)
+
+// Scope はどの枠の上限で拒否されたかを表します
+type Scope string
+
+const (
+	// ScopeUser はユーザーごとの上限です
+	ScopeUser Scope = "user"
+	// ScopeGuild はギルドごとの上限です
+	ScopeGuild Scope = "guild"
+)
+
+// Quota はウィンドウ内で消費できるコストの上限です
+type Quota struct {
+	UserMax  int // ユーザーごとの上限（0以下の場合は MaxRequests）
+	GuildMax int // ギルドごとの上限（0以下の場合はギルド単位の制限なし）
+}
+
+// DefaultQuota はデフォルトの上限を返します
+func DefaultQuota() Quota {
+	return Quota{UserMax: MaxRequests, GuildMax: GuildMaxRequests}
+}
+
+// hasGuildLimit はリクエストにギルド単位の制限を適用するかどうかを返します
+func (q Quota) hasGuildLimit(req Request) bool {
+	return req.GuildID != "" && q.GuildMax > 0
+}
+
+// withDefaults は未設定の項目をデフォルト値で補完した上限を返します
+func (q Quota) withDefaults() Quota {
+	if q.UserMax <= 0 {
+		q.UserMax = MaxRequests
+	}
+	return q
+}
+
+// clampCost はコストを1以上、かつ上限以下に丸めます（上限を超えるコストのコマンドが永久に実行できなくなるのを防ぐ）
+func (q Quota) clampCost(cost int, withGuild bool) int {
+	if cost < 1 {
+		cost = 1
+	}
+	if cost > q.UserMax {
+		cost = q.UserMax
+	}
+	if withGuild && cost > q.GuildMax {
+		cost = q.GuildMax
+	}
+	return cost
+}
+
+// Request はレートリミットの判定対象となるリクエストです
+type Request struct {
+	UserID  string
+	GuildID string // 空の場合（DMなど）はギルド単位の制限を適用しない
+	Cost    int    // 0以下の場合は1
+}
+
+// Decision はレートリミットの判定結果です
+type Decision struct {
+	Allowed        bool
+	Scope          Scope         // 拒否された枠（許可時は空）
+	Cost           int           // 判定に使ったコスト
+	UserRemaining  int           // ユーザーの残りコスト（許可時は消費後）
+	GuildRemaining int           // ギルドの残りコスト（ギルド単位の制限がない場合は -1）
+	RetryAfter     time.Duration // 再試行できるまでの待ち時間（拒否時のみ）
+}
+
+// Remaining はユーザーとギルドの残りコストのうち小さい方を返します
+func (d Decision) Remaining() int {
+	if d.GuildRemaining >= 0 && d.GuildRemaining < d.UserRemaining {
+		return d.GuildRemaining
+	}
+	return d.UserRemaining
+}
+
+// reject は拒否の判定結果を返し、拒否をメトリクスに記録します
+func (d Decision) reject(scope Scope, retryAfter time.Duration) Decision {
+	d.Allowed = false
+	d.Scope = scope
+	d.RetryAfter = retryAfter
+	metrics.IncRateLimitRejection(string(scope))
+	return d
+}
+
+// Costs はサブコマンドごとのコストです（未定義のサブコマンドはコスト1）
+type Costs map[string]int
+
+// DefaultCosts はデフォルトのコストを返します
+// recommend はtracktaste内で複数の外部APIを呼び出すため重く、playlist/search は結果件数が多いため中程度とします
+func DefaultCosts() Costs {
+	return Costs{
+		"recommend": 3,
+		"playlist":  2,
+		"search":    2,
+	}
+}
+
+// Of は指定されたサブコマンドのコストを返します
+func (c Costs) Of(command string) int {
+	if cost, ok := c[command]; ok && cost > 0 {
+		return cost
+	}
+	return 1
+}
+
+// Merge は overrides で上書きしたコストを返します（元のコストは変更しません）
+func (c Costs) Merge(overrides map[string]int) Costs {
+	merged := make(Costs, len(c)+len(overrides))
+	for command, cost := range c {
+		merged[command] = cost
+	}
+	for command, cost := range overrides {
+		merged[command] = cost
+	}
+	return merged
+}
@@ -0,0 +1,186 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisKeyPrefix はレートリミット用のRedisキーのプレフィックスです
+	redisKeyPrefix = "ratelimit:"
+	// redisTimeout はRedisへの判定リクエスト1回あたりのタイムアウトです
+	redisTimeout = 200 * time.Millisecond
+	// redisRetryInterval はRedisの障害検知後、再びRedisを試すまでの間隔です
+	redisRetryInterval = 5 * time.Second
+)
+
+// slidingWindowScript はソート済みセットによる重み付きスライディングウィンドウを原子的に評価します
+// コストNのリクエストは同じスコアのメンバーN個として記録します
+// KEYS[1]: ユーザーのキー / KEYS[2]: ギルドのキー（省略可）
+// ARGV[1]: 現在時刻(μs) / ARGV[2]: ウィンドウ(μs) / ARGV[3]: コスト / ARGV[4]: ユーザー上限 / ARGV[5]: ギルド上限 / ARGV[6]: メンバーのプレフィックス
+// 戻り値: {許可なら1, ユーザーの使用量, ギルドの使用量(ギルドなしは-1), ユーザーの待ち時間(μs), ギルドの待ち時間(μs)}
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local limits = {tonumber(ARGV[4]), tonumber(ARGV[5])}
+
+local used = {0, -1}
+local retry = {0, 0}
+local allowed = 1
+for i, key in ipairs(KEYS) do
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+	used[i] = redis.call('ZCARD', key)
+	local over = used[i] + cost - limits[i]
+	if over > 0 then
+		local oldest = redis.call('ZRANGE', key, over - 1, over - 1, 'WITHSCORES')
+		retry[i] = tonumber(oldest[2]) + window - now
+		allowed = 0
+	end
+end
+
+if allowed == 1 then
+	for i, key in ipairs(KEYS) do
+		for n = 1, cost do
+			redis.call('ZADD', key, now, ARGV[6] .. '-' .. n)
+		end
+		redis.call('PEXPIRE', key, math.ceil(window / 1000))
+		used[i] = used[i] + cost
+	end
+end
+
+return {allowed, used[1], used[2], math.ceil(retry[1]), math.ceil(retry[2])}
+`)
+
+// RedisLimiter はRedisでウィンドウを共有するユーザーごとのレートリミッターです
+// 複数インスタンスで同じRedisを参照することで、インスタンス数に関係なく同じ上限を適用します
+// Redisが利用できない間は fallback のインメモリリミッターで判定します
+type RedisLimiter struct {
+	client     *redis.Client
+	fallback   *Limiter
+	instanceID string // 同時刻のリクエストでメンバーが衝突しないためのインスタンス識別子
+	seq        atomic.Uint64
+
+	mu          sync.Mutex
+	unavailable bool
+	retryAt     time.Time
+}
+
+// NewRedisLimiter は新しいRedisレートリミッターを作成します
+func NewRedisLimiter(redisURL string, fallback *Limiter) (*RedisLimiter, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate instance id: %w", err)
+	}
+
+	return &RedisLimiter{
+		client:     redis.NewClient(opts),
+		fallback:   fallback,
+		instanceID: hex.EncodeToString(buf),
+	}, nil
+}
+
+// Allow は指定されたユーザーのコスト1のリクエストを許可するかどうかを判定します
+func (l *RedisLimiter) Allow(userID string) bool {
+	return l.Take(Request{UserID: userID, Cost: 1}).Allowed
+}
+
+// Take はリクエストのコストをユーザーとギルドの両方の枠から消費できるか判定し、許可した場合は消費します
+// 上限はフォールバック先のインメモリリミッターと同じものを使います
+func (l *RedisLimiter) Take(req Request) Decision {
+	now := time.Now()
+	if !l.shouldTryRedis(now) {
+		return l.fallback.Take(req)
+	}
+
+	quota := l.fallback.Quota()
+	withGuild := quota.hasGuildLimit(req)
+	cost := quota.clampCost(req.Cost, withGuild)
+	keys := []string{redisKeyPrefix + "user:" + req.UserID}
+	if withGuild {
+		keys = append(keys, redisKeyPrefix+"guild:"+req.GuildID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	member := fmt.Sprintf("%d-%s-%d", now.UnixMicro(), l.instanceID, l.seq.Add(1))
+	result, err := slidingWindowScript.Run(ctx, l.client, keys,
+		now.UnixMicro(), Window.Microseconds(), cost, quota.UserMax, quota.GuildMax, member,
+	).Int64Slice()
+	if err == nil && len(result) != 5 {
+		err = fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+	if err != nil {
+		l.markUnavailable(now, err)
+		return l.fallback.Take(req)
+	}
+	l.markAvailable()
+
+	decision := Decision{
+		Cost:           cost,
+		UserRemaining:  quota.UserMax - int(result[1]),
+		GuildRemaining: -1,
+	}
+	if result[2] >= 0 {
+		decision.GuildRemaining = quota.GuildMax - int(result[2])
+	}
+
+	if result[0] == 1 {
+		decision.Allowed = true
+		return decision
+	}
+	if userRetry := time.Duration(result[3]) * time.Microsecond; userRetry > 0 {
+		return decision.reject(ScopeUser, userRetry)
+	}
+	return decision.reject(ScopeGuild, time.Duration(result[4])*time.Microsecond)
+}
+
+// Close はRedisクライアントをクローズします
+func (l *RedisLimiter) Close() error {
+	return l.client.Close()
+}
+
+// shouldTryRedis はRedisで判定を試みるかどうかを返します
+// 障害検知後は redisRetryInterval が経過するまでインメモリで判定し、Redisへの待ち時間を避けます
+func (l *RedisLimiter) shouldTryRedis(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.unavailable || !now.Before(l.retryAt)
+}
+
+// markUnavailable はRedisを一時的に利用不可とし、インメモリでの判定に切り替えます
+func (l *RedisLimiter) markUnavailable(now time.Time, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.unavailable {
+		slog.Warn("redis rate limiter unavailable, falling back to in-memory limiter", "error", err)
+	}
+	l.unavailable = true
+	l.retryAt = now.Add(redisRetryInterval)
+}
+
+// markAvailable はRedisでの判定に復帰します
+func (l *RedisLimiter) markAvailable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.unavailable {
+		l.unavailable = false
+		slog.Info("redis rate limiter restored")
+	}
+}
@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedisLimiter(t *testing.T) (*RedisLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	l, err := NewRedisLimiter(fmt.Sprintf("redis://%s?max_retries=-1&dial_timeout=100ms", mr.Addr()), NewLimiter())
+	if err != nil {
+		t.Fatalf("NewRedisLimiter() error = %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	return l, mr
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []string
+	}{
+		{"single user", []string{"user1"}},
+		{"independent users", []string{"user1", "user2", "user3"}},
+		{"empty user id", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, _ := newTestRedisLimiter(t)
+
+			for _, user := range tt.users {
+				for i := 0; i < MaxRequests; i++ {
+					if !limiter.Allow(user) {
+						t.Errorf("%s request %d should be allowed", user, i+1)
+					}
+				}
+			}
+			for _, user := range tt.users {
+				if limiter.Allow(user) {
+					t.Errorf("%s request %d should be denied", user, MaxRequests+1)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisLimiter_SharedAcrossInstances(t *testing.T) {
+	a, mr := newTestRedisLimiter(t)
+	b, err := NewRedisLimiter(fmt.Sprintf("redis://%s", mr.Addr()), NewLimiter())
+	if err != nil {
+		t.Fatalf("NewRedisLimiter() error = %v", err)
+	}
+	defer b.Close()
+
+	// 2インスタンス合計で MaxRequests 回まで
+	for i := 0; i < MaxRequests; i++ {
+		limiter := a
+		if i%2 == 1 {
+			limiter = b
+		}
+		if !limiter.Allow("user1") {
+			t.Errorf("request %d should be allowed", i+1)
+		}
+	}
+	if a.Allow("user1") || b.Allow("user1") {
+		t.Error("requests over the shared limit should be denied on every instance")
+	}
+}
+
+func TestRedisLimiter_Concurrency(t *testing.T) {
+	limiter, _ := newTestRedisLimiter(t)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowCount := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Allow("user1") {
+				mu.Lock()
+				allowCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowCount != MaxRequests {
+		t.Errorf("Expected %d allowed requests, got %d", MaxRequests, allowCount)
+	}
+}
+
+func TestRedisLimiter_WindowExpiration(t *testing.T) {
+	limiter, mr := newTestRedisLimiter(t)
+
+	// ウィンドウ外のリクエストは数えない
+	old := float64(time.Now().Add(-Window - time.Second).UnixMicro())
+	for i := 0; i < MaxRequests; i++ {
+		if _, err := mr.ZAdd(redisKeyPrefix+"user:user1", old, fmt.Sprintf("old-%d", i)); err != nil {
+			t.Fatalf("ZAdd() error = %v", err)
+		}
+	}
+
+	if !limiter.Allow("user1") {
+		t.Error("requests outside the window should not count")
+	}
+	members, err := mr.ZMembers(redisKeyPrefix + "user:user1")
+	if err != nil {
+		t.Fatalf("ZMembers() error = %v", err)
+	}
+	if len(members) != 1 {
+		t.Errorf("expired members should be removed, got %d members", len(members))
+	}
+}
+
+func TestRedisLimiter_FallbackAndRestore(t *testing.T) {
+	limiter, mr := newTestRedisLimiter(t)
+
+	// Redisでカウントされたリクエストはインメモリには反映されない
+	limiter.Allow("user1")
+	mr.Close()
+
+	for i := 0; i < MaxRequests; i++ {
+		if !limiter.Allow("user1") {
+			t.Errorf("fallback request %d should be allowed", i+1)
+		}
+	}
+	if limiter.Allow("user1") {
+		t.Error("fallback limiter should enforce MaxRequests")
+	}
+	if !limiter.unavailable {
+		t.Error("limiter should be marked unavailable")
+	}
+
+	// 再試行時刻を過ぎるとRedisでの判定に戻る
+	if err := mr.Restart(); err != nil {
+		t.Fatalf("Restart() error = %v", err)
+	}
+	limiter.mu.Lock()
+	limiter.retryAt = time.Now()
+	limiter.mu.Unlock()
+
+	if !limiter.Allow("user2") {
+		t.Error("request should be allowed after redis is restored")
+	}
+	if limiter.unavailable {
+		t.Error("limiter should be marked available after redis is restored")
+	}
+}
+
+func TestNewRedisLimiter_InvalidURL(t *testing.T) {
+	if _, err := NewRedisLimiter("invalid://url", NewLimiter()); err == nil {
+		t.Error("NewRedisLimiter() should return error for invalid URL")
+	}
+}
+
+func TestRedisLimiter_Take(t *testing.T) {
+	runTakeTests(t, func(t *testing.T, quota Quota) RateLimiter {
+		mr := miniredis.RunT(t)
+		l, err := NewRedisLimiter(fmt.Sprintf("redis://%s", mr.Addr()), NewLimiterWithQuota(quota))
+		if err != nil {
+			t.Fatalf("NewRedisLimiter() error = %v", err)
+		}
+		t.Cleanup(func() { _ = l.Close() })
+		return l
+	})
+}
@@ -0,0 +1,68 @@
+package spotify
+
+import (
+	"regexp"
+)
+
+// linkRegex はメッセージ本文中のSpotify URL / URIを検出する正規表現
+var linkRegex = regexp.MustCompile(
+	`https?://(?:open|play)\.spotify\.com/(?:intl-[a-zA-Z-]+/)?(?:embed/)?(track|artist|album|playlist)/([a-zA-Z0-9]{22})|spotify:(track|artist|album|playlist):([a-zA-Z0-9]{22})`,
+)
+
+// shortLinkRegex はメッセージ本文中のSpotifyの短縮URLを検出する正規表現
+var shortLinkRegex = regexp.MustCompile(`https?://spotify(?:\.app)?\.link/[a-zA-Z0-9]+`)
+
+// ExtractLinks はメッセージ本文からSpotifyのリンクを抽出し、バリデーション済みの結果を返します
+// 同一URLは1件にまとめ、最大 maxLinks 件まで返します（0以下の場合は無制限）
+func ExtractLinks(content string, maxLinks int) []ValidationResult {
+	matches := linkRegex.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var results []ValidationResult
+	for _, m := range matches {
+		entityType := EntityType(m[1])
+		if entityType == "" {
+			entityType = EntityType(m[3])
+		}
+
+		result := ValidateInput(m[0], entityType)
+		if !result.Valid {
+			continue
+		}
+		if _, ok := seen[result.URL]; ok {
+			continue
+		}
+		seen[result.URL] = struct{}{}
+
+		results = append(results, result)
+		if maxLinks > 0 && len(results) >= maxLinks {
+			break
+		}
+	}
+
+	return results
+}
+
+// CountShortLinks はメッセージ本文中のSpotifyの短縮URLの数を、最大 maxLinks 件まで数えます（0以下の場合は無制限）
+func CountShortLinks(content string, maxLinks int) int {
+	if maxLinks <= 0 {
+		maxLinks = -1
+	}
+	return len(shortLinkRegex.FindAllStringIndex(content, maxLinks))
+}
+
+// ReplaceShortLinks はメッセージ本文中のSpotifyの短縮URLを resolve の戻り値（展開したURL）に置き換えます
+// 置き換えるのは先頭から最大 maxLinks 件です（0以下の場合は無制限）
+func ReplaceShortLinks(content string, maxLinks int, resolve func(shortURL string) string) string {
+	count := 0
+	return shortLinkRegex.ReplaceAllStringFunc(content, func(link string) string {
+		if maxLinks > 0 && count >= maxLinks {
+			return link
+		}
+		count++
+		return resolve(link)
+	})
+}
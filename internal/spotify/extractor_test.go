@@ -0,0 +1,162 @@
+package spotify
+
+import (
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		maxLinks  int
+		wantURLs  []string
+		wantTypes []EntityType
+	}{
+		{
+			name:     "no links",
+			content:  "今日はいい天気ですね",
+			maxLinks: 3,
+			wantURLs: nil,
+		},
+		{
+			name:      "single track URL with query",
+			content:   "これ聴いて https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abcdef",
+			maxLinks:  3,
+			wantURLs:  []string{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"},
+			wantTypes: []EntityType{EntityTrack},
+		},
+		{
+			name:      "intl prefix",
+			content:   "https://open.spotify.com/intl-ja/album/4aawyAB9vmqN3uQ7FjRGTy",
+			maxLinks:  3,
+			wantURLs:  []string{"https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy"},
+			wantTypes: []EntityType{EntityAlbum},
+		},
+		{
+			name:      "URI",
+			content:   "spotify:artist:0OdUWJ0sBjDrqHygGUXeCF です",
+			maxLinks:  3,
+			wantURLs:  []string{"https://open.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF"},
+			wantTypes: []EntityType{EntityArtist},
+		},
+		{
+			name:      "playlist URL and URI",
+			content:   "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=x spotify:playlist:37i9dQZF1DX0XUsuxWHRQd",
+			maxLinks:  3,
+			wantURLs:  []string{"https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M", "https://open.spotify.com/playlist/37i9dQZF1DX0XUsuxWHRQd"},
+			wantTypes: []EntityType{EntityPlaylist, EntityPlaylist},
+		},
+		{
+			name: "mixed and duplicated",
+			content: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh " +
+				"spotify:track:4iV5W9uYEdYUVa79Axb7Rh " +
+				"https://open.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF",
+			maxLinks: 3,
+			wantURLs: []string{
+				"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+				"https://open.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF",
+			},
+			wantTypes: []EntityType{EntityTrack, EntityArtist},
+		},
+		{
+			name: "capped by maxLinks",
+			content: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh " +
+				"https://open.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF " +
+				"https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+			maxLinks:  2,
+			wantURLs:  []string{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", "https://open.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF"},
+			wantTypes: []EntityType{EntityTrack, EntityArtist},
+		},
+		{
+			name:      "play host and embed URL",
+			content:   "https://play.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh https://open.spotify.com/embed/album/4aawyAB9vmqN3uQ7FjRGTy?utm_source=generator",
+			maxLinks:  3,
+			wantURLs:  []string{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy"},
+			wantTypes: []EntityType{EntityTrack, EntityAlbum},
+		},
+		{
+			name:     "unsupported entity type",
+			content:  "https://open.spotify.com/episode/4iV5W9uYEdYUVa79Axb7Rh",
+			maxLinks: 3,
+			wantURLs: nil,
+		},
+		{
+			name:     "other host",
+			content:  "https://example.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			maxLinks: 3,
+			wantURLs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ExtractLinks(tt.content, tt.maxLinks)
+			if len(results) != len(tt.wantURLs) {
+				t.Fatalf("got %d links, want %d", len(results), len(tt.wantURLs))
+			}
+			for i, r := range results {
+				if !r.Valid {
+					t.Errorf("result[%d] is not valid", i)
+				}
+				if r.URL != tt.wantURLs[i] {
+					t.Errorf("result[%d].URL = %s, want %s", i, r.URL, tt.wantURLs[i])
+				}
+				if r.EntityType != tt.wantTypes[i] {
+					t.Errorf("result[%d].EntityType = %s, want %s", i, r.EntityType, tt.wantTypes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReplaceShortLinks(t *testing.T) {
+	resolved := map[string]string{
+		"https://spotify.link/AbC123":     "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=x",
+		"https://spotify.app.link/XyZ789": "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+	}
+	resolve := func(link string) string {
+		if url, ok := resolved[link]; ok {
+			return url
+		}
+		return link
+	}
+	content := "これ https://spotify.link/AbC123 と https://spotify.app.link/XyZ789?_p=c と https://spotify.link/Unknown"
+
+	got := ReplaceShortLinks(content, 0, resolve)
+	want := "これ https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=x と https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy?_p=c と https://spotify.link/Unknown"
+	if got != want {
+		t.Errorf("ReplaceShortLinks() = %q, want %q", got, want)
+	}
+
+	// 置き換え後の本文からリンクを抽出できる
+	links := ExtractLinks(got, 0)
+	if len(links) != 2 || links[0].EntityType != EntityTrack || links[1].EntityType != EntityAlbum {
+		t.Errorf("ExtractLinks() after replace = %+v", links)
+	}
+
+	limited := ReplaceShortLinks(content, 1, resolve)
+	if ExtractLinks(limited, 0)[0].EntityType != EntityTrack || len(ExtractLinks(limited, 0)) != 1 {
+		t.Errorf("ReplaceShortLinks(max=1) = %q, want only the first link replaced", limited)
+	}
+}
+
+func TestCountShortLinks(t *testing.T) {
+	content := "これ https://spotify.link/AbC123 と https://spotify.app.link/XyZ789?_p=c と https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"
+
+	tests := []struct {
+		name     string
+		maxLinks int
+		want     int
+	}{
+		{name: "unlimited", maxLinks: 0, want: 2},
+		{name: "limited", maxLinks: 1, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountShortLinks(content, tt.maxLinks); got != tt.want {
+				t.Errorf("CountShortLinks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
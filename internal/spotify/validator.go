@@ -11,22 +11,35 @@ import (
 type EntityType string
 
 const (
-	EntityTrack   EntityType = "track"
-	EntityArtist  EntityType = "artist"
-	EntityAlbum   EntityType = "album"
-	EntityUnknown EntityType = "unknown"
+	EntityTrack    EntityType = "track"
+	EntityArtist   EntityType = "artist"
+	EntityAlbum    EntityType = "album"
+	EntityPlaylist EntityType = "playlist"
+	EntityUnknown  EntityType = "unknown"
 )
 
 // SpotifyIDRegex はSpotify IDの形式を検証する正規表現
 var SpotifyIDRegex = regexp.MustCompile(`^[a-zA-Z0-9]{22}$`)
 
+// spotifyHosts はエンティティのURLとして受け付けるホストです（Web プレイヤーの旧ドメインを含む）
+var spotifyHosts = map[string]bool{
+	"open.spotify.com": true,
+	"play.spotify.com": true,
+}
+
+// shortLinkHosts はモバイルアプリの共有メニューが生成する短縮URLのホストです
+var shortLinkHosts = map[string]bool{
+	"spotify.link":     true,
+	"spotify.app.link": true,
+}
+
 // ValidationResult はバリデーション結果を表します
 type ValidationResult struct {
 	Valid      bool
 	URL        string
 	ID         string
 	EntityType EntityType
-	Error      string
+	Error      string // ユーザー向けのエラーメッセージ（日本語の原文。表示時に i18n で翻訳します）
 }
 
 // ValidateInput は入力をバリデーションし、Spotify URLに正規化します
@@ -45,6 +58,11 @@ func ValidateInput(input string, expectedType EntityType) ValidationResult {
 		return validateURL(input, expectedType)
 	}
 
+	// 共有リンクのトラッキング用パラメータ（?si=...）を除去
+	if idx := strings.Index(input, "?"); idx != -1 {
+		input = input[:idx]
+	}
+
 	// URI形式の場合 (spotify:track:xxx)
 	if strings.HasPrefix(input, "spotify:") {
 		return validateURI(input, expectedType)
@@ -54,6 +72,57 @@ func ValidateInput(input string, expectedType EntityType) ValidationResult {
 	return validateID(input, expectedType)
 }
 
+// IsShortLink は入力が Spotify の短縮URL（spotify.link など）かどうかを返します
+// 短縮URLはリダイレクト先を取得して展開してから ValidateInput に渡します
+func IsShortLink(input string) bool {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "http://") && !strings.HasPrefix(input, "https://") {
+		return false
+	}
+	parsed, err := url.Parse(input)
+	if err != nil {
+		return false
+	}
+	return shortLinkHosts[parsed.Host] && strings.Trim(parsed.Path, "/") != ""
+}
+
+// IsEntityURL は入力が展開済みの Spotify のURL（open.spotify.com など）かどうかを返します
+func IsEntityURL(rawURL string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && spotifyHosts[parsed.Host]
+}
+
+// DetectEntityType は URL / URI 形式の入力からエンティティ種別を判定します
+// 生IDなど種別を判定できない入力の場合は EntityUnknown を返します
+func DetectEntityType(input string) EntityType {
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "spotify:") {
+		parts := strings.Split(input, ":")
+		if len(parts) != 3 {
+			return EntityUnknown
+		}
+		return EntityType(parts[1])
+	}
+
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		parsed, err := url.Parse(input)
+		if err != nil || !spotifyHosts[parsed.Host] {
+			return EntityUnknown
+		}
+		parts := entityPathParts(parsed.Path)
+		if len(parts) < 2 {
+			return EntityUnknown
+		}
+		return EntityType(parts[0])
+	}
+
+	return EntityUnknown
+}
+
 // validateURL はURL形式の入力をバリデーションします
 func validateURL(input string, expectedType EntityType) ValidationResult {
 	parsed, err := url.Parse(input)
@@ -65,15 +134,15 @@ func validateURL(input string, expectedType EntityType) ValidationResult {
 	}
 
 	// ドメインチェック
-	if parsed.Host != "open.spotify.com" {
+	if !spotifyHosts[parsed.Host] {
 		return ValidationResult{
 			Valid: false,
 			Error: "❌ Spotify の URL / ID として認識できませんでした。",
 		}
 	}
 
-	// パスからエンティティ種別とIDを抽出
-	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	// パスからエンティティ種別とIDを抽出（クエリパラメータは url.Parse で分離済み）
+	parts := entityPathParts(parsed.Path)
 	if len(parts) < 2 {
 		return ValidationResult{
 			Valid: false,
@@ -81,26 +150,8 @@ func validateURL(input string, expectedType EntityType) ValidationResult {
 		}
 	}
 
-	// intl-xx のようなロケールプレフィックスをスキップ
-	startIdx := 0
-	if strings.HasPrefix(parts[0], "intl-") && len(parts) >= 3 {
-		startIdx = 1
-	}
-
-	if len(parts) < startIdx+2 {
-		return ValidationResult{
-			Valid: false,
-			Error: "❌ Spotify の URL / ID として認識できませんでした。",
-		}
-	}
-
-	entityType := EntityType(parts[startIdx])
-	id := parts[startIdx+1]
-
-	// クエリパラメータを除去
-	if idx := strings.Index(id, "?"); idx != -1 {
-		id = id[:idx]
-	}
+	entityType := EntityType(parts[0])
+	id := parts[1]
 
 	// エンティティ種別チェック
 	if entityType != expectedType {
@@ -126,6 +177,16 @@ func validateURL(input string, expectedType EntityType) ValidationResult {
 	}
 }
 
+// entityPathParts はURLのパスを分割し、エンティティ種別より前のセグメントを除いて返します
+// intl-xx のようなロケールプレフィックスと、埋め込みプレイヤーの embed を読み飛ばします
+func entityPathParts(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for len(parts) >= 3 && (strings.HasPrefix(parts[0], "intl-") || parts[0] == "embed") {
+		parts = parts[1:]
+	}
+	return parts
+}
+
 // validateURI はURI形式の入力をバリデーションします
 func validateURI(input string, expectedType EntityType) ValidationResult {
 	parts := strings.Split(input, ":")
@@ -191,6 +252,8 @@ func getEntityMismatchError(expectedType EntityType) string {
 		return "❌ Spotify の ArtistURL を入力してください"
 	case EntityAlbum:
 		return "❌ Spotify の AlbumURL を入力してください"
+	case EntityPlaylist:
+		return "❌ Spotify の PlaylistURL を入力してください"
 	default:
 		return "❌ Spotify の URL / ID として認識できませんでした。"
 	}
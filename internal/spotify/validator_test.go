@@ -62,6 +62,14 @@ func TestValidateInput_ValidURL(t *testing.T) {
 			wantURL:      "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
 			wantID:       "4aawyAB9vmqN3uQ7FjRGTy",
 		},
+		{
+			name:         "playlist URL",
+			input:        "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=abc123",
+			expectedType: EntityPlaylist,
+			wantValid:    true,
+			wantURL:      "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			wantID:       "37i9dQZF1DXcBWIGoYBM5M",
+		},
 		{
 			name:         "URL with query params",
 			input:        "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc123",
@@ -102,6 +110,30 @@ func TestValidateInput_ValidURL(t *testing.T) {
 			wantURL:      "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
 			wantID:       "4aawyAB9vmqN3uQ7FjRGTy",
 		},
+		{
+			name:         "play.spotify.com URL",
+			input:        "https://play.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			expectedType: EntityTrack,
+			wantValid:    true,
+			wantURL:      "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			wantID:       "4iV5W9uYEdYUVa79Axb7Rh",
+		},
+		{
+			name:         "embed URL",
+			input:        "https://open.spotify.com/embed/album/4aawyAB9vmqN3uQ7FjRGTy?utm_source=generator&theme=0",
+			expectedType: EntityAlbum,
+			wantValid:    true,
+			wantURL:      "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+			wantID:       "4aawyAB9vmqN3uQ7FjRGTy",
+		},
+		{
+			name:         "expanded short link with tracking params",
+			input:        "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc123&context=spotify%3Aalbum%3Axyz&_branch_match_id=123",
+			expectedType: EntityTrack,
+			wantValid:    true,
+			wantURL:      "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			wantID:       "4iV5W9uYEdYUVa79Axb7Rh",
+		},
 		{
 			name:         "URL with whitespace",
 			input:        "  https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh  ",
@@ -317,6 +349,14 @@ func TestValidateInput_ValidRawID(t *testing.T) {
 			wantURL:      "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
 			wantID:       "4iV5W9uYEdYUVa79Axb7Rh",
 		},
+		{
+			name:         "ID with tracking param",
+			input:        "4iV5W9uYEdYUVa79Axb7Rh?si=abc123",
+			expectedType: EntityTrack,
+			wantValid:    true,
+			wantURL:      "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			wantID:       "4iV5W9uYEdYUVa79Axb7Rh",
+		},
 		{
 			name:         "valid 22-char ID for artist",
 			input:        "0OdUWJ0sBjDrqHygGUXeCF",
@@ -500,6 +540,7 @@ func TestGetEntityMismatchError(t *testing.T) {
 		{EntityTrack, "❌ Spotify の TrackURL を入力してください"},
 		{EntityArtist, "❌ Spotify の ArtistURL を入力してください"},
 		{EntityAlbum, "❌ Spotify の AlbumURL を入力してください"},
+		{EntityPlaylist, "❌ Spotify の PlaylistURL を入力してください"},
 		{EntityUnknown, "❌ Spotify の URL / ID として認識できませんでした。"},
 	}
 
@@ -540,3 +581,69 @@ func TestSpotifyIDRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectEntityType(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  EntityType
+	}{
+		{"track URL", "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", EntityTrack},
+		{"album URL with intl prefix", "https://open.spotify.com/intl-ja/album/4aawyAB9vmqN3uQ7FjRGTy", EntityAlbum},
+		{"playlist URL with query", "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=abc", EntityPlaylist},
+		{"artist URI", "spotify:artist:0OdUWJ0sBjDrqHygGUXeCF", EntityArtist},
+		{"playlist URI", " spotify:playlist:37i9dQZF1DXcBWIGoYBM5M ", EntityPlaylist},
+		{"play.spotify.com URL", "https://play.spotify.com/artist/0OdUWJ0sBjDrqHygGUXeCF", EntityArtist},
+		{"embed URL", "https://open.spotify.com/embed/playlist/37i9dQZF1DXcBWIGoYBM5M", EntityPlaylist},
+		{"raw ID", "4iV5W9uYEdYUVa79Axb7Rh", EntityUnknown},
+		{"other host", "https://example.com/album/4aawyAB9vmqN3uQ7FjRGTy", EntityUnknown},
+		{"malformed URI", "spotify:album", EntityUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectEntityType(tt.input); got != tt.want {
+				t.Errorf("DetectEntityType(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsShortLink(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"https://spotify.link/AbCdEf123", true},
+		{" https://spotify.app.link/AbCdEf123?_p=c ", true},
+		{"https://spotify.link/", false},
+		{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh", false},
+		{"https://example.com/AbCdEf123", false},
+		{"spotify.link/AbCdEf123", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsShortLink(tt.input); got != tt.want {
+			t.Errorf("IsShortLink(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIsEntityURL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc", true},
+		{"http://play.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy", true},
+		{"https://spotify.link/AbCdEf123", false},
+		{"https://example.com/track/4iV5W9uYEdYUVa79Axb7Rh", false},
+		{"spotify:track:4iV5W9uYEdYUVa79Axb7Rh", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsEntityURL(tt.input); got != tt.want {
+			t.Errorf("IsEntityURL(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package ttlcache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache は有効期限とエントリ数の上限を設けたインメモリのキャッシュです
+// 現在時刻は呼び出し元が渡します（テストで時刻を進められるようにするため）
+type Cache[V any] struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+// entry はキャッシュエントリです
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// New は新しい Cache を作成します
+func New[V any](ttl time.Duration, maxEntries int) *Cache[V] {
+	return &Cache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]entry[V]),
+	}
+}
+
+// Get は有効期限内の値を返します（期限切れのエントリは削除します）
+func (c *Cache[V]) Get(key string, now time.Time) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !now.Before(e.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set は値を now から TTL の期間保存します
+// 上限に達した場合は期限切れのエントリを削除し、それでも空きがなければ最も早く期限が切れるエントリを削除します
+func (c *Cache[V]) Set(key string, value V, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for len(c.entries) >= c.maxEntries {
+			c.deleteOldest()
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Len は保持しているエントリ数を返します（期限切れで未削除のものを含みます）
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// deleteOldest は最も早く期限が切れるエントリを削除します（呼び出し元がロックを保持していること）
+func (c *Cache[V]) deleteOldest() {
+	var oldest string
+	var oldestAt time.Time
+	for k, e := range c.entries {
+		if oldest == "" || e.expiresAt.Before(oldestAt) {
+			oldest, oldestAt = k, e.expiresAt
+		}
+	}
+	delete(c.entries, oldest)
+}
@@ -0,0 +1,58 @@
+package ttlcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	c := New[string](time.Minute, 2)
+
+	c.Set("a", "1", now)
+	if got, ok := c.Get("a", now.Add(time.Second)); !ok || got != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, true", got, ok)
+	}
+
+	// TTL を過ぎたエントリは返さず削除する
+	if _, ok := c.Get("a", now.Add(time.Minute)); ok {
+		t.Error("Get(a) after expiry should miss")
+	}
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len() = %d, want 0", got)
+	}
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	now := time.Now()
+	c := New[int](time.Minute, 2)
+
+	c.Set("a", 1, now)
+	c.Set("b", 2, now.Add(time.Second))
+	c.Set("c", 3, now.Add(2*time.Second))
+
+	// 最も早く期限が切れるエントリを削除する
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if _, ok := c.Get("a", now.Add(2*time.Second)); ok {
+		t.Error("oldest entry should be evicted")
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := c.Get(key, now.Add(2*time.Second)); !ok {
+			t.Errorf("Get(%s) missed, want hit", key)
+		}
+	}
+
+	// 期限切れのエントリを優先して削除する
+	c.Set("d", 4, now.Add(time.Minute+time.Second))
+	if _, ok := c.Get("c", now.Add(time.Minute+time.Second)); !ok {
+		t.Error("unexpired entry should be kept")
+	}
+
+	// 既存のキーの更新では削除しない
+	c.Set("c", 5, now.Add(time.Minute+time.Second))
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
@@ -10,7 +10,8 @@ import (
 
 // mockAlbumRepository はAlbumRepositoryのモック実装です
 type mockAlbumRepository struct {
-	fetchAlbumFunc func(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error)
+	fetchAlbumFunc   func(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error)
+	searchAlbumsFunc func(ctx context.Context, query string) ([]domain.Album, error)
 }
 
 func (m *mockAlbumRepository) FetchAlbum(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
@@ -20,6 +21,13 @@ func (m *mockAlbumRepository) FetchAlbum(ctx context.Context, spotifyURL string)
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockAlbumRepository) SearchAlbums(ctx context.Context, query string) ([]domain.Album, error) {
+	if m.searchAlbumsFunc != nil {
+		return m.searchAlbumsFunc(ctx, query)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestAlbumUseCase_GetAlbum(t *testing.T) {
 	tests := []struct {
 		name      string
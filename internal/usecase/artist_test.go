@@ -10,7 +10,8 @@ import (
 
 // mockArtistRepository はArtistRepositoryのモック実装です
 type mockArtistRepository struct {
-	fetchArtistFunc func(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error)
+	fetchArtistFunc   func(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error)
+	searchArtistsFunc func(ctx context.Context, query string) ([]domain.ArtistDetail, error)
 }
 
 func (m *mockArtistRepository) FetchArtist(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error) {
@@ -20,6 +21,13 @@ func (m *mockArtistRepository) FetchArtist(ctx context.Context, spotifyURL strin
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockArtistRepository) SearchArtists(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+	if m.searchArtistsFunc != nil {
+		return m.searchArtistsFunc(ctx, query)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestArtistUseCase_GetArtist(t *testing.T) {
 	tests := []struct {
 		name       string
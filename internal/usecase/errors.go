@@ -1,11 +1,21 @@
 package usecase
 
+import (
+	"errors"
+	"fmt"
+)
+
 // ValidationError はバリデーションエラーを表します
+// Message は書式文字列を兼ね、Args がある場合は整形して表示します（翻訳時のキーは整形前の Message です）
 type ValidationError struct {
 	Message string
+	Args    []any
 }
 
 func (e *ValidationError) Error() string {
+	if len(e.Args) > 0 {
+		return fmt.Sprintf(e.Message, e.Args...)
+	}
 	return e.Message
 }
 
@@ -18,14 +28,29 @@ func (e *NotFoundError) Error() string {
 	return e.Message
 }
 
-// IsValidationError はエラーがValidationErrorかどうかを判定します
+// StorageError はユーザーデータ（お気に入り・履歴）の保存・取得に失敗したエラーを表します
+// Message はユーザー向けのメッセージで、Err は元のエラーです
+type StorageError struct {
+	Message string
+	Err     error
+}
+
+func (e *StorageError) Error() string {
+	return e.Message
+}
+
+func (e *StorageError) Unwrap() error {
+	return e.Err
+}
+
+// IsValidationError はエラーがValidationErrorかどうかを判定します（ラップされたエラーも対象）
 func IsValidationError(err error) bool {
-	_, ok := err.(*ValidationError)
-	return ok
+	var target *ValidationError
+	return errors.As(err, &target)
 }
 
-// IsNotFoundError はエラーがNotFoundErrorかどうかを判定します
+// IsNotFoundError はエラーがNotFoundErrorかどうかを判定します（ラップされたエラーも対象）
 func IsNotFoundError(err error) bool {
-	_, ok := err.(*NotFoundError)
-	return ok
+	var target *NotFoundError
+	return errors.As(err, &target)
 }
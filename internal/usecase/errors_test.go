@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -84,6 +85,11 @@ func TestIsValidationError(t *testing.T) {
 			err:  errors.New("standard error"),
 			want: false,
 		},
+		{
+			name: "wrapped ValidationError",
+			err:  fmt.Errorf("wrapped: %w", &ValidationError{Message: "test"}),
+			want: true,
+		},
 		{
 			name: "nil error",
 			err:  nil,
@@ -121,6 +127,11 @@ func TestIsNotFoundError(t *testing.T) {
 			err:  errors.New("standard error"),
 			want: false,
 		},
+		{
+			name: "wrapped NotFoundError",
+			err:  fmt.Errorf("wrapped: %w", &NotFoundError{Message: "test"}),
+			want: true,
+		},
 		{
 			name: "nil error",
 			err:  nil,
@@ -136,3 +147,15 @@ func TestIsNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageError(t *testing.T) {
+	cause := errors.New("database is locked")
+	err := &StorageError{Message: "❌ お気に入りの保存に失敗しました。", Err: cause}
+
+	if err.Error() != "❌ お気に入りの保存に失敗しました。" {
+		t.Errorf("Error() = %v, want user-facing message", err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("StorageError should unwrap to the cause")
+	}
+}
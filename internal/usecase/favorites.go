@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+const (
+	// MaxFavorites はユーザーごとのお気に入り登録上限です
+	MaxFavorites = 100
+)
+
+// FavoritesUseCase はお気に入り関連のユースケースを提供します
+type FavoritesUseCase struct {
+	repo       domain.FavoritesRepository
+	trackRepo  domain.TrackRepository
+	artistRepo domain.ArtistRepository
+	albumRepo  domain.AlbumRepository
+}
+
+// NewFavoritesUseCase は新しいFavoritesUseCaseを作成します
+func NewFavoritesUseCase(repo domain.FavoritesRepository, trackRepo domain.TrackRepository, artistRepo domain.ArtistRepository, albumRepo domain.AlbumRepository) *FavoritesUseCase {
+	return &FavoritesUseCase{
+		repo:       repo,
+		trackRepo:  trackRepo,
+		artistRepo: artistRepo,
+		albumRepo:  albumRepo,
+	}
+}
+
+// FavoriteAddInput はお気に入り登録の入力パラメータです
+type FavoriteAddInput struct {
+	UserID     string
+	Input      string
+	EntityType spotify.EntityType // 生ID指定時の種別（省略時はトラック）
+}
+
+// FavoriteAddOutput はお気に入り登録の出力結果です
+type FavoriteAddOutput struct {
+	Favorite *domain.Favorite
+}
+
+// FavoriteListOutput はお気に入り一覧の出力結果です
+type FavoriteListOutput struct {
+	Favorites []domain.Favorite
+}
+
+// FavoriteRemoveInput はお気に入り削除の入力パラメータです
+type FavoriteRemoveInput struct {
+	UserID string
+	Input  string
+}
+
+// AddFavorite はトラック/アーティスト/アルバムをお気に入りに登録します
+func (u *FavoritesUseCase) AddFavorite(ctx context.Context, input FavoriteAddInput) (*FavoriteAddOutput, error) {
+	entityType := resolveEntityType(input.Input, input.EntityType)
+	if !isFavoritableType(entityType) {
+		slog.Info("validation failed: unsupported entity type", "usecase", "favorites", "input", input.Input, "type", entityType)
+		return nil, &ValidationError{Message: "❌ お気に入りに登録できるのはトラック・アーティスト・アルバムのみです。"}
+	}
+
+	result := spotify.ValidateInput(input.Input, entityType)
+	if !result.Valid {
+		slog.Info("validation failed", "usecase", "favorites", "input", input.Input, "error", result.Error)
+		return nil, &ValidationError{Message: result.Error}
+	}
+
+	// 上限に達している場合は対象を取得する前に断る（上限の確定は登録時にリポジトリで行う）
+	count, err := u.repo.CountFavorites(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to count favorites", "usecase", "favorites", "user_id", input.UserID, "error", err)
+		return nil, &StorageError{Message: "❌ お気に入りの保存に失敗しました。", Err: err}
+	}
+	if count >= MaxFavorites {
+		return nil, favoriteLimitError()
+	}
+
+	fav, err := u.buildFavorite(ctx, input.UserID, result)
+	if err != nil {
+		slog.Warn("favorite target fetch failed", "usecase", "favorites", "url", result.URL, "error", err)
+		return nil, err
+	}
+
+	if err := u.repo.AddFavorite(ctx, fav, MaxFavorites); err != nil {
+		if errors.Is(err, domain.ErrFavoriteExists) {
+			return nil, &ValidationError{Message: "⭐ すでにお気に入りに登録されています。"}
+		}
+		if errors.Is(err, domain.ErrFavoriteLimitReached) {
+			return nil, favoriteLimitError()
+		}
+		slog.Error("failed to add favorite", "usecase", "favorites", "user_id", input.UserID, "error", err)
+		return nil, &StorageError{Message: "❌ お気に入りの保存に失敗しました。", Err: err}
+	}
+
+	slog.Info("favorite added", "usecase", "favorites", "user_id", input.UserID, "type", fav.EntityType, "spotify_id", fav.SpotifyID)
+
+	return &FavoriteAddOutput{Favorite: fav}, nil
+}
+
+// favoriteLimitError はお気に入りの登録上限に達したことを表すエラーを返します
+func favoriteLimitError() error {
+	return &ValidationError{Message: "❌ お気に入りは最大 %d 件までです。不要なものを削除してください。", Args: []any{MaxFavorites}}
+}
+
+// ListFavorites はユーザーのお気に入り一覧を取得します
+func (u *FavoritesUseCase) ListFavorites(ctx context.Context, userID string) (*FavoriteListOutput, error) {
+	favorites, err := u.repo.ListFavorites(ctx, userID)
+	if err != nil {
+		slog.Error("failed to list favorites", "usecase", "favorites", "user_id", userID, "error", err)
+		return nil, &StorageError{Message: "❌ お気に入りの取得に失敗しました。", Err: err}
+	}
+
+	if len(favorites) == 0 {
+		return nil, &NotFoundError{Message: "⭐ お気に入りはまだ登録されていません。"}
+	}
+
+	return &FavoriteListOutput{Favorites: favorites}, nil
+}
+
+// RemoveFavorite はお気に入りを削除します
+func (u *FavoritesUseCase) RemoveFavorite(ctx context.Context, input FavoriteRemoveInput) error {
+	result := spotify.ValidateInput(input.Input, resolveEntityType(input.Input, spotify.EntityTrack))
+	if !result.Valid {
+		slog.Info("validation failed", "usecase", "favorites", "input", input.Input, "error", result.Error)
+		return &ValidationError{Message: result.Error}
+	}
+
+	if err := u.repo.RemoveFavorite(ctx, input.UserID, result.ID); err != nil {
+		if errors.Is(err, domain.ErrFavoriteNotFound) {
+			return &NotFoundError{Message: "🔍 お気に入りに登録されていません。"}
+		}
+		slog.Error("failed to remove favorite", "usecase", "favorites", "user_id", input.UserID, "error", err)
+		return &StorageError{Message: "❌ お気に入りの削除に失敗しました。", Err: err}
+	}
+
+	slog.Info("favorite removed", "usecase", "favorites", "user_id", input.UserID, "spotify_id", result.ID)
+
+	return nil
+}
+
+// buildFavorite はエンティティ情報を取得してお気に入りを構築します
+func (u *FavoritesUseCase) buildFavorite(ctx context.Context, userID string, result spotify.ValidationResult) (*domain.Favorite, error) {
+	fav := &domain.Favorite{
+		UserID:     userID,
+		EntityType: string(result.EntityType),
+		SpotifyID:  result.ID,
+		URL:        result.URL,
+		CreatedAt:  time.Now(),
+	}
+
+	switch result.EntityType {
+	case spotify.EntityTrack:
+		track, err := u.trackRepo.FetchTrack(ctx, result.URL)
+		if err != nil {
+			return nil, err
+		}
+		fav.Name = track.Name
+		fav.Subtitle = joinArtistNames(track.Artists)
+	case spotify.EntityArtist:
+		artist, err := u.artistRepo.FetchArtist(ctx, result.URL)
+		if err != nil {
+			return nil, err
+		}
+		fav.Name = artist.Name
+	case spotify.EntityAlbum:
+		album, err := u.albumRepo.FetchAlbum(ctx, result.URL)
+		if err != nil {
+			return nil, err
+		}
+		fav.Name = album.Name
+		fav.Subtitle = joinArtistNames(album.Artists)
+	}
+
+	return fav, nil
+}
+
+// resolveEntityType は入力から種別を判定し、判定できない場合（生IDなど）は fallback を返します
+func resolveEntityType(input string, fallback spotify.EntityType) spotify.EntityType {
+	if t := spotify.DetectEntityType(input); t != spotify.EntityUnknown {
+		return t
+	}
+	if fallback == "" {
+		return spotify.EntityTrack
+	}
+	return fallback
+}
+
+// isFavoritableType はお気に入りに登録できる種別かどうかを返します
+func isFavoritableType(t spotify.EntityType) bool {
+	return t == spotify.EntityTrack || t == spotify.EntityArtist || t == spotify.EntityAlbum
+}
+
+// joinArtistNames はアーティスト名をカンマ区切りで結合します
+func joinArtistNames(artists []domain.Artist) string {
+	names := make([]string, len(artists))
+	for i, a := range artists {
+		names[i] = a.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+// mockFavoritesRepository はFavoritesRepositoryのインメモリモック実装です
+type mockFavoritesRepository struct {
+	favorites []domain.Favorite
+	err       error
+	addErr    error // AddFavorite のみが返すエラー
+}
+
+func (m *mockFavoritesRepository) AddFavorite(ctx context.Context, fav *domain.Favorite, limit int) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.addErr != nil {
+		return m.addErr
+	}
+	count := 0
+	for _, f := range m.favorites {
+		if f.UserID == fav.UserID && f.SpotifyID == fav.SpotifyID {
+			return domain.ErrFavoriteExists
+		}
+		if f.UserID == fav.UserID {
+			count++
+		}
+	}
+	if count >= limit {
+		return domain.ErrFavoriteLimitReached
+	}
+	m.favorites = append(m.favorites, *fav)
+	return nil
+}
+
+func (m *mockFavoritesRepository) ListFavorites(ctx context.Context, userID string) ([]domain.Favorite, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []domain.Favorite
+	for _, f := range m.favorites {
+		if f.UserID == userID {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockFavoritesRepository) CountFavorites(ctx context.Context, userID string) (int, error) {
+	favorites, err := m.ListFavorites(ctx, userID)
+	return len(favorites), err
+}
+
+func (m *mockFavoritesRepository) RemoveFavorite(ctx context.Context, userID, spotifyID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i, f := range m.favorites {
+		if f.UserID == userID && f.SpotifyID == spotifyID {
+			m.favorites = append(m.favorites[:i], m.favorites[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrFavoriteNotFound
+}
+
+func newTestFavoritesUseCase(repo *mockFavoritesRepository) *FavoritesUseCase {
+	trackRepo := &mockTrackRepository{
+		fetchTrackFunc: func(ctx context.Context, spotifyURL string) (*domain.Track, error) {
+			return &domain.Track{
+				ID:      "4iV5W9uYEdYUVa79Axb7Rh",
+				Name:    "Test Track",
+				Artists: []domain.Artist{{Name: "Artist A"}, {Name: "Artist B"}},
+			}, nil
+		},
+	}
+	artistRepo := &mockArtistRepository{
+		fetchArtistFunc: func(ctx context.Context, spotifyURL string) (*domain.ArtistDetail, error) {
+			return &domain.ArtistDetail{ID: "0OdUWJ0sBjDrqHygGUXeCF", Name: "Test Artist"}, nil
+		},
+	}
+	albumRepo := &mockAlbumRepository{
+		fetchAlbumFunc: func(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
+			return &domain.AlbumDetail{
+				ID:      "0sNOF9WDwhWunNAHPD3Baj",
+				Name:    "Test Album",
+				Artists: []domain.Artist{{Name: "Album Artist"}},
+			}, nil
+		},
+	}
+	return NewFavoritesUseCase(repo, trackRepo, artistRepo, albumRepo)
+}
+
+func TestFavoritesUseCase_AddFavorite(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     []domain.Favorite
+		repoErr      error
+		input        FavoriteAddInput
+		wantErr      bool
+		errType      string
+		wantType     string
+		wantName     string
+		wantSubtitle string
+	}{
+		{
+			name:         "track URL",
+			input:        FavoriteAddInput{UserID: "user1", Input: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh"},
+			wantType:     "track",
+			wantName:     "Test Track",
+			wantSubtitle: "Artist A, Artist B",
+		},
+		{
+			name:     "artist URI",
+			input:    FavoriteAddInput{UserID: "user1", Input: "spotify:artist:0OdUWJ0sBjDrqHygGUXeCF"},
+			wantType: "artist",
+			wantName: "Test Artist",
+		},
+		{
+			name:         "raw ID with album type",
+			input:        FavoriteAddInput{UserID: "user1", Input: "0sNOF9WDwhWunNAHPD3Baj", EntityType: spotify.EntityAlbum},
+			wantType:     "album",
+			wantName:     "Test Album",
+			wantSubtitle: "Album Artist",
+		},
+		{
+			name:     "raw ID defaults to track",
+			input:    FavoriteAddInput{UserID: "user1", Input: "4iV5W9uYEdYUVa79Axb7Rh"},
+			wantType: "track",
+			wantName: "Test Track",
+		},
+		{
+			name:    "playlist is not supported",
+			input:   FavoriteAddInput{UserID: "user1", Input: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M"},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name:    "invalid input",
+			input:   FavoriteAddInput{UserID: "user1", Input: "https://example.com/track/xxx"},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name:     "already exists",
+			existing: []domain.Favorite{{UserID: "user1", SpotifyID: "4iV5W9uYEdYUVa79Axb7Rh"}},
+			input:    FavoriteAddInput{UserID: "user1", Input: "4iV5W9uYEdYUVa79Axb7Rh"},
+			wantErr:  true,
+			errType:  "validation",
+		},
+		{
+			name:    "repository error",
+			repoErr: errors.New("db error"),
+			input:   FavoriteAddInput{UserID: "user1", Input: "4iV5W9uYEdYUVa79Axb7Rh"},
+			wantErr: true,
+			errType: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFavoritesRepository{favorites: tt.existing, err: tt.repoErr}
+			uc := newTestFavoritesUseCase(repo)
+
+			output, err := uc.AddFavorite(context.Background(), tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+				if tt.errType == "validation" && !IsValidationError(err) {
+					t.Errorf("expected ValidationError but got %T", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.Favorite.EntityType != tt.wantType {
+				t.Errorf("EntityType = %v, want %v", output.Favorite.EntityType, tt.wantType)
+			}
+			if output.Favorite.Name != tt.wantName {
+				t.Errorf("Name = %v, want %v", output.Favorite.Name, tt.wantName)
+			}
+			if tt.wantSubtitle != "" && output.Favorite.Subtitle != tt.wantSubtitle {
+				t.Errorf("Subtitle = %v, want %v", output.Favorite.Subtitle, tt.wantSubtitle)
+			}
+			if len(repo.favorites) != len(tt.existing)+1 {
+				t.Errorf("stored favorites = %d, want %d", len(repo.favorites), len(tt.existing)+1)
+			}
+		})
+	}
+}
+
+func TestFavoritesUseCase_AddFavorite_Limit(t *testing.T) {
+	existing := make([]domain.Favorite, MaxFavorites)
+	for i := range existing {
+		existing[i] = domain.Favorite{UserID: "user1", SpotifyID: fmt.Sprintf("id%d", i)}
+	}
+	repo := &mockFavoritesRepository{favorites: existing}
+	uc := newTestFavoritesUseCase(repo)
+
+	_, err := uc.AddFavorite(context.Background(), FavoriteAddInput{UserID: "user1", Input: "4iV5W9uYEdYUVa79Axb7Rh"})
+	if !IsValidationError(err) {
+		t.Fatalf("expected ValidationError but got %v", err)
+	}
+}
+
+func TestFavoritesUseCase_AddFavorite_LimitReachedOnInsert(t *testing.T) {
+	// 件数の確認後、登録までの間に他の登録で上限に達した場合
+	repo := &mockFavoritesRepository{addErr: domain.ErrFavoriteLimitReached}
+	uc := newTestFavoritesUseCase(repo)
+
+	_, err := uc.AddFavorite(context.Background(), FavoriteAddInput{UserID: "user1", Input: "4iV5W9uYEdYUVa79Axb7Rh"})
+	if !IsValidationError(err) {
+		t.Fatalf("expected ValidationError but got %v", err)
+	}
+}
+
+func TestFavoritesUseCase_ListFavorites(t *testing.T) {
+	repo := &mockFavoritesRepository{favorites: []domain.Favorite{
+		{UserID: "user1", SpotifyID: "a", Name: "A"},
+		{UserID: "user2", SpotifyID: "b", Name: "B"},
+	}}
+	uc := newTestFavoritesUseCase(repo)
+
+	output, err := uc.ListFavorites(context.Background(), "user1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(output.Favorites) != 1 || output.Favorites[0].Name != "A" {
+		t.Errorf("Favorites = %+v, want only A", output.Favorites)
+	}
+
+	_, err = uc.ListFavorites(context.Background(), "user3")
+	if !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError but got %v", err)
+	}
+}
+
+func TestFavoritesUseCase_RemoveFavorite(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   FavoriteRemoveInput
+		wantErr bool
+		errType string
+	}{
+		{
+			name:  "remove by URL",
+			input: FavoriteRemoveInput{UserID: "user1", Input: "https://open.spotify.com/album/0sNOF9WDwhWunNAHPD3Baj"},
+		},
+		{
+			name:  "remove by raw ID",
+			input: FavoriteRemoveInput{UserID: "user1", Input: "0sNOF9WDwhWunNAHPD3Baj"},
+		},
+		{
+			name:    "not registered",
+			input:   FavoriteRemoveInput{UserID: "user2", Input: "0sNOF9WDwhWunNAHPD3Baj"},
+			wantErr: true,
+			errType: "not_found",
+		},
+		{
+			name:    "invalid input",
+			input:   FavoriteRemoveInput{UserID: "user1", Input: "invalid"},
+			wantErr: true,
+			errType: "validation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFavoritesRepository{favorites: []domain.Favorite{
+				{UserID: "user1", EntityType: "album", SpotifyID: "0sNOF9WDwhWunNAHPD3Baj"},
+			}}
+			uc := newTestFavoritesUseCase(repo)
+
+			err := uc.RemoveFavorite(context.Background(), tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+				if tt.errType == "validation" && !IsValidationError(err) {
+					t.Errorf("expected ValidationError but got %T", err)
+				}
+				if tt.errType == "not_found" && !IsNotFoundError(err) {
+					t.Errorf("expected NotFoundError but got %T", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.favorites) != 0 {
+				t.Errorf("favorite was not removed: %+v", repo.favorites)
+			}
+		})
+	}
+}
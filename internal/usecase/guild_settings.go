@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/i18n"
+)
+
+const (
+	// MinPageSize はギルドで設定できる1ページあたりの表示件数の下限です
+	MinPageSize = 1
+	// MaxPageSize はギルドで設定できる1ページあたりの表示件数の上限です
+	MaxPageSize = 10
+	// MaxAllowedChannels はギルドごとの許可チャンネルの登録上限です
+	MaxAllowedChannels = 25
+)
+
+// GuildSettingsUseCase はギルドごとの設定関連のユースケースを提供します
+// 設定はインタラクションごとに参照されるため、取得結果をインメモリにキャッシュし、更新時に差し替えます
+type GuildSettingsUseCase struct {
+	repo  domain.GuildSettingsRepository
+	mu    sync.RWMutex
+	cache map[string]domain.GuildSettings
+}
+
+// NewGuildSettingsUseCase は新しいGuildSettingsUseCaseを作成します
+func NewGuildSettingsUseCase(repo domain.GuildSettingsRepository) *GuildSettingsUseCase {
+	return &GuildSettingsUseCase{
+		repo:  repo,
+		cache: make(map[string]domain.GuildSettings),
+	}
+}
+
+// Get はギルドの設定を取得します
+// 未保存のギルドやDM（guildID が空）の場合は、すべて未設定の設定を返します
+func (u *GuildSettingsUseCase) Get(ctx context.Context, guildID string) (*domain.GuildSettings, error) {
+	if guildID == "" {
+		return &domain.GuildSettings{}, nil
+	}
+
+	u.mu.RLock()
+	cached, ok := u.cache[guildID]
+	u.mu.RUnlock()
+	if ok {
+		return cloneGuildSettings(cached), nil
+	}
+
+	settings, err := u.repo.GetGuildSettings(ctx, guildID)
+	if errors.Is(err, domain.ErrGuildSettingsNotFound) {
+		settings = &domain.GuildSettings{GuildID: guildID}
+	} else if err != nil {
+		slog.Error("failed to get guild settings", "usecase", "guild_settings", "guild_id", guildID, "error", err)
+		return nil, &StorageError{Message: "❌ サーバー設定の取得に失敗しました。", Err: err}
+	}
+
+	// 取得中に更新された場合は更新後の設定を優先する
+	u.mu.Lock()
+	if cached, ok := u.cache[guildID]; ok {
+		settings = &cached
+	} else {
+		u.cache[guildID] = *settings
+	}
+	u.mu.Unlock()
+
+	return cloneGuildSettings(*settings), nil
+}
+
+// SetRecommendMode はレコメンドのデフォルトモードを設定します
+func (u *GuildSettingsUseCase) SetRecommendMode(ctx context.Context, guildID string, mode domain.RecommendMode) (*domain.GuildSettings, error) {
+	switch mode {
+	case domain.RecommendModeSimilar, domain.RecommendModeRelated, domain.RecommendModeBalanced:
+	default:
+		return nil, &ValidationError{Message: "❌ レコメンドモードは similar / related / balanced のいずれかを指定してください。"}
+	}
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.RecommendMode = mode
+		return nil
+	})
+}
+
+// SetPageSize は一覧の1ページあたりの表示件数を設定します
+func (u *GuildSettingsUseCase) SetPageSize(ctx context.Context, guildID string, size int) (*domain.GuildSettings, error) {
+	if size < MinPageSize || size > MaxPageSize {
+		return nil, &ValidationError{Message: "❌ 表示件数は %d〜%d 件の範囲で指定してください。", Args: []any{MinPageSize, MaxPageSize}}
+	}
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.PageSize = size
+		return nil
+	})
+}
+
+// SetAutoLink はリンク自動展開の有効/無効を設定します
+func (u *GuildSettingsUseCase) SetAutoLink(ctx context.Context, guildID string, enabled bool) (*domain.GuildSettings, error) {
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.AutoLink = &enabled
+		return nil
+	})
+}
+
+// SetLocale は応答言語を設定します（空文字の場合は実行者のDiscordの言語に従う設定に戻します）
+func (u *GuildSettingsUseCase) SetLocale(ctx context.Context, guildID, locale string) (*domain.GuildSettings, error) {
+	if locale != "" {
+		loc, ok := i18n.Parse(locale)
+		if !ok {
+			return nil, &ValidationError{Message: "❌ 言語は ja / en のいずれかを指定してください。"}
+		}
+		locale = string(loc)
+	}
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.Locale = locale
+		return nil
+	})
+}
+
+// SetDetailVisibility は検索・レコメンドの一覧から選択した項目の詳細の表示範囲を設定します
+func (u *GuildSettingsUseCase) SetDetailVisibility(ctx context.Context, guildID string, visibility domain.DetailVisibility) (*domain.GuildSettings, error) {
+	switch visibility {
+	case domain.DetailVisibilityEphemeral, domain.DetailVisibilityPublic:
+	default:
+		return nil, &ValidationError{Message: "❌ 詳細の表示範囲は ephemeral / public のいずれかを指定してください。"}
+	}
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.DetailVisibility = visibility
+		return nil
+	})
+}
+
+// AllowChannel はコマンドを利用できるチャンネルを追加します
+func (u *GuildSettingsUseCase) AllowChannel(ctx context.Context, guildID, channelID string) (*domain.GuildSettings, error) {
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		if slices.Contains(s.AllowedChannels, channelID) {
+			return &ValidationError{Message: "ℹ️ このチャンネルはすでに許可されています。"}
+		}
+		if len(s.AllowedChannels) >= MaxAllowedChannels {
+			return &ValidationError{Message: "❌ 許可チャンネルは最大 %d 件までです。", Args: []any{MaxAllowedChannels}}
+		}
+		s.AllowedChannels = append(s.AllowedChannels, channelID)
+		return nil
+	})
+}
+
+// DisallowChannel はコマンドを利用できるチャンネルから削除します
+func (u *GuildSettingsUseCase) DisallowChannel(ctx context.Context, guildID, channelID string) (*domain.GuildSettings, error) {
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		idx := slices.Index(s.AllowedChannels, channelID)
+		if idx < 0 {
+			return &NotFoundError{Message: "🔍 このチャンネルは許可リストに登録されていません。"}
+		}
+		s.AllowedChannels = slices.Delete(s.AllowedChannels, idx, idx+1)
+		return nil
+	})
+}
+
+// ClearAllowedChannels は許可チャンネルをすべて削除し、全チャンネルで利用できるようにします
+func (u *GuildSettingsUseCase) ClearAllowedChannels(ctx context.Context, guildID string) (*domain.GuildSettings, error) {
+	return u.update(ctx, guildID, func(s *domain.GuildSettings) error {
+		s.AllowedChannels = nil
+		return nil
+	})
+}
+
+// Reset はギルドの設定をすべて削除し、デフォルトに戻します
+func (u *GuildSettingsUseCase) Reset(ctx context.Context, guildID string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.repo.DeleteGuildSettings(ctx, guildID); err != nil {
+		slog.Error("failed to reset guild settings", "usecase", "guild_settings", "guild_id", guildID, "error", err)
+		return &StorageError{Message: "❌ サーバー設定の保存に失敗しました。", Err: err}
+	}
+	u.cache[guildID] = domain.GuildSettings{GuildID: guildID}
+
+	slog.Info("guild settings reset", "usecase", "guild_settings", "guild_id", guildID)
+	return nil
+}
+
+// update は現在の設定に変更を適用して保存します
+// 同じギルドの設定を同時に更新した場合に変更が失われないよう、読み込みから保存までを直列化します
+func (u *GuildSettingsUseCase) update(ctx context.Context, guildID string, apply func(*domain.GuildSettings) error) (*domain.GuildSettings, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var settings *domain.GuildSettings
+	if cached, ok := u.cache[guildID]; ok {
+		settings = cloneGuildSettings(cached)
+	} else {
+		stored, err := u.repo.GetGuildSettings(ctx, guildID)
+		switch {
+		case errors.Is(err, domain.ErrGuildSettingsNotFound):
+			settings = &domain.GuildSettings{GuildID: guildID}
+		case err != nil:
+			slog.Error("failed to get guild settings", "usecase", "guild_settings", "guild_id", guildID, "error", err)
+			return nil, &StorageError{Message: "❌ サーバー設定の取得に失敗しました。", Err: err}
+		default:
+			settings = stored
+		}
+	}
+
+	if err := apply(settings); err != nil {
+		return nil, err
+	}
+	settings.UpdatedAt = time.Now()
+
+	if err := u.repo.SaveGuildSettings(ctx, settings); err != nil {
+		slog.Error("failed to save guild settings", "usecase", "guild_settings", "guild_id", guildID, "error", err)
+		return nil, &StorageError{Message: "❌ サーバー設定の保存に失敗しました。", Err: err}
+	}
+	u.cache[guildID] = *cloneGuildSettings(*settings)
+
+	slog.Info("guild settings updated", "usecase", "guild_settings", "guild_id", guildID,
+		"recommend_mode", settings.RecommendMode,
+		"page_size", settings.PageSize,
+		"locale", settings.Locale,
+		"allowed_channels", len(settings.AllowedChannels),
+		"detail_visibility", settings.DetailVisibility,
+	)
+	return settings, nil
+}
+
+// cloneGuildSettings は呼び出し元の変更がキャッシュに影響しないよう設定を複製します
+func cloneGuildSettings(settings domain.GuildSettings) *domain.GuildSettings {
+	settings.AllowedChannels = slices.Clone(settings.AllowedChannels)
+	if settings.AutoLink != nil {
+		autoLink := *settings.AutoLink
+		settings.AutoLink = &autoLink
+	}
+	return &settings
+}
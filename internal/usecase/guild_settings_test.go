@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// mockGuildSettingsRepository はGuildSettingsRepositoryのインメモリモック実装です
+type mockGuildSettingsRepository struct {
+	settings map[string]domain.GuildSettings
+	gets     int
+	err      error
+}
+
+func newMockGuildSettingsRepository() *mockGuildSettingsRepository {
+	return &mockGuildSettingsRepository{settings: make(map[string]domain.GuildSettings)}
+}
+
+func (m *mockGuildSettingsRepository) GetGuildSettings(ctx context.Context, guildID string) (*domain.GuildSettings, error) {
+	m.gets++
+	if m.err != nil {
+		return nil, m.err
+	}
+	s, ok := m.settings[guildID]
+	if !ok {
+		return nil, domain.ErrGuildSettingsNotFound
+	}
+	s.AllowedChannels = slices.Clone(s.AllowedChannels)
+	return &s, nil
+}
+
+func (m *mockGuildSettingsRepository) SaveGuildSettings(ctx context.Context, settings *domain.GuildSettings) error {
+	if m.err != nil {
+		return m.err
+	}
+	s := *settings
+	s.AllowedChannels = slices.Clone(s.AllowedChannels)
+	m.settings[settings.GuildID] = s
+	return nil
+}
+
+func (m *mockGuildSettingsRepository) DeleteGuildSettings(ctx context.Context, guildID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.settings, guildID)
+	return nil
+}
+
+func TestGuildSettingsUseCase_Get(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockGuildSettingsRepository()
+	repo.settings["guild1"] = domain.GuildSettings{GuildID: "guild1", PageSize: 8, AllowedChannels: []string{"ch1"}}
+	uc := NewGuildSettingsUseCase(repo)
+
+	got, err := uc.Get(ctx, "guild1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.PageSize != 8 {
+		t.Errorf("Get() PageSize = %d, want 8", got.PageSize)
+	}
+
+	// 返り値を変更してもキャッシュに影響せず、2回目はリポジトリを参照しない
+	got.AllowedChannels[0] = "changed"
+	again, err := uc.Get(ctx, "guild1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if again.AllowedChannels[0] != "ch1" {
+		t.Errorf("Get() AllowedChannels = %v, want [ch1]", again.AllowedChannels)
+	}
+	if repo.gets != 1 {
+		t.Errorf("repository gets = %d, want 1 (cached)", repo.gets)
+	}
+
+	// 未保存のギルドは未設定の設定を返す
+	empty, err := uc.Get(ctx, "guild2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if empty.GuildID != "guild2" || empty.PageSize != 0 || empty.AutoLink != nil || !empty.ChannelAllowed("any") {
+		t.Errorf("Get() unsaved guild = %+v, want empty settings", empty)
+	}
+
+	// DM はリポジトリを参照しない
+	if _, err := uc.Get(ctx, ""); err != nil {
+		t.Errorf("Get(\"\") error = %v", err)
+	}
+	if repo.gets != 2 {
+		t.Errorf("repository gets = %d, want 2", repo.gets)
+	}
+}
+
+func TestGuildSettingsUseCase_Get_StorageError(t *testing.T) {
+	repo := newMockGuildSettingsRepository()
+	repo.err = errors.New("disk I/O error")
+	uc := NewGuildSettingsUseCase(repo)
+
+	_, err := uc.Get(context.Background(), "guild1")
+	var storageErr *StorageError
+	if !errors.As(err, &storageErr) {
+		t.Fatalf("Get() error = %v, want StorageError", err)
+	}
+}
+
+func TestGuildSettingsUseCase_Update(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		update    func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error)
+		check     func(t *testing.T, s *domain.GuildSettings)
+		wantError func(error) bool
+	}{
+		{
+			name: "recommend mode",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetRecommendMode(ctx, "guild1", domain.RecommendModeRelated)
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.RecommendMode != domain.RecommendModeRelated {
+					t.Errorf("RecommendMode = %q, want related", s.RecommendMode)
+				}
+			},
+		},
+		{
+			name: "invalid recommend mode",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetRecommendMode(ctx, "guild1", "unknown")
+			},
+			wantError: IsValidationError,
+		},
+		{
+			name: "page size",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetPageSize(ctx, "guild1", MaxPageSize)
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.PageSize != MaxPageSize {
+					t.Errorf("PageSize = %d, want %d", s.PageSize, MaxPageSize)
+				}
+			},
+		},
+		{
+			name: "page size out of range",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetPageSize(ctx, "guild1", MaxPageSize+1)
+			},
+			wantError: IsValidationError,
+		},
+		{
+			name: "auto link",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetAutoLink(ctx, "guild1", false)
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.AutoLink == nil || *s.AutoLink {
+					t.Errorf("AutoLink = %v, want false", s.AutoLink)
+				}
+			},
+		},
+		{
+			name: "locale is normalized",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetLocale(ctx, "guild1", "en-US")
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.Locale != "en" {
+					t.Errorf("Locale = %q, want en", s.Locale)
+				}
+			},
+		},
+		{
+			name: "unsupported locale",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetLocale(ctx, "guild1", "fr")
+			},
+			wantError: IsValidationError,
+		},
+		{
+			name: "detail visibility",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetDetailVisibility(ctx, "guild1", domain.DetailVisibilityPublic)
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.DetailVisibility != domain.DetailVisibilityPublic {
+					t.Errorf("DetailVisibility = %q, want public", s.DetailVisibility)
+				}
+			},
+		},
+		{
+			name: "invalid detail visibility",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.SetDetailVisibility(ctx, "guild1", "everyone")
+			},
+			wantError: IsValidationError,
+		},
+		{
+			name: "allow channel",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.AllowChannel(ctx, "guild1", "ch2")
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if !slices.Equal(s.AllowedChannels, []string{"ch1", "ch2"}) {
+					t.Errorf("AllowedChannels = %v, want [ch1 ch2]", s.AllowedChannels)
+				}
+			},
+		},
+		{
+			name: "allow duplicate channel",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.AllowChannel(ctx, "guild1", "ch1")
+			},
+			wantError: IsValidationError,
+		},
+		{
+			name: "disallow channel",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.DisallowChannel(ctx, "guild1", "ch1")
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if len(s.AllowedChannels) != 0 || !s.ChannelAllowed("other") {
+					t.Errorf("AllowedChannels = %v, want empty", s.AllowedChannels)
+				}
+			},
+		},
+		{
+			name: "disallow unknown channel",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.DisallowChannel(ctx, "guild1", "ch9")
+			},
+			wantError: IsNotFoundError,
+		},
+		{
+			name: "clear channels",
+			update: func(uc *GuildSettingsUseCase) (*domain.GuildSettings, error) {
+				return uc.ClearAllowedChannels(ctx, "guild1")
+			},
+			check: func(t *testing.T, s *domain.GuildSettings) {
+				if s.AllowedChannels != nil {
+					t.Errorf("AllowedChannels = %v, want nil", s.AllowedChannels)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockGuildSettingsRepository()
+			repo.settings["guild1"] = domain.GuildSettings{GuildID: "guild1", PageSize: 3, AllowedChannels: []string{"ch1"}}
+			uc := NewGuildSettingsUseCase(repo)
+
+			got, err := tt.update(uc)
+			if tt.wantError != nil {
+				if !tt.wantError(err) {
+					t.Fatalf("error = %v, want typed error", err)
+				}
+				if repo.settings["guild1"].PageSize != 3 {
+					t.Error("settings should not be saved on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			tt.check(t, got)
+
+			// 保存され、キャッシュ経由の取得にも反映されている
+			saved := repo.settings["guild1"]
+			tt.check(t, &saved)
+			cached, err := uc.Get(ctx, "guild1")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			tt.check(t, cached)
+			if saved.UpdatedAt.IsZero() {
+				t.Error("UpdatedAt should be set")
+			}
+		})
+	}
+}
+
+func TestGuildSettingsUseCase_AllowChannel_Limit(t *testing.T) {
+	ctx := context.Background()
+	uc := NewGuildSettingsUseCase(newMockGuildSettingsRepository())
+
+	for i := range MaxAllowedChannels {
+		if _, err := uc.AllowChannel(ctx, "guild1", string(rune('a'+i))); err != nil {
+			t.Fatalf("AllowChannel() #%d error = %v", i, err)
+		}
+	}
+	if _, err := uc.AllowChannel(ctx, "guild1", "overflow"); !IsValidationError(err) {
+		t.Errorf("AllowChannel() over limit error = %v, want ValidationError", err)
+	}
+}
+
+func TestGuildSettingsUseCase_Reset(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockGuildSettingsRepository()
+	uc := NewGuildSettingsUseCase(repo)
+
+	if _, err := uc.SetPageSize(ctx, "guild1", 7); err != nil {
+		t.Fatalf("SetPageSize() error = %v", err)
+	}
+	if err := uc.Reset(ctx, "guild1"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if _, ok := repo.settings["guild1"]; ok {
+		t.Error("settings should be deleted from repository")
+	}
+	got, err := uc.Get(ctx, "guild1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.PageSize != 0 {
+		t.Errorf("Get() after reset PageSize = %d, want 0", got.PageSize)
+	}
+}
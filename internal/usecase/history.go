@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+const (
+	// DefaultHistoryRetention はユーザーごとに保持する履歴件数のデフォルト値です
+	DefaultHistoryRetention = 50
+)
+
+// HistoryUseCase はコマンド実行履歴関連のユースケースを提供します
+type HistoryUseCase struct {
+	repo      domain.HistoryRepository
+	retention int
+}
+
+// NewHistoryUseCase は新しいHistoryUseCaseを作成します
+// retention はユーザーごとに保持する履歴件数です（0以下の場合はデフォルト値）
+func NewHistoryUseCase(repo domain.HistoryRepository, retention int) *HistoryUseCase {
+	if retention <= 0 {
+		retention = DefaultHistoryRetention
+	}
+	return &HistoryUseCase{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// HistoryRecordInput は履歴記録の入力パラメータです
+type HistoryRecordInput struct {
+	UserID     string
+	Command    string // track / artist / album / recommend / search
+	Input      string // コマンドに渡された入力（URL/URI/ID または検索キーワード）
+	Mode       string
+	SearchType string // 検索対象の種別（search のみ）
+	Label      string
+}
+
+// HistoryListOutput は履歴一覧の出力結果です
+type HistoryListOutput struct {
+	Entries []domain.HistoryEntry
+}
+
+// Record はコマンド実行履歴を記録し、保持件数を超えた古い履歴を削除します
+func (u *HistoryUseCase) Record(ctx context.Context, input HistoryRecordInput) error {
+	entry := &domain.HistoryEntry{
+		UserID:    input.UserID,
+		Command:   input.Command,
+		Mode:      input.Mode,
+		Label:     input.Label,
+		CreatedAt: time.Now(),
+	}
+
+	if input.Command == "search" {
+		entry.Query = input.Input
+		entry.EntityType = input.SearchType
+	} else {
+		// 再実行できるよう正規化したURLを保存する
+		result := spotify.ValidateInput(input.Input, resolveEntityType(input.Input, historyEntityType(input.Command)))
+		if !result.Valid {
+			return fmt.Errorf("invalid history input: %s", result.Error)
+		}
+		entry.EntityType = string(result.EntityType)
+		entry.URL = result.URL
+	}
+
+	if err := u.repo.AddHistory(ctx, entry); err != nil {
+		return err
+	}
+	if err := u.repo.PruneHistory(ctx, input.UserID, u.retention); err != nil {
+		return err
+	}
+
+	slog.Debug("history recorded", "usecase", "history", "user_id", input.UserID, "command", input.Command, "history_id", entry.ID)
+
+	return nil
+}
+
+// ListHistory はユーザーの履歴を新しい順に取得します
+func (u *HistoryUseCase) ListHistory(ctx context.Context, userID string) (*HistoryListOutput, error) {
+	entries, err := u.repo.ListHistory(ctx, userID, u.retention)
+	if err != nil {
+		slog.Error("failed to list history", "usecase", "history", "user_id", userID, "error", err)
+		return nil, &StorageError{Message: "❌ 履歴の取得に失敗しました。", Err: err}
+	}
+
+	if len(entries) == 0 {
+		return nil, &NotFoundError{Message: "🕘 履歴はまだありません。"}
+	}
+
+	return &HistoryListOutput{Entries: entries}, nil
+}
+
+// GetEntry は再実行のために履歴を1件取得します
+func (u *HistoryUseCase) GetEntry(ctx context.Context, userID string, id int64) (*domain.HistoryEntry, error) {
+	entry, err := u.repo.GetHistory(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrHistoryNotFound) {
+			return nil, &NotFoundError{Message: "🔍 履歴が見つかりません。削除された可能性があります。"}
+		}
+		slog.Error("failed to get history", "usecase", "history", "user_id", userID, "history_id", id, "error", err)
+		return nil, &StorageError{Message: "❌ 履歴の取得に失敗しました。", Err: err}
+	}
+	return entry, nil
+}
+
+// ClearHistory はユーザーの履歴をすべて削除し、削除件数を返します
+func (u *HistoryUseCase) ClearHistory(ctx context.Context, userID string) (int, error) {
+	n, err := u.repo.ClearHistory(ctx, userID)
+	if err != nil {
+		slog.Error("failed to clear history", "usecase", "history", "user_id", userID, "error", err)
+		return 0, &StorageError{Message: "❌ 履歴の削除に失敗しました。", Err: err}
+	}
+
+	slog.Info("history cleared", "usecase", "history", "user_id", userID, "deleted", n)
+
+	return n, nil
+}
+
+// historyEntityType はコマンドから生ID入力時の種別を返します
+func historyEntityType(command string) spotify.EntityType {
+	switch command {
+	case "artist":
+		return spotify.EntityArtist
+	case "album":
+		return spotify.EntityAlbum
+	default:
+		return spotify.EntityTrack
+	}
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// mockHistoryRepository はHistoryRepositoryのインメモリモック実装です
+type mockHistoryRepository struct {
+	entries []domain.HistoryEntry
+	nextID  int64
+	err     error
+}
+
+func (m *mockHistoryRepository) AddHistory(ctx context.Context, entry *domain.HistoryEntry) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.nextID++
+	entry.ID = m.nextID
+	// 新しい順に保持する
+	m.entries = append([]domain.HistoryEntry{*entry}, m.entries...)
+	return nil
+}
+
+func (m *mockHistoryRepository) ListHistory(ctx context.Context, userID string, limit int) ([]domain.HistoryEntry, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []domain.HistoryEntry
+	for _, e := range m.entries {
+		if e.UserID == userID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockHistoryRepository) GetHistory(ctx context.Context, userID string, id int64) (*domain.HistoryEntry, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, e := range m.entries {
+		if e.UserID == userID && e.ID == id {
+			return &e, nil
+		}
+	}
+	return nil, domain.ErrHistoryNotFound
+}
+
+func (m *mockHistoryRepository) PruneHistory(ctx context.Context, userID string, keep int) error {
+	var result []domain.HistoryEntry
+	kept := 0
+	for _, e := range m.entries {
+		if e.UserID == userID {
+			if kept >= keep {
+				continue
+			}
+			kept++
+		}
+		result = append(result, e)
+	}
+	m.entries = result
+	return nil
+}
+
+func (m *mockHistoryRepository) ClearHistory(ctx context.Context, userID string) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	var result []domain.HistoryEntry
+	for _, e := range m.entries {
+		if e.UserID != userID {
+			result = append(result, e)
+		}
+	}
+	n := len(m.entries) - len(result)
+	m.entries = result
+	return n, nil
+}
+
+func TestHistoryUseCase_Record(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          HistoryRecordInput
+		wantErr        bool
+		wantEntityType string
+		wantURL        string
+		wantQuery      string
+	}{
+		{
+			name:           "track URL with query string",
+			input:          HistoryRecordInput{UserID: "user1", Command: "track", Input: "https://open.spotify.com/intl-ja/track/4iV5W9uYEdYUVa79Axb7Rh?si=abc"},
+			wantEntityType: "track",
+			wantURL:        "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+		},
+		{
+			name:           "album raw ID",
+			input:          HistoryRecordInput{UserID: "user1", Command: "album", Input: "0sNOF9WDwhWunNAHPD3Baj"},
+			wantEntityType: "album",
+			wantURL:        "https://open.spotify.com/album/0sNOF9WDwhWunNAHPD3Baj",
+		},
+		{
+			name:           "recommend from playlist",
+			input:          HistoryRecordInput{UserID: "user1", Command: "recommend", Input: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M", Mode: "similar"},
+			wantEntityType: "playlist",
+			wantURL:        "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+		},
+		{
+			name:      "search keeps query",
+			input:     HistoryRecordInput{UserID: "user1", Command: "search", Input: "YOASOBI"},
+			wantQuery: "YOASOBI",
+		},
+		{
+			name:           "search keeps type",
+			input:          HistoryRecordInput{UserID: "user1", Command: "search", Input: "YOASOBI", SearchType: "artist"},
+			wantEntityType: "artist",
+			wantQuery:      "YOASOBI",
+		},
+		{
+			name:    "invalid input",
+			input:   HistoryRecordInput{UserID: "user1", Command: "track", Input: "invalid"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockHistoryRepository{}
+			uc := NewHistoryUseCase(repo, 10)
+
+			err := uc.Record(context.Background(), tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.entries) != 1 {
+				t.Fatalf("stored entries = %d, want 1", len(repo.entries))
+			}
+			got := repo.entries[0]
+			if got.EntityType != tt.wantEntityType || got.URL != tt.wantURL || got.Query != tt.wantQuery {
+				t.Errorf("entry = %+v, want type=%s url=%s query=%s", got, tt.wantEntityType, tt.wantURL, tt.wantQuery)
+			}
+			if got.Mode != tt.input.Mode {
+				t.Errorf("Mode = %s, want %s", got.Mode, tt.input.Mode)
+			}
+		})
+	}
+}
+
+func TestHistoryUseCase_Record_Retention(t *testing.T) {
+	repo := &mockHistoryRepository{}
+	uc := NewHistoryUseCase(repo, 3)
+
+	for i := 0; i < 5; i++ {
+		if err := uc.Record(context.Background(), HistoryRecordInput{UserID: "user1", Command: "search", Input: "q"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(repo.entries) != 3 {
+		t.Errorf("stored entries = %d, want 3", len(repo.entries))
+	}
+	if repo.entries[0].ID != 5 {
+		t.Errorf("latest entry ID = %d, want 5", repo.entries[0].ID)
+	}
+}
+
+func TestHistoryUseCase_ListAndGet(t *testing.T) {
+	repo := &mockHistoryRepository{}
+	uc := NewHistoryUseCase(repo, 0)
+	ctx := context.Background()
+
+	if uc.retention != DefaultHistoryRetention {
+		t.Errorf("retention = %d, want %d", uc.retention, DefaultHistoryRetention)
+	}
+
+	if _, err := uc.ListHistory(ctx, "user1"); !IsNotFoundError(err) {
+		t.Errorf("expected NotFoundError for empty history but got %v", err)
+	}
+
+	_ = uc.Record(ctx, HistoryRecordInput{UserID: "user1", Command: "search", Input: "YOASOBI"})
+
+	output, err := uc.ListHistory(ctx, "user1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(output.Entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(output.Entries))
+	}
+
+	id := output.Entries[0].ID
+	if _, err := uc.GetEntry(ctx, "user1", id); err != nil {
+		t.Errorf("GetEntry() unexpected error: %v", err)
+	}
+	if _, err := uc.GetEntry(ctx, "user2", id); !IsNotFoundError(err) {
+		t.Errorf("GetEntry() for other user should be NotFoundError, got %v", err)
+	}
+
+	repo.err = errors.New("db error")
+	if _, err := uc.ListHistory(ctx, "user1"); err == nil || IsNotFoundError(err) {
+		t.Errorf("expected repository error but got %v", err)
+	}
+}
+
+func TestHistoryUseCase_ClearHistory(t *testing.T) {
+	repo := &mockHistoryRepository{}
+	uc := NewHistoryUseCase(repo, 10)
+	ctx := context.Background()
+
+	_ = uc.Record(ctx, HistoryRecordInput{UserID: "user1", Command: "search", Input: "a"})
+	_ = uc.Record(ctx, HistoryRecordInput{UserID: "user1", Command: "search", Input: "b"})
+	_ = uc.Record(ctx, HistoryRecordInput{UserID: "user2", Command: "search", Input: "c"})
+
+	n, err := uc.ClearHistory(ctx, "user1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deleted = %d, want 2", n)
+	}
+	if len(repo.entries) != 1 || repo.entries[0].UserID != "user2" {
+		t.Errorf("remaining entries = %+v, want only user2", repo.entries)
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+	"github.com/t1nyb0x/jamberry/internal/spotify"
+)
+
+// PlaylistUseCase はプレイリスト関連のユースケースを提供します
+type PlaylistUseCase struct {
+	repo domain.PlaylistRepository
+}
+
+// NewPlaylistUseCase は新しいPlaylistUseCaseを作成します
+func NewPlaylistUseCase(repo domain.PlaylistRepository) *PlaylistUseCase {
+	return &PlaylistUseCase{repo: repo}
+}
+
+// PlaylistInput はプレイリスト取得の入力パラメータです
+type PlaylistInput struct {
+	Input string
+}
+
+// PlaylistOutput はプレイリスト取得の出力結果です
+type PlaylistOutput struct {
+	Playlist *domain.Playlist
+}
+
+// GetPlaylist はプレイリスト情報を取得します
+func (u *PlaylistUseCase) GetPlaylist(ctx context.Context, input PlaylistInput) (*PlaylistOutput, error) {
+	// バリデーション
+	result := spotify.ValidateInput(input.Input, spotify.EntityPlaylist)
+	if !result.Valid {
+		slog.Info("validation failed", "usecase", "playlist", "input", input.Input, "error", result.Error)
+		return nil, &ValidationError{Message: result.Error}
+	}
+
+	slog.Debug("validation passed", "usecase", "playlist", "url", result.URL, "id", result.ID)
+
+	// プレイリスト情報を取得
+	playlist, err := u.repo.FetchPlaylist(ctx, result.URL)
+	if err != nil {
+		slog.Warn("playlist fetch failed", "usecase", "playlist", "url", result.URL, "error", err)
+		return nil, err
+	}
+
+	if len(playlist.Tracks) == 0 {
+		slog.Info("playlist has no tracks", "usecase", "playlist", "playlist_id", playlist.ID)
+		return nil, &NotFoundError{Message: "🔍 プレイリストに曲が含まれていません。"}
+	}
+
+	slog.Info("playlist fetched", "usecase", "playlist",
+		"playlist_name", playlist.Name,
+		"playlist_id", playlist.ID,
+		"track_count", len(playlist.Tracks))
+
+	return &PlaylistOutput{Playlist: playlist}, nil
+}
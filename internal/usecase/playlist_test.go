@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/t1nyb0x/jamberry/internal/domain"
+)
+
+// mockPlaylistRepository はPlaylistRepositoryのモック実装です
+type mockPlaylistRepository struct {
+	fetchPlaylistFunc func(ctx context.Context, spotifyURL string) (*domain.Playlist, error)
+}
+
+func (m *mockPlaylistRepository) FetchPlaylist(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+	if m.fetchPlaylistFunc != nil {
+		return m.fetchPlaylistFunc(ctx, spotifyURL)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestPlaylistUseCase_GetPlaylist(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        PlaylistInput
+		mockFunc     func(ctx context.Context, spotifyURL string) (*domain.Playlist, error)
+		wantErr      bool
+		errType      string
+		wantPlaylist string
+		wantTracks   int
+	}{
+		{
+			name: "valid URL",
+			input: PlaylistInput{
+				Input: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			},
+			mockFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				if spotifyURL != "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M" {
+					return nil, errors.New("unexpected url")
+				}
+				return &domain.Playlist{
+					ID:   "37i9dQZF1DXcBWIGoYBM5M",
+					Name: "Test Playlist",
+					Tracks: []domain.Track{
+						{ID: "track1", Name: "Track 1"},
+						{ID: "track2", Name: "Track 2"},
+					},
+				}, nil
+			},
+			wantErr:      false,
+			wantPlaylist: "Test Playlist",
+			wantTracks:   2,
+		},
+		{
+			name: "valid URI",
+			input: PlaylistInput{
+				Input: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			},
+			mockFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				return &domain.Playlist{
+					ID:     "37i9dQZF1DXcBWIGoYBM5M",
+					Name:   "Test Playlist",
+					Tracks: []domain.Track{{ID: "track1", Name: "Track 1"}},
+				}, nil
+			},
+			wantErr:      false,
+			wantPlaylist: "Test Playlist",
+			wantTracks:   1,
+		},
+		{
+			name: "empty input",
+			input: PlaylistInput{
+				Input: "",
+			},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name: "track URL instead of playlist",
+			input: PlaylistInput{
+				Input: "https://open.spotify.com/track/4iV5W9uYEdYUVa79Axb7Rh",
+			},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name: "empty playlist",
+			input: PlaylistInput{
+				Input: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			},
+			mockFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				return &domain.Playlist{ID: "37i9dQZF1DXcBWIGoYBM5M", Name: "Empty"}, nil
+			},
+			wantErr: true,
+			errType: "notfound",
+		},
+		{
+			name: "repository error",
+			input: PlaylistInput{
+				Input: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			},
+			mockFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				return nil, errors.New("API error")
+			},
+			wantErr: true,
+			errType: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPlaylistRepository{
+				fetchPlaylistFunc: tt.mockFunc,
+			}
+			uc := NewPlaylistUseCase(repo)
+
+			output, err := uc.GetPlaylist(context.Background(), tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+					return
+				}
+				switch tt.errType {
+				case "validation":
+					if !IsValidationError(err) {
+						t.Errorf("expected ValidationError but got %T: %v", err, err)
+					}
+				case "notfound":
+					if !IsNotFoundError(err) {
+						t.Errorf("expected NotFoundError but got %T: %v", err, err)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if output.Playlist.Name != tt.wantPlaylist {
+				t.Errorf("Playlist.Name = %v, want %v", output.Playlist.Name, tt.wantPlaylist)
+			}
+			if len(output.Playlist.Tracks) != tt.wantTracks {
+				t.Errorf("Tracks count = %v, want %v", len(output.Playlist.Tracks), tt.wantTracks)
+			}
+		})
+	}
+}
+
+func TestNewPlaylistUseCase(t *testing.T) {
+	repo := &mockPlaylistRepository{}
+	uc := NewPlaylistUseCase(repo)
+
+	if uc == nil {
+		t.Fatal("NewPlaylistUseCase returned nil")
+	}
+	if uc.repo != repo {
+		t.Error("repo not set correctly")
+	}
+}
@@ -2,20 +2,37 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
+	"sync"
 
 	"github.com/t1nyb0x/jamberry/internal/domain"
 	"github.com/t1nyb0x/jamberry/internal/spotify"
 )
 
+const (
+	// MaxRecommendSeeds はアルバム/プレイリスト指定時にサンプリングするシードトラックの最大数です
+	MaxRecommendSeeds = 5
+	// maxConcurrentSeedFetches はシードごとのレコメンド取得の最大並列数です
+	maxConcurrentSeedFetches = 3
+)
+
 // RecommendUseCase はレコメンド関連のユースケースを提供します
 type RecommendUseCase struct {
-	repo domain.TrackRepository
+	repo         domain.TrackRepository
+	albumRepo    domain.AlbumRepository
+	playlistRepo domain.PlaylistRepository
 }
 
 // NewRecommendUseCase は新しいRecommendUseCaseを作成します
-func NewRecommendUseCase(repo domain.TrackRepository) *RecommendUseCase {
-	return &RecommendUseCase{repo: repo}
+// albumRepo / playlistRepo が nil の場合、アルバム/プレイリストを起点としたレコメンドは受け付けません
+func NewRecommendUseCase(repo domain.TrackRepository, albumRepo domain.AlbumRepository, playlistRepo domain.PlaylistRepository) *RecommendUseCase {
+	return &RecommendUseCase{
+		repo:         repo,
+		albumRepo:    albumRepo,
+		playlistRepo: playlistRepo,
+	}
 }
 
 // RecommendInput はレコメンド取得の入力パラメータです
@@ -31,18 +48,25 @@ type RecommendOutput struct {
 	SeedFeatures *domain.TrackFeatures // v2: Deezer + MusicBrainz features
 	Items        []domain.SimilarTrack
 	Mode         domain.RecommendMode
+	SourceName   string         // アルバム/プレイリスト指定時のシード元の名前
+	Seeds        []domain.Track // アルバム/プレイリスト指定時にサンプリングしたシードトラック
+}
+
+// IsMultiSeed はアルバム/プレイリストを起点とした複数シードのレコメンドかどうかを返します
+func (o *RecommendOutput) IsMultiSeed() bool {
+	return len(o.Seeds) > 0
 }
 
 // GetRecommend はレコメンド情報を取得します
+// トラックのほか、アルバム/プレイリストの URL を指定した場合は複数のシードトラックから取得した結果を統合します
 func (u *RecommendUseCase) GetRecommend(ctx context.Context, input RecommendInput) (*RecommendOutput, error) {
-	// バリデーション（トラックURLのみ受け付ける）
-	result := spotify.ValidateInput(input.Input, spotify.EntityTrack)
+	result := u.validate(input.Input)
 	if !result.Valid {
 		slog.Info("validation failed", "usecase", "recommend", "input", input.Input, "error", result.Error)
 		return nil, &ValidationError{Message: result.Error}
 	}
 
-	slog.Debug("validation passed", "usecase", "recommend", "url", result.URL, "id", result.ID, "mode", input.Mode)
+	slog.Debug("validation passed", "usecase", "recommend", "url", result.URL, "id", result.ID, "type", result.EntityType, "mode", input.Mode)
 
 	// デフォルト値の設定
 	mode := input.Mode
@@ -54,10 +78,38 @@ func (u *RecommendUseCase) GetRecommend(ctx context.Context, input RecommendInpu
 		limit = 20
 	}
 
+	switch result.EntityType {
+	case spotify.EntityAlbum:
+		return u.getRecommendFromAlbum(ctx, result.URL, mode, limit)
+	case spotify.EntityPlaylist:
+		return u.getRecommendFromPlaylist(ctx, result.URL, mode, limit)
+	default:
+		return u.getRecommendFromTrack(ctx, result.URL, mode, limit)
+	}
+}
+
+// validate は入力の種別に応じてバリデーションします
+// アルバム/プレイリストの URL / URI 以外（生IDを含む）はトラックとして扱います
+func (u *RecommendUseCase) validate(input string) spotify.ValidationResult {
+	switch spotify.DetectEntityType(input) {
+	case spotify.EntityAlbum:
+		if u.albumRepo != nil {
+			return spotify.ValidateInput(input, spotify.EntityAlbum)
+		}
+	case spotify.EntityPlaylist:
+		if u.playlistRepo != nil {
+			return spotify.ValidateInput(input, spotify.EntityPlaylist)
+		}
+	}
+	return spotify.ValidateInput(input, spotify.EntityTrack)
+}
+
+// getRecommendFromTrack は単一のトラックを起点にレコメンドを取得します
+func (u *RecommendUseCase) getRecommendFromTrack(ctx context.Context, trackURL string, mode domain.RecommendMode, limit int) (*RecommendOutput, error) {
 	// 新しいレコメンドAPIを使用
-	recommendResult, err := u.repo.FetchRecommend(ctx, result.URL, mode, limit)
+	recommendResult, err := u.repo.FetchRecommend(ctx, trackURL, mode, limit)
 	if err != nil {
-		slog.Warn("recommend fetch failed", "usecase", "recommend", "url", result.URL, "mode", mode, "error", err)
+		slog.Warn("recommend fetch failed", "usecase", "recommend", "url", trackURL, "mode", mode, "error", err)
 		return nil, err
 	}
 
@@ -72,10 +124,10 @@ func (u *RecommendUseCase) GetRecommend(ctx context.Context, input RecommendInpu
 		"result_count", len(recommendResult.Items))
 
 	// SeedTrackの完全な情報を取得（URLやDurationMsなどが必要な場合）
-	seedTrack, err := u.repo.FetchTrack(ctx, result.URL)
+	seedTrack, err := u.repo.FetchTrack(ctx, trackURL)
 	if err != nil {
 		// シードトラックの詳細取得に失敗しても、レコメンド結果は返す
-		slog.Warn("seed track detail fetch failed, using basic info", "url", result.URL, "error", err)
+		slog.Warn("seed track detail fetch failed, using basic info", "url", trackURL, "error", err)
 		seedTrack = &recommendResult.SeedTrack
 	}
 
@@ -86,3 +138,247 @@ func (u *RecommendUseCase) GetRecommend(ctx context.Context, input RecommendInpu
 		Mode:         recommendResult.Mode,
 	}, nil
 }
+
+// getRecommendFromAlbum はアルバムの収録曲をシードにレコメンドを取得します
+func (u *RecommendUseCase) getRecommendFromAlbum(ctx context.Context, albumURL string, mode domain.RecommendMode, limit int) (*RecommendOutput, error) {
+	album, err := u.albumRepo.FetchAlbum(ctx, albumURL)
+	if err != nil {
+		slog.Warn("album fetch failed", "usecase", "recommend", "url", albumURL, "error", err)
+		return nil, err
+	}
+
+	candidates := make([]domain.Track, len(album.Tracks))
+	for i, t := range album.Tracks {
+		candidates[i] = domain.Track{
+			ID:          t.ID,
+			Name:        t.Name,
+			URL:         t.URL,
+			TrackNumber: t.TrackNumber,
+			Artists:     t.Artists,
+		}
+	}
+
+	return u.getRecommendFromSeeds(ctx, album.Name, candidates, mode, limit)
+}
+
+// getRecommendFromPlaylist はプレイリストの収録曲をシードにレコメンドを取得します
+func (u *RecommendUseCase) getRecommendFromPlaylist(ctx context.Context, playlistURL string, mode domain.RecommendMode, limit int) (*RecommendOutput, error) {
+	playlist, err := u.playlistRepo.FetchPlaylist(ctx, playlistURL)
+	if err != nil {
+		slog.Warn("playlist fetch failed", "usecase", "recommend", "url", playlistURL, "error", err)
+		return nil, err
+	}
+
+	return u.getRecommendFromSeeds(ctx, playlist.Name, playlist.Tracks, mode, limit)
+}
+
+// getRecommendFromSeeds は複数のシードトラックからレコメンドを取得し、統合・再ランキングします
+func (u *RecommendUseCase) getRecommendFromSeeds(ctx context.Context, sourceName string, candidates []domain.Track, mode domain.RecommendMode, limit int) (*RecommendOutput, error) {
+	seeds := sampleSeeds(candidates, MaxRecommendSeeds)
+	if len(seeds) == 0 {
+		slog.Info("no seed tracks found", "usecase", "recommend", "source_name", sourceName)
+		return nil, &NotFoundError{Message: "🔍 シードにできる曲が見つかりませんでした。"}
+	}
+
+	results := make([]*domain.RecommendResult, len(seeds))
+	errs := make([]error, len(seeds))
+
+	// シードごとに並列取得（並列数は制限する）
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSeedFetches)
+	for i, seed := range seeds {
+		wg.Add(1)
+		go func(i int, seedURL string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			results[i], errs[i] = u.repo.FetchRecommend(ctx, seedURL, mode, limit)
+			if errs[i] == nil && seeds[i].ISRC == nil {
+				u.fillSeedISRC(ctx, &seeds[i])
+			}
+		}(i, seed.URL)
+	}
+	wg.Wait()
+
+	var succeeded []*domain.RecommendResult
+	var firstErr error
+	for i, err := range errs {
+		if err != nil {
+			slog.Warn("recommend fetch failed for seed", "usecase", "recommend", "url", seeds[i].URL, "mode", mode, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+
+	// 全シードで失敗した場合はエラーを返す
+	if len(succeeded) == 0 {
+		return nil, firstErr
+	}
+
+	items := mergeRecommendItems(succeeded, seeds, limit)
+	if len(items) == 0 {
+		slog.Info("no recommend tracks found", "usecase", "recommend", "source_name", sourceName)
+		return nil, &NotFoundError{Message: "🔍 該当する結果は見つかりませんでした。"}
+	}
+
+	slog.Info("recommend fetched", "usecase", "recommend",
+		"source_name", sourceName,
+		"seed_count", len(seeds),
+		"succeeded_seed_count", len(succeeded),
+		"mode", mode,
+		"result_count", len(items))
+
+	return &RecommendOutput{
+		SeedTrack:  &seeds[0],
+		Items:      items,
+		Mode:       mode,
+		SourceName: sourceName,
+		Seeds:      seeds,
+	}, nil
+}
+
+// fillSeedISRC はシードトラックの ISRC をトラック詳細から補完します
+// アルバムの収録曲には ISRC が含まれないため、シングル版やリマスター版など別IDの同じ録音を統合時に除外できるようにします
+// 取得に失敗した場合は ID のみで除外します
+func (u *RecommendUseCase) fillSeedISRC(ctx context.Context, seed *domain.Track) {
+	track, err := u.repo.FetchTrack(ctx, seed.URL)
+	if err != nil {
+		slog.Debug("seed track detail fetch failed, excluding seed by ID only", "usecase", "recommend", "url", seed.URL, "error", err)
+		return
+	}
+	seed.ISRC = track.ISRC
+}
+
+// sampleSeeds は候補から最大 n 曲を等間隔にサンプリングします
+// URL が欠けている場合は ID から補完し、ID も無いトラックは除外します
+func sampleSeeds(candidates []domain.Track, n int) []domain.Track {
+	var valid []domain.Track
+	for _, t := range candidates {
+		if t.URL == "" && t.ID != "" {
+			t.URL = fmt.Sprintf("https://open.spotify.com/track/%s", t.ID)
+		}
+		if t.URL == "" {
+			continue
+		}
+		valid = append(valid, t)
+	}
+
+	if len(valid) <= n {
+		return valid
+	}
+
+	seeds := make([]domain.Track, n)
+	for i := 0; i < n; i++ {
+		seeds[i] = valid[i*len(valid)/n]
+	}
+	return seeds
+}
+
+// mergeRecommendItems は複数シードのレコメンド結果をトラックID / ISRCで重複排除し、
+// スコアの合計の降順に並べ替えます（シードトラック自身は、別IDの同じ録音も含めて ISRC で除外します）
+// 複数のシードで推薦された曲ほど上位になりますが、表示するスコアは1曲ごとの尺度を保つため、推薦したシードでの平均とします
+func mergeRecommendItems(results []*domain.RecommendResult, seeds []domain.Track, limit int) []domain.SimilarTrack {
+	seedIDs := make(map[string]struct{}, len(seeds))
+	seedISRCs := make(map[string]struct{}, len(seeds))
+	for _, s := range seeds {
+		seedIDs[s.ID] = struct{}{}
+		if s.ISRC != nil && *s.ISRC != "" {
+			seedISRCs[*s.ISRC] = struct{}{}
+		}
+	}
+
+	type merged struct {
+		item     domain.SimilarTrack
+		total    float64 // シードごとのスコアの合計（並べ替えに使う）
+		count    int     // 推薦したシードの数
+		hasFinal bool    // final_score を持つ結果があったか
+	}
+
+	var order []*merged
+	byID := make(map[string]*merged)
+	byISRC := make(map[string]*merged)
+
+	for _, r := range results {
+		for _, item := range r.Items {
+			if _, isSeed := seedIDs[item.ID]; isSeed {
+				continue
+			}
+			if item.ISRC != nil {
+				if _, isSeed := seedISRCs[*item.ISRC]; isSeed {
+					continue
+				}
+			}
+
+			m, ok := byID[item.ID]
+			if !ok && item.ISRC != nil && *item.ISRC != "" {
+				m, ok = byISRC[*item.ISRC]
+			}
+			if ok {
+				// ジャンルボーナスはシードごとに異なるため、一致しない場合は表示しない
+				if !sameBonus(m.item.GenreBonus, item.GenreBonus) {
+					m.item.GenreBonus = nil
+				}
+			} else {
+				m = &merged{item: item}
+				order = append(order, m)
+			}
+			m.total += itemScore(item)
+			m.count++
+			m.hasFinal = m.hasFinal || item.FinalScore != nil
+
+			if item.ID != "" {
+				byID[item.ID] = m
+			}
+			if item.ISRC != nil && *item.ISRC != "" {
+				byISRC[*item.ISRC] = m
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].total > order[j].total
+	})
+
+	if len(order) > limit {
+		order = order[:limit]
+	}
+
+	items := make([]domain.SimilarTrack, len(order))
+	for i, m := range order {
+		score := m.total / float64(m.count)
+		items[i] = m.item
+		if m.hasFinal {
+			items[i].FinalScore = &score
+		} else {
+			items[i].SimilarityScore = &score
+		}
+	}
+	return items
+}
+
+// sameBonus は2つのジャンルボーナスが等しいかどうかを返します
+func sameBonus(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// itemScore はレコメンド結果のスコアを返します（final_scoreを優先、なければsimilarity_scoreを使用）
+func itemScore(item domain.SimilarTrack) float64 {
+	if item.FinalScore != nil {
+		return *item.FinalScore
+	}
+	if item.SimilarityScore != nil {
+		return *item.SimilarityScore
+	}
+	return 0
+}
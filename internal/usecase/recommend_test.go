@@ -210,7 +210,7 @@ func TestRecommendUseCase_GetRecommend(t *testing.T) {
 				fetchRecommendFunc: tt.fetchRecommend,
 				fetchTrackFunc:     tt.fetchTrack,
 			}
-			uc := NewRecommendUseCase(repo)
+			uc := NewRecommendUseCase(repo, nil, nil)
 
 			output, err := uc.GetRecommend(context.Background(), tt.input)
 
@@ -249,7 +249,7 @@ func TestRecommendUseCase_GetRecommend(t *testing.T) {
 
 func TestNewRecommendUseCase(t *testing.T) {
 	repo := &mockTrackRepository{}
-	uc := NewRecommendUseCase(repo)
+	uc := NewRecommendUseCase(repo, nil, nil)
 
 	if uc == nil {
 		t.Fatal("NewRecommendUseCase returned nil")
@@ -258,3 +258,275 @@ func TestNewRecommendUseCase(t *testing.T) {
 		t.Error("repo not set correctly")
 	}
 }
+
+func TestRecommendUseCase_GetRecommend_MultiSeed(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	isrc := func(v string) *string { return &v }
+
+	// シードごとのレコメンド結果
+	recommendBySeed := map[string][]domain.SimilarTrack{
+		"https://open.spotify.com/track/seed1": {
+			{ID: "a", Name: "A", FinalScore: score(0.9)},
+			{ID: "b", Name: "B", FinalScore: score(0.5), ISRC: isrc("JPXX00000001")},
+			{ID: "seed2", Name: "Seed 2", FinalScore: score(0.99)}, // シード自身は除外される
+		},
+		"https://open.spotify.com/track/seed2": {
+			{ID: "b-alt", Name: "B (別ID)", FinalScore: score(0.6), ISRC: isrc("JPXX00000001")},
+			{ID: "c", Name: "C", SimilarityScore: score(0.3)},
+		},
+	}
+
+	fetchRecommend := func(ctx context.Context, spotifyURL string, mode domain.RecommendMode, limit int) (*domain.RecommendResult, error) {
+		items, ok := recommendBySeed[spotifyURL]
+		if !ok {
+			return nil, errors.New("unexpected seed: " + spotifyURL)
+		}
+		return &domain.RecommendResult{Items: items, Mode: mode}, nil
+	}
+
+	t.Run("album", func(t *testing.T) {
+		albumRepo := &mockAlbumRepository{
+			fetchAlbumFunc: func(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
+				return &domain.AlbumDetail{
+					Name: "Test Album",
+					Tracks: []domain.AlbumTrack{
+						{ID: "seed1", Name: "Seed 1", URL: "https://open.spotify.com/track/seed1"},
+						{ID: "seed2", Name: "Seed 2"}, // URLはIDから補完される
+					},
+				}, nil
+			},
+		}
+		uc := NewRecommendUseCase(&mockTrackRepository{fetchRecommendFunc: fetchRecommend}, albumRepo, nil)
+
+		output, err := uc.GetRecommend(context.Background(), RecommendInput{
+			Input: "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+			Mode:  domain.RecommendModeRelated,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !output.IsMultiSeed() || len(output.Seeds) != 2 {
+			t.Fatalf("Seeds count = %d, want 2", len(output.Seeds))
+		}
+		if output.SourceName != "Test Album" {
+			t.Errorf("SourceName = %s, want Test Album", output.SourceName)
+		}
+		if output.Mode != domain.RecommendModeRelated {
+			t.Errorf("Mode = %s, want related", output.Mode)
+		}
+
+		// B は ISRC で統合され合計 0.5 + 0.6 = 1.1 で首位になる
+		wantOrder := []string{"b", "a", "c"}
+		if len(output.Items) != len(wantOrder) {
+			t.Fatalf("Items count = %d, want %d", len(output.Items), len(wantOrder))
+		}
+		for i, id := range wantOrder {
+			if output.Items[i].ID != id {
+				t.Errorf("Items[%d].ID = %s, want %s", i, output.Items[i].ID, id)
+			}
+		}
+		// 表示するスコアは推薦したシードでの平均
+		if got := *output.Items[0].FinalScore; got < 0.549 || got > 0.551 {
+			t.Errorf("merged FinalScore = %v, want 0.55", got)
+		}
+		// similarity_score のみの結果は final_score にしない
+		if c := output.Items[2]; c.FinalScore != nil || c.SimilarityScore == nil || *c.SimilarityScore != 0.3 {
+			t.Errorf("Items[2] scores = (%v, %v), want similarity only", c.FinalScore, c.SimilarityScore)
+		}
+	})
+
+	t.Run("playlist with partial failure", func(t *testing.T) {
+		playlistRepo := &mockPlaylistRepository{
+			fetchPlaylistFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				return &domain.Playlist{
+					Name: "Test Playlist",
+					Tracks: []domain.Track{
+						{ID: "seed1", Name: "Seed 1", URL: "https://open.spotify.com/track/seed1"},
+						{ID: "broken", Name: "Broken", URL: "https://open.spotify.com/track/broken"},
+					},
+				}, nil
+			},
+		}
+		uc := NewRecommendUseCase(&mockTrackRepository{fetchRecommendFunc: fetchRecommend}, nil, playlistRepo)
+
+		output, err := uc.GetRecommend(context.Background(), RecommendInput{
+			Input: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(output.Items) != 3 {
+			t.Errorf("Items count = %d, want 3", len(output.Items))
+		}
+		if output.Mode != domain.RecommendModeBalanced {
+			t.Errorf("Mode = %s, want balanced", output.Mode)
+		}
+	})
+
+	t.Run("all seeds fail", func(t *testing.T) {
+		playlistRepo := &mockPlaylistRepository{
+			fetchPlaylistFunc: func(ctx context.Context, spotifyURL string) (*domain.Playlist, error) {
+				return &domain.Playlist{
+					Name:   "Broken Playlist",
+					Tracks: []domain.Track{{ID: "broken", URL: "https://open.spotify.com/track/broken"}},
+				}, nil
+			},
+		}
+		uc := NewRecommendUseCase(&mockTrackRepository{fetchRecommendFunc: fetchRecommend}, nil, playlistRepo)
+
+		if _, err := uc.GetRecommend(context.Background(), RecommendInput{
+			Input: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+		}); err == nil {
+			t.Error("expected error but got nil")
+		}
+	})
+
+	t.Run("album URL without album repository", func(t *testing.T) {
+		uc := NewRecommendUseCase(&mockTrackRepository{}, nil, nil)
+
+		_, err := uc.GetRecommend(context.Background(), RecommendInput{
+			Input: "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+		})
+		if !IsValidationError(err) {
+			t.Errorf("expected ValidationError but got %T: %v", err, err)
+		}
+	})
+}
+
+func TestSampleSeeds(t *testing.T) {
+	candidates := make([]domain.Track, 10)
+	for i := range candidates {
+		candidates[i] = domain.Track{ID: string(rune('a' + i))}
+	}
+	candidates = append(candidates, domain.Track{Name: "no id"})
+
+	seeds := sampleSeeds(candidates, 5)
+	if len(seeds) != 5 {
+		t.Fatalf("seeds count = %d, want 5", len(seeds))
+	}
+
+	wantIDs := []string{"a", "c", "e", "g", "i"}
+	for i, id := range wantIDs {
+		if seeds[i].ID != id {
+			t.Errorf("seeds[%d].ID = %s, want %s", i, seeds[i].ID, id)
+		}
+		if seeds[i].URL != "https://open.spotify.com/track/"+id {
+			t.Errorf("seeds[%d].URL = %s", i, seeds[i].URL)
+		}
+	}
+}
+
+func TestMergeRecommendItems_ExcludesSeeds(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	isrc := func(v string) *string { return &v }
+
+	seeds := []domain.Track{
+		{ID: "seed1", Name: "Seed 1", ISRC: isrc("JPXX00000001")},
+		{ID: "seed2", Name: "Seed 2"},
+	}
+	results := []*domain.RecommendResult{
+		{Items: []domain.SimilarTrack{
+			{ID: "a", Name: "A", FinalScore: score(0.9)},
+			{ID: "seed2", Name: "Seed 2", FinalScore: score(0.8)},
+			{ID: "seed1-alt", Name: "Seed 1 (別ID)", FinalScore: score(0.7), ISRC: isrc("JPXX00000001")},
+			{ID: "b", Name: "B", FinalScore: score(0.5), ISRC: isrc("JPXX00000002")},
+		}},
+	}
+
+	items := mergeRecommendItems(results, seeds, 10)
+
+	wantIDs := []string{"a", "b"}
+	if len(items) != len(wantIDs) {
+		t.Fatalf("Items count = %d, want %d", len(items), len(wantIDs))
+	}
+	for i, id := range wantIDs {
+		if items[i].ID != id {
+			t.Errorf("Items[%d].ID = %s, want %s", i, items[i].ID, id)
+		}
+	}
+}
+
+func TestRecommendUseCase_GetRecommend_AlbumExcludesSeedISRC(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	isrc := func(v string) *string { return &v }
+
+	trackRepo := &mockTrackRepository{
+		fetchTrackFunc: func(ctx context.Context, spotifyURL string) (*domain.Track, error) {
+			if spotifyURL == "https://open.spotify.com/track/seed1" {
+				return &domain.Track{ID: "seed1", ISRC: isrc("JPXX00000001")}, nil
+			}
+			return nil, errors.New("not found")
+		},
+		fetchRecommendFunc: func(ctx context.Context, spotifyURL string, mode domain.RecommendMode, limit int) (*domain.RecommendResult, error) {
+			return &domain.RecommendResult{Mode: mode, Items: []domain.SimilarTrack{
+				{ID: "a", Name: "A", FinalScore: score(0.9)},
+				{ID: "seed1-single", Name: "Seed 1 (Single)", FinalScore: score(0.8), ISRC: isrc("JPXX00000001")},
+			}}, nil
+		},
+	}
+	albumRepo := &mockAlbumRepository{
+		fetchAlbumFunc: func(ctx context.Context, spotifyURL string) (*domain.AlbumDetail, error) {
+			return &domain.AlbumDetail{
+				Name: "Test Album",
+				Tracks: []domain.AlbumTrack{
+					{ID: "seed1", Name: "Seed 1"},
+					{ID: "seed2", Name: "Seed 2"},
+				},
+			}, nil
+		},
+	}
+	uc := NewRecommendUseCase(trackRepo, albumRepo, nil)
+
+	output, err := uc.GetRecommend(context.Background(), RecommendInput{
+		Input: "https://open.spotify.com/album/4aawyAB9vmqN3uQ7FjRGTy",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 別IDでもシードと同じ ISRC の曲は除外される
+	if len(output.Items) != 1 || output.Items[0].ID != "a" {
+		t.Errorf("Items = %+v, want only a", output.Items)
+	}
+	if output.Seeds[0].ISRC == nil || *output.Seeds[0].ISRC != "JPXX00000001" {
+		t.Errorf("Seeds[0].ISRC = %v, want JPXX00000001", output.Seeds[0].ISRC)
+	}
+}
+
+func TestMergeRecommendItems_AverageScore(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	results := []*domain.RecommendResult{
+		{Items: []domain.SimilarTrack{
+			{ID: "a", Name: "A", FinalScore: score(0.9), GenreBonus: score(1.5)},
+			{ID: "b", Name: "B", FinalScore: score(0.5), GenreBonus: score(2.5)},
+		}},
+		{Items: []domain.SimilarTrack{
+			{ID: "b", Name: "B", FinalScore: score(0.7), GenreBonus: score(1.0)},
+		}},
+	}
+
+	items := mergeRecommendItems(results, nil, 10)
+	if len(items) != 2 {
+		t.Fatalf("Items count = %d, want 2", len(items))
+	}
+
+	// 複数のシードで推薦された B が合計 1.2 で首位になり、スコアは平均の 0.6 を表示する
+	b := items[0]
+	if b.ID != "b" {
+		t.Fatalf("Items[0].ID = %s, want b", b.ID)
+	}
+	if got := *b.FinalScore; got < 0.599 || got > 0.601 {
+		t.Errorf("FinalScore = %v, want 0.6", got)
+	}
+	if b.GenreBonus != nil {
+		t.Errorf("GenreBonus = %v, want nil for differing bonuses", *b.GenreBonus)
+	}
+
+	// 1つのシードのみの結果はそのまま
+	a := items[1]
+	if *a.FinalScore != 0.9 || a.GenreBonus == nil || *a.GenreBonus != 1.5 {
+		t.Errorf("Items[1] = (%v, %v), want (0.9, 1.5)", *a.FinalScore, a.GenreBonus)
+	}
+}
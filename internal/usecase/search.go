@@ -4,37 +4,66 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/t1nyb0x/jamberry/internal/domain"
 )
 
+// MaxSearchItemsPerType は種別をまとめた検索（all）で種別ごとに表示する最大件数です
+const MaxSearchItemsPerType = 10
+
 // SearchUseCase は検索関連のユースケースを提供します
 type SearchUseCase struct {
-	repo domain.TrackRepository
+	repo       domain.TrackRepository
+	artistRepo domain.ArtistRepository
+	albumRepo  domain.AlbumRepository
 }
 
 // NewSearchUseCase は新しいSearchUseCaseを作成します
-func NewSearchUseCase(repo domain.TrackRepository) *SearchUseCase {
-	return &SearchUseCase{repo: repo}
+func NewSearchUseCase(repo domain.TrackRepository, artistRepo domain.ArtistRepository, albumRepo domain.AlbumRepository) *SearchUseCase {
+	return &SearchUseCase{
+		repo:       repo,
+		artistRepo: artistRepo,
+		albumRepo:  albumRepo,
+	}
 }
 
 // SearchInput は検索の入力パラメータです
 type SearchInput struct {
 	Query string
+	Type  domain.SearchType // 検索対象の種別（空の場合はトラック）
 }
 
 // SearchOutput は検索の出力結果です
+// Type に応じて Tracks / Artists / Albums / Items（all の場合）のいずれかが設定されます
 type SearchOutput struct {
-	Query  string
-	Tracks []domain.Track
+	Query   string
+	Type    domain.SearchType
+	Tracks  []domain.Track
+	Artists []domain.ArtistDetail
+	Albums  []domain.Album
+	Items   []domain.SearchItem
+}
+
+// Count は検索結果の件数を返します
+func (o *SearchOutput) Count() int {
+	switch o.Type {
+	case domain.SearchTypeArtist:
+		return len(o.Artists)
+	case domain.SearchTypeAlbum:
+		return len(o.Albums)
+	case domain.SearchTypeAll:
+		return len(o.Items)
+	default:
+		return len(o.Tracks)
+	}
 }
 
 // SearchTracks はトラックを検索します
 func (u *SearchUseCase) SearchTracks(ctx context.Context, input SearchInput) (*SearchOutput, error) {
-	query := strings.TrimSpace(input.Query)
-	if query == "" {
-		slog.Info("validation failed: empty query", "usecase", "search")
-		return nil, &ValidationError{Message: "❌ 検索キーワードを入力してください。"}
+	query, err := validateQuery(input.Query)
+	if err != nil {
+		return nil, err
 	}
 
 	slog.Debug("search query received", "usecase", "search", "query", query)
@@ -55,6 +84,107 @@ func (u *SearchUseCase) SearchTracks(ctx context.Context, input SearchInput) (*S
 
 	return &SearchOutput{
 		Query:  query,
+		Type:   domain.SearchTypeTrack,
 		Tracks: tracks,
 	}, nil
 }
+
+// Search は指定した種別（トラック・アーティスト・アルバム・すべて）で検索します
+func (u *SearchUseCase) Search(ctx context.Context, input SearchInput) (*SearchOutput, error) {
+	searchType := input.Type
+	if searchType == "" || searchType == domain.SearchTypeTrack {
+		return u.SearchTracks(ctx, input)
+	}
+
+	query, err := validateQuery(input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("search query received", "usecase", "search", "query", query, "type", searchType)
+
+	output := &SearchOutput{Query: query, Type: searchType}
+	switch searchType {
+	case domain.SearchTypeArtist:
+		output.Artists, err = u.artistRepo.SearchArtists(ctx, query)
+	case domain.SearchTypeAlbum:
+		output.Albums, err = u.albumRepo.SearchAlbums(ctx, query)
+	case domain.SearchTypeAll:
+		output.Items, err = u.searchAll(ctx, query)
+	default:
+		slog.Info("validation failed: invalid search type", "usecase", "search", "type", searchType)
+		return nil, &ValidationError{Message: "❌ 検索の種別が正しくありません。"}
+	}
+	if err != nil {
+		slog.Warn("search failed", "usecase", "search", "query", query, "type", searchType, "error", err)
+		return nil, err
+	}
+
+	if output.Count() == 0 {
+		slog.Info("no results found", "usecase", "search", "query", query, "type", searchType)
+		return nil, &NotFoundError{Message: "🔍 該当する結果は見つかりませんでした。"}
+	}
+
+	slog.Info("search completed", "usecase", "search", "query", query, "type", searchType, "result_count", output.Count())
+
+	return output, nil
+}
+
+// searchAll はトラック・アーティスト・アルバムを並行して検索し、種別が交互に並ぶようにまとめます
+// 一部の種別の検索に失敗した場合は残りの結果を返し、すべて失敗した場合のみエラーを返します
+func (u *SearchUseCase) searchAll(ctx context.Context, query string) ([]domain.SearchItem, error) {
+	var (
+		wg      sync.WaitGroup
+		tracks  []domain.Track
+		artists []domain.ArtistDetail
+		albums  []domain.Album
+		errs    [3]error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		tracks, errs[0] = u.repo.SearchTracks(ctx, query)
+	}()
+	go func() {
+		defer wg.Done()
+		artists, errs[1] = u.artistRepo.SearchArtists(ctx, query)
+	}()
+	go func() {
+		defer wg.Done()
+		albums, errs[2] = u.albumRepo.SearchAlbums(ctx, query)
+	}()
+	wg.Wait()
+
+	if errs[0] != nil && errs[1] != nil && errs[2] != nil {
+		return nil, errs[0]
+	}
+	for i, searchType := range []domain.SearchType{domain.SearchTypeTrack, domain.SearchTypeArtist, domain.SearchTypeAlbum} {
+		if errs[i] != nil {
+			slog.Warn("partial search failed", "usecase", "search", "query", query, "type", searchType, "error", errs[i])
+		}
+	}
+
+	var items []domain.SearchItem
+	for i := range MaxSearchItemsPerType {
+		if i < len(tracks) {
+			items = append(items, domain.SearchItem{Type: domain.SearchTypeTrack, Track: &tracks[i]})
+		}
+		if i < len(artists) {
+			items = append(items, domain.SearchItem{Type: domain.SearchTypeArtist, Artist: &artists[i]})
+		}
+		if i < len(albums) {
+			items = append(items, domain.SearchItem{Type: domain.SearchTypeAlbum, Album: &albums[i]})
+		}
+	}
+	return items, nil
+}
+
+// validateQuery は検索キーワードの前後の空白を取り除き、空でないことを検証します
+func validateQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		slog.Info("validation failed: empty query", "usecase", "search")
+		return "", &ValidationError{Message: "❌ 検索キーワードを入力してください。"}
+	}
+	return query, nil
+}
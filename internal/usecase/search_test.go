@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/t1nyb0x/jamberry/internal/domain"
@@ -110,7 +111,7 @@ func TestSearchUseCase_SearchTracks(t *testing.T) {
 			repo := &mockTrackRepository{
 				searchTracksFunc: tt.mockFunc,
 			}
-			uc := NewSearchUseCase(repo)
+			uc := NewSearchUseCase(repo, &mockArtistRepository{}, &mockAlbumRepository{})
 
 			output, err := uc.SearchTracks(context.Background(), tt.input)
 
@@ -149,7 +150,7 @@ func TestSearchUseCase_SearchTracks(t *testing.T) {
 
 func TestNewSearchUseCase(t *testing.T) {
 	repo := &mockTrackRepository{}
-	uc := NewSearchUseCase(repo)
+	uc := NewSearchUseCase(repo, &mockArtistRepository{}, &mockAlbumRepository{})
 
 	if uc == nil {
 		t.Fatal("NewSearchUseCase returned nil")
@@ -158,3 +159,164 @@ func TestNewSearchUseCase(t *testing.T) {
 		t.Error("repo not set correctly")
 	}
 }
+
+func TestSearchUseCase_Search(t *testing.T) {
+	tracks := func(ctx context.Context, query string) ([]domain.Track, error) {
+		return []domain.Track{{ID: "t1", Name: "Track 1"}, {ID: "t2", Name: "Track 2"}}, nil
+	}
+	artists := func(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+		return []domain.ArtistDetail{{ID: "ar1", Name: "Artist 1"}}, nil
+	}
+	albums := func(ctx context.Context, query string) ([]domain.Album, error) {
+		return []domain.Album{{ID: "al1", Name: "Album 1"}, {ID: "al2", Name: "Album 2"}}, nil
+	}
+	failTracks := func(ctx context.Context, query string) ([]domain.Track, error) {
+		return nil, errors.New("API error")
+	}
+	failArtists := func(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+		return nil, errors.New("API error")
+	}
+	failAlbums := func(ctx context.Context, query string) ([]domain.Album, error) {
+		return nil, errors.New("API error")
+	}
+
+	tests := []struct {
+		name          string
+		searchType    domain.SearchType
+		searchTracks  func(ctx context.Context, query string) ([]domain.Track, error)
+		searchArtists func(ctx context.Context, query string) ([]domain.ArtistDetail, error)
+		searchAlbums  func(ctx context.Context, query string) ([]domain.Album, error)
+		wantErr       bool
+		errType       string
+		wantType      domain.SearchType
+		wantIDs       []string
+	}{
+		{
+			name:         "default is track",
+			searchTracks: tracks,
+			wantType:     domain.SearchTypeTrack,
+			wantIDs:      []string{"t1", "t2"},
+		},
+		{
+			name:          "artist",
+			searchType:    domain.SearchTypeArtist,
+			searchArtists: artists,
+			wantType:      domain.SearchTypeArtist,
+			wantIDs:       []string{"ar1"},
+		},
+		{
+			name:         "album",
+			searchType:   domain.SearchTypeAlbum,
+			searchAlbums: albums,
+			wantType:     domain.SearchTypeAlbum,
+			wantIDs:      []string{"al1", "al2"},
+		},
+		{
+			name:          "all interleaves types",
+			searchType:    domain.SearchTypeAll,
+			searchTracks:  tracks,
+			searchArtists: artists,
+			searchAlbums:  albums,
+			wantType:      domain.SearchTypeAll,
+			wantIDs:       []string{"t1", "ar1", "al1", "t2", "al2"},
+		},
+		{
+			name:          "all with partial failure",
+			searchType:    domain.SearchTypeAll,
+			searchTracks:  failTracks,
+			searchArtists: artists,
+			searchAlbums:  albums,
+			wantType:      domain.SearchTypeAll,
+			wantIDs:       []string{"ar1", "al1", "al2"},
+		},
+		{
+			name:          "all failed",
+			searchType:    domain.SearchTypeAll,
+			searchTracks:  failTracks,
+			searchArtists: failArtists,
+			searchAlbums:  failAlbums,
+			wantErr:       true,
+			errType:       "other",
+		},
+		{
+			name:       "artist not found",
+			searchType: domain.SearchTypeArtist,
+			searchArtists: func(ctx context.Context, query string) ([]domain.ArtistDetail, error) {
+				return nil, nil
+			},
+			wantErr: true,
+			errType: "notfound",
+		},
+		{
+			name:       "invalid type",
+			searchType: "playlist",
+			wantErr:    true,
+			errType:    "validation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewSearchUseCase(
+				&mockTrackRepository{searchTracksFunc: tt.searchTracks},
+				&mockArtistRepository{searchArtistsFunc: tt.searchArtists},
+				&mockAlbumRepository{searchAlbumsFunc: tt.searchAlbums},
+			)
+
+			output, err := uc.Search(context.Background(), SearchInput{Query: "query", Type: tt.searchType})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+				switch tt.errType {
+				case "validation":
+					if !IsValidationError(err) {
+						t.Errorf("expected ValidationError but got %T", err)
+					}
+				case "notfound":
+					if !IsNotFoundError(err) {
+						t.Errorf("expected NotFoundError but got %T", err)
+					}
+				default:
+					if IsValidationError(err) || IsNotFoundError(err) {
+						t.Errorf("expected repository error but got %T", err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if output.Type != tt.wantType {
+				t.Errorf("Type = %v, want %v", output.Type, tt.wantType)
+			}
+			var ids []string
+			for _, track := range output.Tracks {
+				ids = append(ids, track.ID)
+			}
+			for _, artist := range output.Artists {
+				ids = append(ids, artist.ID)
+			}
+			for _, album := range output.Albums {
+				ids = append(ids, album.ID)
+			}
+			for _, item := range output.Items {
+				switch item.Type {
+				case domain.SearchTypeTrack:
+					ids = append(ids, item.Track.ID)
+				case domain.SearchTypeArtist:
+					ids = append(ids, item.Artist.ID)
+				case domain.SearchTypeAlbum:
+					ids = append(ids, item.Album.ID)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("result IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if output.Count() != len(tt.wantIDs) {
+				t.Errorf("Count() = %d, want %d", output.Count(), len(tt.wantIDs))
+			}
+		})
+	}
+}